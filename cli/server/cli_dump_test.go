@@ -156,3 +156,39 @@ func TestDBDumpRestoreIncremental(t *testing.T) {
 	// Restore second 15 blocks from incremental dump.
 	e.Run(t, append(restoreBaseArgs, "--in", incDump, "-n", "--count", "15")...)
 }
+
+func TestDBSnapshot(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg, err := config.LoadFile(filepath.Join("..", "..", "config", "protocol.unit_testnet.yml"))
+	require.NoError(t, err, "could not load config")
+	cfg.ApplicationConfiguration.DBConfiguration.Type = dbconfig.LevelDB
+	cfg.ApplicationConfiguration.DBConfiguration.LevelDBOptions.DataDirectoryPath = filepath.Join(tmpDir, "neogotestchain")
+	out, err := yaml.Marshal(cfg)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "protocol.unit_testnet.yml"), out, os.ModePerm))
+
+	e := testcli.NewExecutor(t, false)
+	e.Run(t, "neo-go", "db", "restore", "--unittest", "--config-path", tmpDir, "--in", inDump)
+
+	snapshotPath := filepath.Join(tmpDir, "snapshot.bin")
+	t.Run("excessive parameters", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "db", "snapshot", "export", "--unittest", "--config-path", tmpDir,
+			"--out", snapshotPath, "something")
+	})
+	t.Run("state sync is not enabled", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "db", "snapshot", "export", "--unittest", "--config-path", tmpDir,
+			"--out", snapshotPath)
+	})
+	t.Run("no state root in header", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "db", "snapshot", "export", "--unittest", "--config-path", tmpDir,
+			"--out", snapshotPath, "--height", "10")
+	})
+	t.Run("import: missing file", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "db", "snapshot", "import", "--unittest", "--config-path", tmpDir,
+			"--in", filepath.Join(tmpDir, "unknown"))
+	})
+	t.Run("import: not a snapshot", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "db", "snapshot", "import", "--unittest", "--config-path", tmpDir,
+			"--in", inDump)
+	})
+}
@@ -81,6 +81,28 @@ func NewCommands() []cli.Command {
 		Usage:    "Height of the state to reset DB to",
 		Required: true,
 	}
+	var cfgSnapshotOutFlags = make([]cli.Flag, len(cfgFlags))
+	copy(cfgSnapshotOutFlags, cfgFlags)
+	cfgSnapshotOutFlags = append(cfgSnapshotOutFlags,
+		cli.StringFlag{
+			Name:     "out, o",
+			Usage:    "Output file",
+			Required: true,
+		},
+		cli.UintFlag{
+			Name:  "height",
+			Usage: "State sync point to export the state for (default: the latest one)",
+		},
+	)
+	var cfgSnapshotInFlags = make([]cli.Flag, len(cfgFlags))
+	copy(cfgSnapshotInFlags, cfgFlags)
+	cfgSnapshotInFlags = append(cfgSnapshotInFlags,
+		cli.StringFlag{
+			Name:     "in, i",
+			Usage:    "Input file",
+			Required: true,
+		},
+	)
 	return []cli.Command{
 		{
 			Name:      "node",
@@ -114,6 +136,40 @@ func NewCommands() []cli.Command {
 					Action:    resetDB,
 					Flags:     cfgHeightFlags,
 				},
+				{
+					Name:  "snapshot",
+					Usage: "state snapshot manipulations",
+					Subcommands: []cli.Command{
+						{
+							Name:      "export",
+							Usage:     "export contract storage state at the state sync point to the file",
+							UsageText: "neo-go db snapshot export -o file [--height height] [--config-path path] [-p/-m/-t] [--config-file file]",
+							Description: `Writes headers, the last MaxTraceableBlocks blocks, state root and all MPT
+   nodes for the given state sync point (a multiple of StateSyncInterval, the
+   latest available one by default) into a single archive. The state root
+   must be signed by state validators unless StateRootInHeader is enabled,
+   signed state roots and MPTs for every StateValidator designation are
+   required to prove its witness.
+`,
+							Action: exportSnapshot,
+							Flags:  cfgSnapshotOutFlags,
+						},
+						{
+							Name:      "import",
+							Usage:     "bootstrap an empty node from the state snapshot file",
+							UsageText: "neo-go db snapshot import -i file [--config-path path] [-p/-m/-t] [--config-file file]",
+							Description: `Restores the node state from the archive created by 'db snapshot export'.
+   Headers are verified by the chain, state root witness is checked against
+   state validators designated for its height (and against the next header
+   if StateRootInHeader is enabled) and every MPT node is checked against
+   this root. The node must have an empty DB and RemoveUntraceableBlocks
+   enabled, it continues with regular block synchronisation after import.
+`,
+							Action: importSnapshot,
+							Flags:  cfgSnapshotInFlags,
+						},
+					},
+				},
 			},
 		},
 	}
@@ -358,6 +414,96 @@ func resetDB(ctx *cli.Context) error {
 	return nil
 }
 
+func exportSnapshot(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	cfg, err := options.GetConfigFromContext(ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	log, _, logCloser, err := options.HandleLoggingParams(ctx.Bool("debug"), cfg.ApplicationConfiguration)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if logCloser != nil {
+		defer func() { _ = logCloser() }()
+	}
+
+	chain, prometheus, pprof, err := initBCWithMetrics(cfg, log)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		pprof.ShutDown()
+		prometheus.ShutDown()
+		chain.Close()
+	}()
+
+	module := chain.GetStateSyncModule()
+	h := uint32(ctx.Uint("height"))
+	if !ctx.IsSet("height") {
+		h, err = module.LatestSyncPoint()
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to get state sync point: %w", err), 1)
+		}
+	}
+
+	outStream, err := os.Create(ctx.String("out"))
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	defer outStream.Close()
+	writer := io.NewBinWriterFromIO(outStream)
+	err = module.DumpSnapshot(writer, h)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to export snapshot: %w", err), 1)
+	}
+	log.Info("snapshot exported", zap.Uint32("height", h))
+	return nil
+}
+
+func importSnapshot(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	cfg, err := options.GetConfigFromContext(ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	log, _, logCloser, err := options.HandleLoggingParams(ctx.Bool("debug"), cfg.ApplicationConfiguration)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if logCloser != nil {
+		defer func() { _ = logCloser() }()
+	}
+
+	inStream, err := os.Open(ctx.String("in"))
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	defer inStream.Close()
+	reader := io.NewBinReaderFromIO(inStream)
+
+	chain, prometheus, pprof, err := initBCWithMetrics(cfg, log)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		pprof.ShutDown()
+		prometheus.ShutDown()
+		chain.Close()
+	}()
+
+	err = chain.GetStateSyncModule().RestoreSnapshot(reader)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to import snapshot: %w", err), 1)
+	}
+	log.Info("snapshot imported", zap.Uint32("blockHeight", chain.BlockHeight()))
+	return nil
+}
+
 // oracleService is an interface representing Oracle service with network.Service
 // capabilities and ability to submit oracle responses.
 type oracleService interface {
@@ -496,6 +642,9 @@ func startServer(ctx *cli.Context) error {
 	}
 	errChan := make(chan error)
 	rpcServer := rpcsrv.New(chain, cfg.ApplicationConfiguration.RPC, serv, oracleSrv, log, errChan)
+	if p2pNotary != nil {
+		rpcServer.SetNotaryHandler(p2pNotary)
+	}
 	serv.AddService(&rpcServer)
 
 	serv.Start()
@@ -555,6 +704,9 @@ Main:
 				serv.DelService(&rpcServer)
 				rpcServer.Shutdown()
 				rpcServer = rpcsrv.New(chain, cfgnew.ApplicationConfiguration.RPC, serv, oracleSrv, log, errChan)
+				if p2pNotary != nil {
+					rpcServer.SetNotaryHandler(p2pNotary)
+				}
 				serv.AddService(&rpcServer)
 				if !cfgnew.ApplicationConfiguration.RPC.StartWhenSynchronized || serv.IsInSync() {
 					// Here similar to the initial run (see above for-loop), so async.
@@ -595,6 +747,7 @@ Main:
 				if p2pNotary != nil {
 					serv.DelService(p2pNotary)
 					chain.SetNotary(nil)
+					rpcServer.SetNotaryHandler(nil)
 					p2pNotary.Shutdown()
 				}
 				p2pNotary, err = mkP2PNotary(cfgnew.ApplicationConfiguration.P2PNotary, chain, serv, log)
@@ -602,8 +755,11 @@ Main:
 					log.Error("failed to create notary service", zap.Error(err))
 					break // Keep going.
 				}
-				if p2pNotary != nil && serv.IsInSync() {
-					p2pNotary.Start()
+				if p2pNotary != nil {
+					rpcServer.SetNotaryHandler(p2pNotary)
+					if serv.IsInSync() {
+						p2pNotary.Start()
+					}
 				}
 				serv.DelExtensibleService(sr, stateroot.Category)
 				srMod.SetUpdateValidatorsCallback(nil)
@@ -8,6 +8,7 @@ import (
 	"github.com/nspcc-dev/neo-go/cli/cmdargs"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/binding"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/rpcbinding"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/tsbinding"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/urfave/cli"
 	"gopkg.in/yaml.v3"
@@ -51,9 +52,19 @@ var generateWrapperCmd = cli.Command{
 var generateRPCWrapperCmd = cli.Command{
 	Name:      "generate-rpcwrapper",
 	Usage:     "generate RPC wrapper to use for data reads",
-	UsageText: "neo-go contract generate-rpcwrapper --manifest <file.json> --out <file.go> [--hash <hash>] [--config <config>]",
-	Action:    contractGenerateRPCWrapper,
-	Flags:     generatorFlags,
+	UsageText: "neo-go contract generate-rpcwrapper --manifest <file.json> --out <file.go> [--hash <hash>] [--config <config>] [--lang <lang>]",
+	Description: `Generates RPC wrapper to interact with the contract via RPC. Go (the
+   default) and TypeScript ("ts") wrappers are supported, they're generated
+   using the same configuration file.
+`,
+	Action: contractGenerateRPCWrapper,
+	Flags: append([]cli.Flag{
+		cli.StringFlag{
+			Name:  "lang",
+			Value: "go",
+			Usage: "Language of the generated wrapper: go or ts",
+		},
+	}, generatorFlags...),
 }
 
 func contractGenerateWrapper(ctx *cli.Context) error {
@@ -61,7 +72,14 @@ func contractGenerateWrapper(ctx *cli.Context) error {
 }
 
 func contractGenerateRPCWrapper(ctx *cli.Context) error {
-	return contractGenerateSomething(ctx, rpcbinding.Generate)
+	switch lang := ctx.String("lang"); lang {
+	case "go":
+		return contractGenerateSomething(ctx, rpcbinding.Generate)
+	case "ts":
+		return contractGenerateSomething(ctx, tsbinding.Generate)
+	default:
+		return cli.NewExitError(fmt.Errorf("unsupported language: %s", lang), 1)
+	}
 }
 
 // contractGenerateSomething reads generator parameters and calls the given callback.
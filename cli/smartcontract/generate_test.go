@@ -426,7 +426,7 @@ func (c *ContractReader) Get() (*big.Int, error) {
 }
 
 // rewriteExpectedOutputs denotes whether expected output files should be rewritten
-// for TestGenerateRPCBindings and TestAssistedRPCBindings.
+// for TestGenerateRPCBindings, TestAssistedRPCBindings and TestGenerateTSBindings.
 const rewriteExpectedOutputs = false
 
 func TestGenerateRPCBindings(t *testing.T) {
@@ -547,6 +547,75 @@ func TestAssistedRPCBindings(t *testing.T) {
 	require.False(t, rewriteExpectedOutputs)
 }
 
+func TestGenerateTSBindings(t *testing.T) {
+	tmpDir := t.TempDir()
+	app := cli.NewApp()
+	app.Commands = NewCommands()
+
+	var checkBinding = func(t *testing.T, expectedFile string, args ...string) {
+		outFile := filepath.Join(tmpDir, "out.ts")
+		require.NoError(t, app.Run(append([]string{"", "contract", "generate-rpcwrapper",
+			"--lang", "ts",
+			"--out", outFile,
+		}, args...)))
+
+		data, err := os.ReadFile(outFile)
+		require.NoError(t, err)
+		data = bytes.ReplaceAll(data, []byte("\r"), []byte{}) // Windows.
+		if rewriteExpectedOutputs {
+			require.NoError(t, os.WriteFile(expectedFile, data, os.ModePerm))
+		} else {
+			expected, err := os.ReadFile(expectedFile)
+			require.NoError(t, err)
+			expected = bytes.ReplaceAll(expected, []byte("\r"), []byte{}) // Windows.
+			require.Equal(t, string(expected), string(data))
+		}
+	}
+
+	t.Run("manifest", func(t *testing.T) {
+		checkBinding(t, filepath.Join("testdata", "nameservice", "nns.ts"),
+			"--manifest", filepath.Join("testdata", "nameservice", "nns.manifest.json"),
+			"--hash", "0x50ac1c37690cc2cfc594472833cf57505d5f46de")
+		checkBinding(t, filepath.Join("testdata", "verifyrpc", "verify.ts"),
+			"--manifest", filepath.Join("testdata", "verifyrpc", "verify.manifest.json"))
+	})
+	for _, name := range []string{"types", "structs", "notifications"} {
+		t.Run(name, func(t *testing.T) {
+			var (
+				source    = filepath.Join("testdata", "rpcbindings", name)
+				configF   = filepath.Join(source, "config.yml")
+				manifestF = filepath.Join(tmpDir, "manifest.json")
+				bindingF  = filepath.Join(tmpDir, "binding.yml")
+			)
+			if name == "notifications" {
+				configF = filepath.Join(source, "config_extended.yml")
+			}
+			require.NoError(t, app.Run([]string{"", "contract", "compile",
+				"--in", source,
+				"--config", configF,
+				"--manifest", manifestF,
+				"--bindings", bindingF,
+				"--out", filepath.Join(tmpDir, "out.nef"),
+			}))
+			checkBinding(t, filepath.Join(source, "rpcbindings.ts"),
+				"--config", bindingF,
+				"--manifest", manifestF,
+				"--hash", "0x00112233445566778899aabbccddeeff00112233")
+		})
+	}
+	t.Run("unsupported language", func(t *testing.T) {
+		app.ExitErrHandler = func(*cli.Context, error) {}
+		err := app.Run([]string{"", "contract", "generate-rpcwrapper",
+			"--lang", "rust",
+			"--manifest", filepath.Join("testdata", "verifyrpc", "verify.manifest.json"),
+			"--out", filepath.Join(tmpDir, "out.rs"),
+		})
+		require.ErrorContains(t, err, "unsupported language")
+	})
+
+	require.False(t, rewriteExpectedOutputs)
+}
+
 func TestGenerate_Errors(t *testing.T) {
 	app := cli.NewApp()
 	app.Commands = []cli.Command{generateWrapperCmd}
@@ -7,8 +7,11 @@ import (
 	"errors"
 	"fmt"
 	"github.com/google/uuid"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/nep11"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/unwrap"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
@@ -437,6 +440,21 @@ func (e *SetAdminEvent) FromStackItem(item *stackitem.Array) error {
 	return nil
 }
 
+// SubscribeSetAdmin subscribes to "SetAdmin" events emitted by the
+// contract using the given [rpcclient.WSClient].
+// Decoded events are sent to the provided channel, events that can't be
+// decoded are skipped. It returns subscription ID that can be used to
+// unsubscribe, see [rpcclient.WSClient] documentation for receiver channel
+// handling details.
+func SubscribeSetAdmin(ws *rpcclient.WSClient, ch chan<- *SetAdminEvent) (string, error) {
+	var hash = Hash
+	var name = "SetAdmin"
+	return rpcclient.ReceiveEvents(ws, &neorpc.NotificationFilter{Contract: &hash, Name: &name}, ch, func(ntf *state.ContainedNotificationEvent) (*SetAdminEvent, error) {
+		var e = new(SetAdminEvent)
+		return e, e.FromStackItem(ntf.Item)
+	})
+}
+
 // RenewEventsFromApplicationLog retrieves a set of all emitted events
 // with "Renew" name from the provided [result.ApplicationLog].
 func RenewEventsFromApplicationLog(log *result.ApplicationLog) ([]*RenewEvent, error) {
@@ -509,3 +527,18 @@ func (e *RenewEvent) FromStackItem(item *stackitem.Array) error {
 
 	return nil
 }
+
+// SubscribeRenew subscribes to "Renew" events emitted by the
+// contract using the given [rpcclient.WSClient].
+// Decoded events are sent to the provided channel, events that can't be
+// decoded are skipped. It returns subscription ID that can be used to
+// unsubscribe, see [rpcclient.WSClient] documentation for receiver channel
+// handling details.
+func SubscribeRenew(ws *rpcclient.WSClient, ch chan<- *RenewEvent) (string, error) {
+	var hash = Hash
+	var name = "Renew"
+	return rpcclient.ReceiveEvents(ws, &neorpc.NotificationFilter{Contract: &hash, Name: &name}, ch, func(ntf *state.ContainedNotificationEvent) (*RenewEvent, error) {
+		var e = new(RenewEvent)
+		return e, e.FromStackItem(ntf.Item)
+	})
+}
@@ -6,9 +6,12 @@ package nextoken
 import (
 	"errors"
 	"fmt"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/nep17"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/unwrap"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -337,3 +340,18 @@ func (e *OnMintEvent) FromStackItem(item *stackitem.Array) error {
 
 	return nil
 }
+
+// SubscribeOnMint subscribes to "OnMint" events emitted by the
+// contract using the given [rpcclient.WSClient].
+// Decoded events are sent to the provided channel, events that can't be
+// decoded are skipped. It returns subscription ID that can be used to
+// unsubscribe, see [rpcclient.WSClient] documentation for receiver channel
+// handling details.
+func SubscribeOnMint(ws *rpcclient.WSClient, ch chan<- *OnMintEvent) (string, error) {
+	var hash = Hash
+	var name = "OnMint"
+	return rpcclient.ReceiveEvents(ws, &neorpc.NotificationFilter{Contract: &hash, Name: &name}, ch, func(ntf *state.ContainedNotificationEvent) (*OnMintEvent, error) {
+		var e = new(OnMintEvent)
+		return e, e.FromStackItem(ntf.Item)
+	})
+}
@@ -6,8 +6,11 @@ package verify
 import (
 	"errors"
 	"fmt"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
@@ -145,3 +148,18 @@ func (e *HelloWorldEvent) FromStackItem(item *stackitem.Array) error {
 
 	return nil
 }
+
+// SubscribeHelloWorld subscribes to "Hello world!" events emitted by the
+// contract using the given [rpcclient.WSClient].
+// Decoded events are sent to the provided channel, events that can't be
+// decoded are skipped. It returns subscription ID that can be used to
+// unsubscribe, see [rpcclient.WSClient] documentation for receiver channel
+// handling details.
+func SubscribeHelloWorld(ws *rpcclient.WSClient, ch chan<- *HelloWorldEvent) (string, error) {
+	var hash = Hash
+	var name = "Hello world!"
+	return rpcclient.ReceiveEvents(ws, &neorpc.NotificationFilter{Contract: &hash, Name: &name}, ch, func(ntf *state.ContainedNotificationEvent) (*HelloWorldEvent, error) {
+		var e = new(HelloWorldEvent)
+		return e, e.FromStackItem(ntf.Item)
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/nspcc-dev/neo-go/cli/options"
 	"github.com/nspcc-dev/neo-go/cli/txctx"
 	vmcli "github.com/nspcc-dev/neo-go/cli/vm"
+	"github.com/nspcc-dev/neo-go/pkg/network"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/urfave/cli"
 )
@@ -27,6 +28,45 @@ func NewCommands() []cli.Command {
 		txctx.AwaitFlag,
 	}, options.RPC...)
 	txCancelFlags = append(txCancelFlags, options.Wallet...)
+	replayFlags := []cli.Flag{options.Config, options.ConfigFile, options.RelativePath}
+	replayFlags = append(replayFlags, options.Network...)
+	replayFlags = append(replayFlags, options.Debug,
+		cli.StringFlag{
+			Name:  "in, i",
+			Usage: "P2P capture file",
+		},
+		cli.StringFlag{
+			Name:  "peer",
+			Usage: "replay messages of the peer with the given address only",
+		},
+	)
+	netmapFlags := []cli.Flag{options.Config, options.ConfigFile, options.RelativePath}
+	netmapFlags = append(netmapFlags, options.Network...)
+	netmapFlags = append(netmapFlags,
+		cli.StringSliceFlag{
+			Name:  "seed, s",
+			Usage: "seed node address (can be repeated), overrides configured seeds",
+		},
+		cli.StringFlag{
+			Name:  "format, f",
+			Value: "json",
+			Usage: "output format (json or dot)",
+		},
+		cli.StringFlag{
+			Name:  "out, o",
+			Usage: "output file (standard output is used if not specified)",
+		},
+		cli.IntFlag{
+			Name:  "max-nodes",
+			Value: network.DefaultCrawlMaxNodes,
+			Usage: "maximum number of nodes to visit",
+		},
+		cli.DurationFlag{
+			Name:  "timeout",
+			Value: network.DefaultCrawlTimeout,
+			Usage: "time limit for a single node",
+		},
+	)
 	return []cli.Command{
 		{
 			Name:  "util",
@@ -103,6 +143,35 @@ func NewCommands() []cli.Command {
 						},
 					},
 				},
+				{
+					Name:      "p2p-replay",
+					Usage:     "Replay P2P messages from the capture file",
+					UsageText: "neo-go util p2p-replay -i file [--peer address] [--config-path path] [-p/-m/-t] [--config-file file] [-d]",
+					Description: `Replays incoming P2P messages recorded by the node with P2P.CaptureFile
+   setting to the local node isolated from the network (seeds are not used,
+   outgoing messages are not sent anywhere), it uses the node's configuration
+   and database. Messages are handled in the capture order as if they were
+   received from the captured peers, this allows to reproduce synchronization
+   issues with any given DB state. Capture network magic and StateRootInHeader
+   setting must match the node's configuration.
+`,
+					Action: p2pReplay,
+					Flags:  replayFlags,
+				},
+				{
+					Name:      "netmap",
+					Usage:     "Crawl the P2P network and build its map",
+					UsageText: "neo-go util netmap [-s address ...] [-f json|dot] [-o file] [--max-nodes n] [--timeout duration] [--config-path path] [-p/-m/-t] [--config-file file]",
+					Description: `Connects to the seed nodes (configured ones or specified with --seed flag)
+   over P2P, performs a handshake with each of them, requests their peers and
+   recursively crawls all addresses received. The resulting network map
+   contains node user agents, heights, capabilities, reachability and peer
+   lists, it's written as JSON or Graphviz DOT (--format dot). Network magic
+   is taken from the configuration, no local node is started.
+`,
+					Action: netmap,
+					Flags:  netmapFlags,
+				},
 			},
 		},
 	}
@@ -0,0 +1,68 @@
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"github.com/nspcc-dev/neo-go/cli/cmdargs"
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/pkg/network"
+	"github.com/urfave/cli"
+)
+
+func netmap(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	format := ctx.String("format")
+	if format != "json" && format != "dot" {
+		return cli.NewExitError(fmt.Errorf("unknown format: %s", format), 1)
+	}
+	cfg, err := options.GetConfigFromContext(ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	seeds := ctx.StringSlice("seed")
+	if len(seeds) == 0 {
+		seeds = cfg.ProtocolConfiguration.SeedList
+	}
+	if len(seeds) == 0 {
+		return cli.NewExitError("no seed nodes specified", 1)
+	}
+
+	var w io.Writer = ctx.App.Writer
+	if out := ctx.String("out"); out != "" {
+		f, err := os.Create(out)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	// Interrupted crawling still produces a (partial) map.
+	cctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	m := network.Crawl(cctx, network.CrawlConfig{
+		Magic:     cfg.ProtocolConfiguration.Magic,
+		Seeds:     seeds,
+		UserAgent: cfg.GenerateUserAgent(),
+		Timeout:   ctx.Duration("timeout"),
+		MaxNodes:  ctx.Int("max-nodes"),
+	})
+	if format == "dot" {
+		err = m.WriteDOT(w)
+	} else {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(m)
+	}
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	return nil
+}
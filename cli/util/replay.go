@@ -0,0 +1,92 @@
+package util
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/nspcc-dev/neo-go/cli/cmdargs"
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/network"
+	"github.com/urfave/cli"
+)
+
+func p2pReplay(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	in := ctx.String("in")
+	if in == "" {
+		return cli.NewExitError("no capture file specified", 1)
+	}
+	cfg, err := options.GetConfigFromContext(ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	log, _, logCloser, err := options.HandleLoggingParams(ctx.Bool("debug"), cfg.ApplicationConfiguration)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if logCloser != nil {
+		defer func() { _ = logCloser() }()
+	}
+
+	f, err := os.Open(in)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	defer f.Close()
+	r, err := network.NewCaptureReader(f)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	// Isolate the node from the network, everything it gets comes from the
+	// capture.
+	cfg.ProtocolConfiguration.SeedList = nil
+	cfg.ApplicationConfiguration.P2P.Addresses = []string{"127.0.0.1:0"}
+	cfg.ApplicationConfiguration.P2P.MinPeers = 0
+	cfg.ApplicationConfiguration.P2P.CaptureFile = ""
+
+	store, err := storage.NewStore(cfg.ApplicationConfiguration.DBConfiguration)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("could not initialize storage: %w", err), 1)
+	}
+	chain, err := core.NewBlockchain(store, cfg.Blockchain(), log)
+	if err != nil {
+		_ = store.Close()
+		return cli.NewExitError(fmt.Errorf("could not initialize blockchain: %w", err), 1)
+	}
+	go chain.Run()
+	defer chain.Close()
+
+	serverConfig, err := network.NewServerConfig(cfg)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	serv, err := network.NewServer(serverConfig, chain, chain.GetStateSyncModule(), log)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to create network server: %w", err), 1)
+	}
+	serv.Start()
+	defer serv.Shutdown()
+
+	var filter func(*network.CaptureRecord) bool
+	if peer := ctx.String("peer"); peer != "" {
+		filter = func(rec *network.CaptureRecord) bool {
+			return rec.PeerAddr == peer
+		}
+	}
+	start := chain.BlockHeight()
+	stats, err := serv.Replay(r, filter)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("replay failed: %w", err), 1)
+	}
+	fmt.Fprintf(ctx.App.Writer, "Peers: %d\n", stats.Peers)
+	fmt.Fprintf(ctx.App.Writer, "Records: %d\n", stats.Records)
+	fmt.Fprintf(ctx.App.Writer, "Messages: %d\n", stats.Messages)
+	fmt.Fprintf(ctx.App.Writer, "Errors: %d\n", stats.Errors)
+	fmt.Fprintf(ctx.App.Writer, "Height: %d -> %d\n", start, chain.BlockHeight())
+	return nil
+}
@@ -1,7 +1,9 @@
 package util_test
 
 import (
+	"encoding/json"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"strings"
@@ -9,6 +11,11 @@ import (
 	"time"
 
 	"github.com/nspcc-dev/neo-go/internal/testcli"
+	"github.com/nspcc-dev/neo-go/internal/testserdes"
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/network"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
@@ -190,3 +197,98 @@ func TestAwaitUtilCancelTx(t *testing.T) {
 		t.Fatal(fmt.Errorf("unexpected error: %w", err))
 	}
 }
+
+func TestUtilP2PReplay(t *testing.T) {
+	e := testcli.NewExecutor(t, false)
+	tmp := t.TempDir()
+
+	// Put the first ten blocks of the test chain dump into the capture.
+	dump, err := os.Open(filepath.Join("..", "server", "testdata", "chain50x2.acc"))
+	require.NoError(t, err)
+	defer dump.Close()
+	r := io.NewBinReaderFromIO(dump)
+	_ = r.ReadU32LE() // Number of blocks.
+	capPath := filepath.Join(tmp, "capture")
+	writeCapture := func(t *testing.T, magic netmode.Magic, count int) {
+		f, err := os.Create(capPath)
+		require.NoError(t, err)
+		w, err := network.NewCaptureWriter(f, magic, false)
+		require.NoError(t, err)
+		for i := 0; i < count; i++ {
+			buf := make([]byte, r.ReadU32LE())
+			r.ReadBytes(buf)
+			require.NoError(t, r.Err)
+			b := block.New(false)
+			require.NoError(t, testserdes.DecodeBinary(buf, b))
+			if b.Index == 0 {
+				continue
+			}
+			data, err := network.NewMessage(network.CMDBlock, b).Bytes()
+			require.NoError(t, err)
+			require.NoError(t, w.Write(&network.CaptureRecord{
+				Time:      time.Now(),
+				Direction: network.CaptureIn,
+				PeerID:    1,
+				PeerAddr:  "1.2.3.4:20333",
+				Data:      data,
+			}))
+		}
+		require.NoError(t, w.Close())
+	}
+	baseArgs := []string{"neo-go", "util", "p2p-replay", "--unittest", "--config-path", "../../config"}
+
+	e.RunWithError(t, baseArgs...) // No capture file.
+	e.RunWithError(t, append(baseArgs, "--in", filepath.Join(tmp, "missing"))...)
+
+	writeCapture(t, netmode.PrivNet, 0)
+	e.RunWithError(t, append(baseArgs, "--in", capPath)...) // Magic mismatch.
+
+	writeCapture(t, netmode.UnitTestNet, 11)
+	e.Run(t, append(baseArgs, "--in", capPath, "--peer", "5.6.7.8:20333")...)
+	e.CheckNextLine(t, "Peers: 0")
+	e.CheckNextLine(t, "Records: 0")
+	e.CheckNextLine(t, "Messages: 0")
+	e.CheckNextLine(t, "Errors: 0")
+	e.CheckNextLine(t, "Height: 0 -> 0")
+	e.CheckEOF(t)
+
+	e.Run(t, append(baseArgs, "--in", capPath)...)
+	e.CheckNextLine(t, "Peers: 1")
+	e.CheckNextLine(t, "Records: 10")
+	e.CheckNextLine(t, "Messages: 10")
+	e.CheckNextLine(t, "Errors: 0")
+	e.CheckNextLine(t, "Height: 0 -> 10")
+	e.CheckEOF(t)
+}
+
+func TestUtilNetmap(t *testing.T) {
+	e := testcli.NewExecutor(t, false)
+	tmp := t.TempDir()
+
+	// Reserve an address nobody listens on.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	dead := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	baseArgs := []string{"neo-go", "util", "netmap", "--unittest", "--config-path", "../../config", "--timeout", "1s", "--seed", dead}
+	e.RunWithError(t, append(baseArgs, "--format", "xml")...)
+
+	e.Run(t, baseArgs...)
+	var m network.NetMap
+	require.NoError(t, json.Unmarshal(e.Out.Bytes(), &m))
+	e.Out.Reset()
+	require.Equal(t, netmode.UnitTestNet, m.Magic)
+	require.Equal(t, 1, len(m.Nodes))
+	require.Equal(t, dead, m.Nodes[0].Address)
+	require.False(t, m.Nodes[0].Reachable)
+	require.NotEmpty(t, m.Nodes[0].Error)
+
+	out := filepath.Join(tmp, "netmap.dot")
+	e.Run(t, append(baseArgs, "--format", "dot", "--out", out)...)
+	e.CheckEOF(t)
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(string(data), "digraph netmap {\n"))
+	require.Contains(t, string(data), " style=dashed];\n")
+}
@@ -0,0 +1,251 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/cli/cmdargs"
+	"github.com/nspcc-dev/neo-go/cli/flags"
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/cli/txctx"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/actor"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/gas"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/notary"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/urfave/cli"
+)
+
+func newNotaryCommands() []cli.Command {
+	return []cli.Command{
+		{
+			Name:      "deposit",
+			Usage:     "deposit GAS to the Notary contract",
+			UsageText: "deposit -w <path> -r <rpc> -a <addr> --amount <amount> [--to <addr>] [--till <index>] [-g gas] [-e sysgas] [--out file] [--force] [--await]",
+			Description: `Transfers the specified amount of GAS from the given account to the
+   Notary contract deposit of the --to account (which is the same account
+   by default). Deposit is needed to pay for fallback transactions of notary
+   requests. --till specifies the block index the deposit is locked till, by
+   default it's the current height + 5760 blocks (but not less than the
+   current deposit lock). Notice that only the deposit owner can change it.
+`,
+			Action: depositNotary,
+			Flags: append([]cli.Flag{
+				walletPathFlag,
+				walletConfigFlag,
+				txctx.GasFlag,
+				txctx.SysGasFlag,
+				txctx.OutFlag,
+				txctx.ForceFlag,
+				txctx.AwaitFlag,
+				flags.AddressFlag{
+					Name:  "address, a",
+					Usage: "Address to deposit GAS from",
+				},
+				flags.AddressFlag{
+					Name:  "to",
+					Usage: "Address to deposit GAS to (the sender by default)",
+				},
+				cli.StringFlag{
+					Name:  "amount",
+					Usage: "Amount of GAS to deposit",
+				},
+				cli.UintFlag{
+					Name:  "till",
+					Usage: "Block index the deposit is locked till",
+				},
+			}, options.RPC...),
+		},
+		{
+			Name:      "withdraw",
+			Usage:     "withdraw GAS from the Notary contract",
+			UsageText: "withdraw -w <path> -r <rpc> -a <addr> [--to <addr>] [-g gas] [-e sysgas] [--out file] [--force] [--await]",
+			Description: `Withdraws the whole Notary contract deposit of the given account to the
+   --to account (which is the same account by default). Deposit can only be
+   withdrawn after its lock expires.
+`,
+			Action: withdrawNotary,
+			Flags: append([]cli.Flag{
+				walletPathFlag,
+				walletConfigFlag,
+				txctx.GasFlag,
+				txctx.SysGasFlag,
+				txctx.OutFlag,
+				txctx.ForceFlag,
+				txctx.AwaitFlag,
+				flags.AddressFlag{
+					Name:  "address, a",
+					Usage: "Address to withdraw deposit of",
+				},
+				flags.AddressFlag{
+					Name:  "to",
+					Usage: "Address to withdraw GAS to (the deposit owner by default)",
+				},
+			}, options.RPC...),
+		},
+		{
+			Name:      "status",
+			Usage:     "show Notary contract deposit status",
+			UsageText: "status -w <path> -r <rpc> [-a <addr>]",
+			Description: `Prints Notary contract deposit balance and lock height for the given
+   account or for all wallet accounts if no address is specified.
+`,
+			Action: notaryStatus,
+			Flags: append([]cli.Flag{
+				walletPathFlag,
+				walletConfigFlag,
+				flags.AddressFlag{
+					Name:  "address, a",
+					Usage: "Address to show deposit status for",
+				},
+			}, options.RPC...),
+		},
+	}
+}
+
+func depositNotary(ctx *cli.Context) error {
+	amount, err := fixedn.FromString(ctx.String("amount"), 8)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("invalid amount: %w", err), 1)
+	}
+	if amount.Sign() <= 0 {
+		return cli.NewExitError(errors.New("amount must be positive"), 1)
+	}
+	return handleNotaryAction(ctx, func(act *actor.Actor, from util.Uint160, to util.Uint160) (*transaction.Transaction, error) {
+		till := uint32(ctx.Uint("till"))
+		if till == 0 {
+			height, err := act.GetBlockCount()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get block count: %w", err)
+			}
+			till = height + notary.DefaultDepositLockTill
+			expiration, err := notary.NewReader(act).ExpirationOf(to)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get deposit expiration: %w", err)
+			}
+			if till < expiration {
+				till = expiration
+			}
+		}
+		return gas.New(act).TransferUnsigned(from, notary.Hash, amount, &notary.OnNEP17PaymentData{Account: &to, Till: till})
+	})
+}
+
+func withdrawNotary(ctx *cli.Context) error {
+	return handleNotaryAction(ctx, func(act *actor.Actor, from util.Uint160, to util.Uint160) (*transaction.Transaction, error) {
+		return notary.New(act).WithdrawUnsigned(from, to)
+	})
+}
+
+func handleNotaryAction(ctx *cli.Context, mkTx func(*actor.Actor, util.Uint160, util.Uint160) (*transaction.Transaction, error)) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	wall, pass, err := readWallet(ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	defer wall.Close()
+
+	addrFlag := ctx.Generic("address").(*flags.Address)
+	if !addrFlag.IsSet {
+		return cli.NewExitError("address was not provided", 1)
+	}
+	addr := addrFlag.Uint160()
+	to := addr
+	toFlag := ctx.Generic("to").(*flags.Address)
+	if toFlag.IsSet {
+		to = toFlag.Uint160()
+	}
+	acc, err := options.GetUnlockedAccount(wall, addr, pass)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+
+	signers, err := cmdargs.GetSignersAccounts(acc, wall, nil, transaction.CalledByEntry)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("invalid signers: %w", err), 1)
+	}
+	_, act, exitErr := options.GetRPCWithActor(gctx, ctx, signers)
+	if exitErr != nil {
+		return exitErr
+	}
+
+	tx, err := mkTx(act, addr, to)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	return txctx.SignAndSend(ctx, act, acc, tx)
+}
+
+func notaryStatus(ctx *cli.Context) error {
+	var accounts []*wallet.Account
+
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	wall, _, err := readWallet(ctx)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("bad wallet: %w", err), 1)
+	}
+	defer wall.Close()
+
+	addrFlag := ctx.Generic("address").(*flags.Address)
+	if addrFlag.IsSet {
+		addrHash := addrFlag.Uint160()
+		acc := wall.GetAccount(addrHash)
+		if acc == nil {
+			return cli.NewExitError(fmt.Errorf("can't find account for the address: %s", address.Uint160ToString(addrHash)), 1)
+		}
+		accounts = append(accounts, acc)
+	} else {
+		if len(wall.Accounts) == 0 {
+			return cli.NewExitError(errors.New("no accounts in the wallet"), 1)
+		}
+		accounts = wall.Accounts
+	}
+
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+
+	c, inv, exitErr := options.GetRPCWithInvoker(gctx, ctx, nil)
+	if exitErr != nil {
+		return exitErr
+	}
+	height, err := c.GetBlockCount()
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to get block count: %w", err), 1)
+	}
+	reader := notary.NewReader(inv)
+	for k, acc := range accounts {
+		if k != 0 {
+			fmt.Fprintln(ctx.App.Writer)
+		}
+		h := acc.ScriptHash()
+		balance, err := reader.BalanceOf(h)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to get deposit balance for %s: %w", acc.Address, err), 1)
+		}
+		expiration, err := reader.ExpirationOf(h)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to get deposit expiration for %s: %w", acc.Address, err), 1)
+		}
+		fmt.Fprintf(ctx.App.Writer, "Account %s\n", acc.Address)
+		fmt.Fprintf(ctx.App.Writer, "\tDeposit: %s GAS\n", fixedn.ToString(balance, 8))
+		if balance.Sign() == 0 {
+			continue
+		}
+		var lockState = "locked"
+		if expiration < height {
+			lockState = "expired"
+		}
+		fmt.Fprintf(ctx.App.Writer, "\tLocked till: %d (%s)\n", expiration, lockState)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package wallet_test
+
+import (
+	"math/big"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/internal/testcli"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotaryDeposit(t *testing.T) {
+	e := testcli.NewExecutor(t, true)
+
+	validatorHash, err := address.StringToUint160(testcli.ValidatorAddr)
+	require.NoError(t, err)
+	args := []string{
+		"--rpc-endpoint", "http://" + e.RPC.Addresses()[0],
+		"--wallet", testcli.ValidatorWallet,
+		"--address", testcli.ValidatorAddr,
+	}
+
+	t.Run("missing address", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "notary", "deposit",
+			"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+			"--wallet", testcli.ValidatorWallet,
+			"--amount", "1")
+	})
+	t.Run("bad amount", func(t *testing.T) {
+		e.RunWithError(t, append([]string{"neo-go", "wallet", "notary", "deposit", "--amount", "-1"}, args...)...)
+		e.RunWithError(t, append([]string{"neo-go", "wallet", "notary", "deposit", "--amount", "one"}, args...)...)
+	})
+
+	e.Run(t, append([]string{"neo-go", "wallet", "notary", "status"}, args...)...)
+	e.CheckNextLine(t, "^Account "+testcli.ValidatorAddr+"$")
+	e.CheckNextLine(t, "^\\s*Deposit:\\s+0 GAS$")
+	e.CheckEOF(t)
+
+	till := e.Chain.BlockHeight() + 5
+	e.In.WriteString("one\r")
+	e.Run(t, append([]string{"neo-go", "wallet", "notary", "deposit",
+		"--amount", "10",
+		"--till", strconv.FormatUint(uint64(till), 10),
+		"--force"}, args...)...)
+	e.CheckTxPersisted(t)
+	require.Equal(t, big.NewInt(10_0000_0000), e.Chain.GetNotaryBalance(validatorHash))
+
+	e.Run(t, append([]string{"neo-go", "wallet", "notary", "status"}, args...)...)
+	e.CheckNextLine(t, "^Account "+testcli.ValidatorAddr+"$")
+	e.CheckNextLine(t, "^\\s*Deposit:\\s+10 GAS$")
+	e.CheckNextLine(t, "^\\s*Locked till:\\s+"+strconv.FormatUint(uint64(till), 10)+" \\(locked\\)$")
+	e.CheckEOF(t)
+
+	if e.Chain.BlockHeight() < till {
+		// Deposit is still locked.
+		e.In.WriteString("one\r")
+		e.RunWithError(t, append([]string{"neo-go", "wallet", "notary", "withdraw", "--force"}, args...)...)
+	}
+
+	require.Eventually(t, func() bool { return e.Chain.BlockHeight() > till }, 5*time.Second, 50*time.Millisecond)
+	gasBefore := e.Chain.GetUtilityTokenBalance(validatorHash)
+	e.In.WriteString("one\r")
+	e.Run(t, append([]string{"neo-go", "wallet", "notary", "withdraw", "--force"}, args...)...)
+	e.CheckTxPersisted(t)
+	require.Equal(t, 0, e.Chain.GetNotaryBalance(validatorHash).Sign())
+	require.Equal(t, 1, e.Chain.GetUtilityTokenBalance(validatorHash).Cmp(gasBefore))
+}
@@ -342,6 +342,11 @@ func NewCommands() []cli.Command {
 				Usage:       "work with candidates",
 				Subcommands: newValidatorCommands(),
 			},
+			{
+				Name:        "notary",
+				Usage:       "work with Notary contract deposits",
+				Subcommands: newNotaryCommands(),
+			},
 		},
 	}}
 }
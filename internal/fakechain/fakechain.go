@@ -26,6 +26,7 @@ type FakeChain struct {
 	*mempool.Pool
 	blocksCh                 []chan *block.Block
 	Blockheight              atomic.Uint32
+	Headerheight             atomic.Uint32
 	PoolTxF                  func(*transaction.Transaction) error
 	poolTxWithData           func(*transaction.Transaction, any, *mempool.Pool) error
 	blocks                   map[util.Uint256]*block.Block
@@ -178,8 +179,18 @@ func (chain *FakeChain) P2PSigExtensionsEnabled() bool {
 }
 
 // AddHeaders implements the Blockchainer interface.
-func (chain *FakeChain) AddHeaders(...*block.Header) error {
-	panic("TODO")
+func (chain *FakeChain) AddHeaders(hdrs ...*block.Header) error {
+	for _, h := range hdrs {
+		if h.Index <= chain.HeaderHeight() {
+			continue
+		}
+		if h.Index != chain.HeaderHeight()+1 {
+			return errors.New("previous header was not found")
+		}
+		chain.hdrHashes[h.Index] = h.Hash()
+		chain.Headerheight.Store(h.Index)
+	}
+	return nil
 }
 
 // AddBlock implements the Blockchainer interface.
@@ -197,6 +208,9 @@ func (chain *FakeChain) BlockHeight() uint32 {
 
 // HeaderHeight implements the Blockchainer interface.
 func (chain *FakeChain) HeaderHeight() uint32 {
+	if h := chain.Headerheight.Load(); h > chain.Blockheight.Load() {
+		return h
+	}
 	return chain.Blockheight.Load()
 }
 
@@ -49,17 +49,22 @@ func (a *ApplicationConfiguration) EqualsButServices(o *ApplicationConfiguration
 			return false
 		}
 	}
-	if a.P2P.AttemptConnPeers != o.P2P.AttemptConnPeers ||
+	if a.P2P.AnnounceCompactBlocks != o.P2P.AnnounceCompactBlocks ||
+		a.P2P.AttemptConnPeers != o.P2P.AttemptConnPeers ||
 		a.P2P.BroadcastFactor != o.P2P.BroadcastFactor ||
+		a.P2P.CaptureFile != o.P2P.CaptureFile ||
+		a.P2P.CompactBlocks != o.P2P.CompactBlocks ||
 		a.DBConfiguration != o.DBConfiguration ||
 		a.P2P.DialTimeout != o.P2P.DialTimeout ||
 		a.P2P.ExtensiblePoolSize != o.P2P.ExtensiblePoolSize ||
+		a.P2P.HeadersFirstSync != o.P2P.HeadersFirstSync ||
 		a.LogPath != o.LogPath ||
 		a.P2P.MaxPeers != o.P2P.MaxPeers ||
 		a.P2P.MinPeers != o.P2P.MinPeers ||
 		a.P2P.PingInterval != o.P2P.PingInterval ||
 		a.P2P.PingTimeout != o.P2P.PingTimeout ||
 		a.P2P.ProtoTickInterval != o.P2P.ProtoTickInterval ||
+		!a.P2P.RateLimits.Equals(o.P2P.RateLimits) ||
 		a.Relay != o.Relay {
 		return false
 	}
@@ -4,9 +4,13 @@ package config
 // a part of the ProtocolConfiguration (which is common for every node on the
 // network).
 type Ledger struct {
+	// GarbageCollectionBudget sets the maximum number of stale MPT nodes
+	// removed per block by the incremental MPT garbage collector when
+	// RemoveUntraceableBlocks option is used.
+	GarbageCollectionBudget uint32 `yaml:"GarbageCollectionBudget"`
 	// GarbageCollectionPeriod sets the number of blocks to wait before
-	// starting the next MPT garbage collection cycle when RemoveUntraceableBlocks
-	// option is used.
+	// starting the next old token transfer logs removal cycle when
+	// RemoveUntraceableBlocks option is used.
 	GarbageCollectionPeriod uint32 `yaml:"GarbageCollectionPeriod"`
 	// KeepOnlyLatestState specifies if MPT should only store the latest state.
 	// If true, DB size will be smaller, but older roots won't be accessible.
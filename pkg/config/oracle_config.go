@@ -4,17 +4,22 @@ import "time"
 
 // OracleConfiguration is a config for the oracle module.
 type OracleConfiguration struct {
-	Enabled               bool               `yaml:"Enabled"`
-	AllowPrivateHost      bool               `yaml:"AllowPrivateHost"`
-	AllowedContentTypes   []string           `yaml:"AllowedContentTypes"`
-	Nodes                 []string           `yaml:"Nodes"`
-	NeoFS                 NeoFSConfiguration `yaml:"NeoFS"`
-	MaxTaskTimeout        time.Duration      `yaml:"MaxTaskTimeout"`
-	RefreshInterval       time.Duration      `yaml:"RefreshInterval"`
-	MaxConcurrentRequests int                `yaml:"MaxConcurrentRequests"`
-	RequestTimeout        time.Duration      `yaml:"RequestTimeout"`
-	ResponseTimeout       time.Duration      `yaml:"ResponseTimeout"`
-	UnlockWallet          Wallet             `yaml:"UnlockWallet"`
+	Enabled                   bool                      `yaml:"Enabled"`
+	AllowPrivateHost          bool                      `yaml:"AllowPrivateHost"`
+	AllowedContentTypes       []string                  `yaml:"AllowedContentTypes"`
+	Nodes                     []string                  `yaml:"Nodes"`
+	NeoFS                     NeoFSConfiguration        `yaml:"NeoFS"`
+	IPFS                      IPFSConfiguration         `yaml:"IPFS"`
+	HTTP                      OracleHTTPConfiguration   `yaml:"HTTP"`
+	NeoRPC                    OracleNeoRPCConfiguration `yaml:"NeoRPC"`
+	MaxTaskTimeout            time.Duration             `yaml:"MaxTaskTimeout"`
+	RefreshInterval           time.Duration             `yaml:"RefreshInterval"`
+	MaxConcurrentRequests     int                       `yaml:"MaxConcurrentRequests"`
+	MaxConcurrentHostRequests int                       `yaml:"MaxConcurrentHostRequests"`
+	RequestTimeout            time.Duration             `yaml:"RequestTimeout"`
+	ResponseCacheTTL          time.Duration             `yaml:"ResponseCacheTTL"`
+	ResponseTimeout           time.Duration             `yaml:"ResponseTimeout"`
+	UnlockWallet              Wallet                    `yaml:"UnlockWallet"`
 }
 
 // NeoFSConfiguration is a config for the NeoFS service.
@@ -22,3 +27,43 @@ type NeoFSConfiguration struct {
 	Nodes   []string      `yaml:"Nodes"`
 	Timeout time.Duration `yaml:"Timeout"`
 }
+
+// OracleFetcherConfiguration contains limits applied to oracle requests with
+// a particular URI scheme.
+type OracleFetcherConfiguration struct {
+	// Timeout is the request timeout, RequestTimeout is used if not set.
+	Timeout time.Duration `yaml:"Timeout"`
+	// MaxResponseSize is the maximum response size, it can't exceed the
+	// protocol limit which is used if not set.
+	MaxResponseSize int `yaml:"MaxResponseSize"`
+	// AllowedContentTypes is a list of allowed MIME types, the global
+	// AllowedContentTypes setting is used if not set.
+	AllowedContentTypes []string `yaml:"AllowedContentTypes"`
+}
+
+// IPFSConfiguration is a config for the ipfs:// oracle URI scheme.
+type IPFSConfiguration struct {
+	OracleFetcherConfiguration `yaml:",inline"`
+	// Gateway is the IPFS HTTP gateway URL, the scheme is enabled when
+	// it's set.
+	Gateway string `yaml:"Gateway"`
+}
+
+// OracleHTTPConfiguration is a config for the http:// oracle URI scheme.
+type OracleHTTPConfiguration struct {
+	OracleFetcherConfiguration `yaml:",inline"`
+	// AllowedHosts is a list of hosts (including private ones) allowed to be
+	// requested, the scheme is enabled when it's not empty.
+	AllowedHosts []string `yaml:"AllowedHosts"`
+}
+
+// OracleNeoRPCConfiguration is a config for the neo-rpc:// oracle URI scheme.
+type OracleNeoRPCConfiguration struct {
+	OracleFetcherConfiguration `yaml:",inline"`
+	// Endpoints maps network names used in URIs to RPC node URLs, the scheme
+	// is enabled when it's not empty.
+	Endpoints map[string]string `yaml:"Endpoints"`
+	// Methods is a list of allowed RPC methods, a set of read-only methods
+	// is used if not set.
+	Methods []string `yaml:"Methods"`
+}
@@ -5,15 +5,60 @@ import "time"
 // P2P holds P2P node settings.
 type P2P struct {
 	// Addresses stores the node address list in the form of "[host]:[port][:announcedPort]".
-	Addresses        []string `yaml:"Addresses"`
-	AttemptConnPeers int      `yaml:"AttemptConnPeers"`
+	Addresses []string `yaml:"Addresses"`
+	// AnnounceCompactBlocks makes the node advertise compact block relay
+	// capability, it only has effect if CompactBlocks is enabled.
+	AnnounceCompactBlocks bool `yaml:"AnnounceCompactBlocks"`
+	AttemptConnPeers      int  `yaml:"AttemptConnPeers"`
 	// BroadcastFactor is the factor (0-100) controlling gossip fan-out number optimization.
 	BroadcastFactor    int           `yaml:"BroadcastFactor"`
+	CaptureFile        string        `yaml:"CaptureFile"`
+	CompactBlocks      bool          `yaml:"CompactBlocks"`
 	DialTimeout        time.Duration `yaml:"DialTimeout"`
 	ExtensiblePoolSize int           `yaml:"ExtensiblePoolSize"`
+	HeadersFirstSync   bool          `yaml:"HeadersFirstSync"`
 	MaxPeers           int           `yaml:"MaxPeers"`
 	MinPeers           int           `yaml:"MinPeers"`
 	PingInterval       time.Duration `yaml:"PingInterval"`
 	PingTimeout        time.Duration `yaml:"PingTimeout"`
 	ProtoTickInterval  time.Duration `yaml:"ProtoTickInterval"`
+	RateLimits         RateLimits    `yaml:"RateLimits"`
+}
+
+// RateLimits holds per-peer P2P message rate limiting settings.
+type RateLimits struct {
+	// Enabled turns rate limiting on.
+	Enabled bool `yaml:"Enabled"`
+	// BanScore is the misbehaviour score after which the peer is
+	// disconnected and banned.
+	BanScore int `yaml:"BanScore"`
+	// BanTime is the duration of the ban.
+	BanTime time.Duration `yaml:"BanTime"`
+	// Commands maps P2P command name (like "inv" or "getdata") to its limit.
+	// These limits override the default ones.
+	Commands map[string]RateLimit `yaml:"Commands"`
+	// Peer is the limit for all messages received from a single peer.
+	Peer RateLimit `yaml:"Peer"`
+}
+
+// RateLimit is a token bucket rate limit. Zero Rate means no limit.
+type RateLimit struct {
+	// Rate is the number of messages per second allowed.
+	Rate float64 `yaml:"Rate"`
+	// Burst is the number of messages allowed to be received at once.
+	Burst int `yaml:"Burst"`
+}
+
+// Equals checks whether two RateLimits configurations are the same.
+func (r RateLimits) Equals(o RateLimits) bool {
+	if r.Enabled != o.Enabled || r.BanScore != o.BanScore || r.BanTime != o.BanTime ||
+		r.Peer != o.Peer || len(r.Commands) != len(o.Commands) {
+		return false
+	}
+	for cmd, l := range r.Commands {
+		if ol, ok := o.Commands[cmd]; !ok || ol != l {
+			return false
+		}
+	}
+	return true
 }
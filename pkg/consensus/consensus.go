@@ -38,6 +38,9 @@ const defaultTimePerBlock = 15 * time.Second
 // Number of nanoseconds in millisecond.
 const nsInMs = 1000000
 
+// ServiceName is the name of the consensus service returned by Name.
+const ServiceName = "consensus"
+
 // Ledger is the interface to Blockchain sufficient for Service.
 type Ledger interface {
 	ApplyPolicyToTxSet([]*transaction.Transaction) []*transaction.Transaction
@@ -80,6 +83,14 @@ type Service interface {
 	OnTransaction(tx *transaction.Transaction)
 }
 
+// TimelineReporter is an optional interface implemented by the Service
+// instances that record round timelines (the one returned by NewService does).
+type TimelineReporter interface {
+	// Timeline returns consensus messages of the current round and several
+	// previous ones, the oldest round goes first.
+	Timeline() []RoundTimeline
+}
+
 type service struct {
 	Config
 
@@ -107,6 +118,8 @@ type service struct {
 	// before the block is accepted. So, in case of change view, it will contain
 	// an updated value.
 	lastTimestamp uint64
+	// timeline records consensus messages of the recent rounds.
+	timeline timeline
 }
 
 // Config is a configuration for consensus services.
@@ -133,6 +146,10 @@ type Config struct {
 	// Wallet is a local-node wallet configuration. If the path is empty, then
 	// no wallet will be initialized and the service will be in watch-only mode.
 	Wallet config.Wallet
+	// Timer is a dBFT timer which is also used as a source of the current
+	// time by the service. If not set, the default one based on the system
+	// clock is used, custom timers are useful for tests.
+	Timer dbft.Timer
 }
 
 // NewService returns a new consensus.Service instance.
@@ -144,6 +161,9 @@ func NewService(cfg Config) (Service, error) {
 	if cfg.Logger == nil {
 		return nil, errors.New("empty logger")
 	}
+	if cfg.Timer == nil {
+		cfg.Timer = timer.New()
+	}
 
 	srv := &service{
 		Config: cfg,
@@ -180,7 +200,7 @@ func NewService(cfg Config) (Service, error) {
 	}
 
 	srv.dbft, err = dbft.New[util.Uint256](
-		dbft.WithTimer[util.Uint256](timer.New()),
+		dbft.WithTimer[util.Uint256](cfg.Timer),
 		dbft.WithLogger[util.Uint256](srv.log),
 		dbft.WithSecondsPerBlock[util.Uint256](cfg.TimePerBlock),
 		dbft.WithGetKeyPair[util.Uint256](srv.getKeyPair),
@@ -304,7 +324,7 @@ func (s *service) newRecoveryMessage() dbft.RecoveryMessage[util.Uint256] {
 
 // Name returns service name.
 func (s *service) Name() string {
-	return "consensus"
+	return ServiceName
 }
 
 func (s *service) Start() {
@@ -313,6 +333,7 @@ func (s *service) Start() {
 		b, _ := s.Chain.GetBlock(s.Chain.CurrentBlockHash()) // Can't fail, we have some current block!
 		s.lastTimestamp = b.Timestamp
 		s.dbft.Start(s.lastTimestamp * nsInMs)
+		s.startRound()
 		go s.eventLoop()
 	}
 }
@@ -341,6 +362,7 @@ func (s *service) eventLoop() {
 	}
 events:
 	for {
+		height, view := s.dbft.BlockIndex, s.dbft.ViewNumber
 		select {
 		case <-s.quit:
 			s.dbft.Timer.Stop()
@@ -377,12 +399,14 @@ events:
 			}
 
 			s.log.Debug("received message", fields...)
+			s.trackMessage(&msg, false)
 			s.dbft.OnReceive(&msg)
 		case tx := <-s.transactions:
 			s.dbft.OnTransaction(tx)
 		case b := <-s.blockEvents:
 			s.handleChainBlock(b)
 		}
+		s.trackViewChange(height, view)
 		// Always process block event if there is any, we can add one above or external
 		// services can add several blocks during message processing.
 		var latestBlock *coreb.Block
@@ -421,7 +445,9 @@ func (s *service) handleChainBlock(b *coreb.Block) {
 			zap.Uint32("dbft index", s.dbft.BlockIndex),
 			zap.Uint32("chain index", s.Chain.BlockHeight()))
 		s.postBlock(b)
+		s.finishRound(b.Index)
 		s.dbft.Reset(b.Timestamp * nsInMs)
+		s.startRound()
 	}
 }
 
@@ -503,6 +529,7 @@ func (s *service) broadcast(p dbft.ConsensusPayload[util.Uint256]) {
 		s.log.Warn("can't sign consensus payload", zap.Error(err))
 	}
 
+	s.trackMessage(p.(*Payload), true)
 	ep := &p.(*Payload).Extensible
 	s.Config.Broadcast(ep)
 }
@@ -0,0 +1,114 @@
+package consensus
+
+import (
+	"time"
+
+	"github.com/nspcc-dev/dbft"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// roundBuckets are histogram buckets (in seconds) for round stage durations.
+var roundBuckets = []float64{0.1, 0.25, 0.5, 1, 2, 3, 5, 10, 15, 20, 30, 60, 120}
+
+// Metrics used in monitoring service.
+var (
+	changeViews = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of ChangeView messages sent or received by reason",
+			Name:      "consensus_change_views",
+			Namespace: "neogo",
+		},
+		[]string{"reason"},
+	)
+	viewChanges = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Help:      "Number of dBFT view changes",
+			Name:      "consensus_view_changes",
+			Namespace: "neogo",
+		},
+	)
+	roundTime = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Help:      "Time from the dBFT round start to the block acceptance",
+			Name:      "consensus_round_time",
+			Namespace: "neogo",
+			Buckets:   roundBuckets,
+		},
+	)
+	prepareTime = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Help:      "Time from the dBFT round start to the PrepareRequest",
+			Name:      "consensus_prepare_time",
+			Namespace: "neogo",
+			Buckets:   roundBuckets,
+		},
+	)
+	commitTime = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Help:      "Time from the dBFT round start to the first Commit",
+			Name:      "consensus_commit_time",
+			Namespace: "neogo",
+			Buckets:   roundBuckets,
+		},
+	)
+	recoveryRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of RecoveryRequest messages sent and received",
+			Name:      "consensus_recovery_requests",
+			Namespace: "neogo",
+		},
+		[]string{"direction"},
+	)
+	missedPrimary = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of views changed because of the primary validator",
+			Name:      "consensus_missed_primary",
+			Namespace: "neogo",
+		},
+		[]string{"validator"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		changeViews,
+		viewChanges,
+		roundTime,
+		prepareTime,
+		commitTime,
+		recoveryRequests,
+		missedPrimary,
+	)
+}
+
+func addChangeViewMetric(reason dbft.ChangeViewReason) {
+	changeViews.WithLabelValues(reason.String()).Inc()
+}
+
+func addViewChangesMetric(n int) {
+	viewChanges.Add(float64(n))
+}
+
+func addRoundTimeMetric(d time.Duration) {
+	roundTime.Observe(d.Seconds())
+}
+
+func addPrepareTimeMetric(d time.Duration) {
+	prepareTime.Observe(d.Seconds())
+}
+
+func addCommitTimeMetric(d time.Duration) {
+	commitTime.Observe(d.Seconds())
+}
+
+func addRecoveryRequestMetric(sent bool) {
+	direction := "received"
+	if sent {
+		direction = "sent"
+	}
+	recoveryRequests.WithLabelValues(direction).Inc()
+}
+
+func addMissedPrimaryMetric(validator string) {
+	missedPrimary.WithLabelValues(validator).Inc()
+}
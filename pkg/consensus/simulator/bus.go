@@ -0,0 +1,251 @@
+package simulator
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/nspcc-dev/dbft"
+	"github.com/nspcc-dev/neo-go/pkg/consensus"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	npayload "github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+type (
+	// Message is a consensus message sent from one node to another.
+	Message struct {
+		From int
+		To   int
+		// Type, Height and View are taken from the original consensus
+		// message, they're not updated if Payload is changed.
+		Type   dbft.MessageType
+		Height uint32
+		View   byte
+		// Payload is the receiver's copy of the message payload, Filter can
+		// change it (see Node.Sign).
+		Payload *npayload.Extensible
+		// Delay is the delivery delay, Filter can change it.
+		Delay time.Duration
+	}
+
+	// Filter is called for every consensus message that is not lost by the
+	// Network, it returns false if the message must be dropped.
+	Filter func(m *Message) bool
+)
+
+// DropFrom returns a Filter dropping all messages sent by the given nodes
+// (that behave like mute Byzantine nodes then).
+func DropFrom(nodes ...int) Filter {
+	return func(m *Message) bool {
+		for _, i := range nodes {
+			if m.From == i {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// DropTypes returns a Filter dropping all messages of the given types.
+func DropTypes(types ...dbft.MessageType) Filter {
+	return func(m *Message) bool {
+		for _, t := range types {
+			if m.Type == t {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// SetLatency changes message delivery delay settings, see Options.
+func (n *Network) SetLatency(latency, jitter time.Duration) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.opts.Latency = latency
+	n.opts.Jitter = jitter
+}
+
+// SetLoss changes consensus message loss probability, see Options.
+func (n *Network) SetLoss(loss float64) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.opts.Loss = loss
+}
+
+// AddFilter adds a message filter to the network. Filters are applied in the
+// order they're added.
+func (n *Network) AddFilter(f Filter) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.filters = append(n.filters, f)
+}
+
+// ClearFilters removes all message filters.
+func (n *Network) ClearFilters() {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.filters = nil
+}
+
+// Partition splits the network into isolated groups of nodes, nodes not
+// mentioned in any group are isolated from all others. Nodes can only
+// communicate within the same group until Heal is called.
+func (n *Network) Partition(groups ...[]int) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	for i := range n.groups {
+		n.groups[i] = -1 - i
+	}
+	for g := range groups {
+		for _, i := range groups[g] {
+			n.groups[i] = g
+		}
+	}
+}
+
+// Heal removes all partitions.
+func (n *Network) Heal() {
+	n.lock.Lock()
+	for i := range n.groups {
+		n.groups[i] = 0
+	}
+	n.lock.Unlock()
+	// Nodes exchange their heights upon reconnection in the real network,
+	// so lagging ones get blocks from others.
+	for _, node := range n.nodes {
+		for _, peer := range n.nodes {
+			if h := peer.Chain.BlockHeight(); h > node.Chain.BlockHeight() && peer.Running() {
+				node.onBlock(peer, h)
+			}
+		}
+	}
+}
+
+// connected checks whether nodes with the given indexes can communicate.
+func (n *Network) connected(a, b int) bool {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	return n.groups[a] == n.groups[b]
+}
+
+// schedule runs f after the given delay unless the network is closed or the
+// nodes are partitioned by that time. It returns false if f won't be called.
+func (n *Network) schedule(from, to int, delay time.Duration, f func()) bool {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if n.closed || n.groups[from] != n.groups[to] {
+		return false
+	}
+	n.clock.afterFunc(delay, func() {
+		if n.isClosed() || !n.connected(from, to) {
+			return
+		}
+		f()
+	})
+	return true
+}
+
+func (n *Network) isClosed() bool {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	return n.closed
+}
+
+// messageRand returns a random number generator for the message delivery, it
+// only depends on the network seed, the message and its receiver.
+func (n *Network) messageRand(h util.Uint256, to int) *rand.Rand {
+	return rand.New(rand.NewSource(n.opts.Seed ^ int64(binary.LittleEndian.Uint64(h[:])) ^ int64(to)))
+}
+
+// clonePayload returns a deep copy of the payload (with no cached hash).
+func clonePayload(p *npayload.Extensible) *npayload.Extensible {
+	buf := io.NewBufBinWriter()
+	p.EncodeBinary(buf.BinWriter)
+	res := npayload.NewExtensible()
+	res.DecodeBinary(io.NewBinReaderFromBuf(buf.Bytes()))
+	return res
+}
+
+// broadcast sends the consensus payload from the given node to all others.
+func (n *Network) broadcast(from int, p *npayload.Extensible) {
+	var (
+		cfg = n.nodes[from].Chain.GetConfig()
+		buf = io.NewBufBinWriter()
+		dec = consensus.NewPayload(cfg.Magic, cfg.StateRootInHeader)
+	)
+	// It's our own payload, so it's always correct.
+	p.EncodeBinary(buf.BinWriter)
+	dec.DecodeBinary(io.NewBinReaderFromBuf(buf.Bytes()))
+
+	n.lock.RLock()
+	var (
+		loss    = n.opts.Loss
+		latency = n.opts.Latency
+		jitter  = n.opts.Jitter
+		filters = n.filters
+	)
+	n.lock.RUnlock()
+
+	for to := range n.nodes {
+		if to == from {
+			continue
+		}
+		m := &Message{
+			From:    from,
+			To:      to,
+			Type:    dec.Type(),
+			Height:  dec.Height(),
+			View:    dec.ViewNumber(),
+			Payload: clonePayload(p),
+			Delay:   latency,
+		}
+		r := n.messageRand(p.Hash(), to)
+		lost := r.Float64() < loss
+		if jitter > 0 {
+			m.Delay += time.Duration(r.Int63n(int64(jitter) + 1))
+		}
+		ok := !lost
+		for i := 0; ok && i < len(filters); i++ {
+			ok = filters[i](m)
+		}
+		ok = ok && n.schedule(from, to, m.Delay, func() {
+			err := n.nodes[m.To].onPayload(clonePayload(m.Payload))
+			n.lock.Lock()
+			switch {
+			case err == nil:
+				n.stats.Delivered++
+			case errors.Is(err, errStopped):
+				n.stats.Dropped++
+			default:
+				n.stats.Rejected++
+			}
+			n.lock.Unlock()
+		})
+		n.lock.Lock()
+		n.stats.Sent++
+		if !ok {
+			n.stats.Dropped++
+		}
+		n.lock.Unlock()
+	}
+}
+
+// relayBlock sends the block accepted by the given node to all others.
+func (n *Network) relayBlock(from int, b *block.Block) {
+	n.lock.RLock()
+	latency := n.opts.Latency
+	n.lock.RUnlock()
+	for to := range n.nodes {
+		if to == from {
+			continue
+		}
+		node := n.nodes[to]
+		n.schedule(from, to, latency, func() {
+			node.onBlock(n.nodes[from], b.Index)
+		})
+	}
+}
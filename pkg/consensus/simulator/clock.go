@@ -0,0 +1,267 @@
+package simulator
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/dbft"
+)
+
+const (
+	// settlePoll is the (real) interval between activity checks of the
+	// network when the clock waits for it to settle.
+	settlePoll = 500 * time.Microsecond
+	// settleRounds is the number of consecutive activity checks without any
+	// new activity after which the network is considered to be settled.
+	settleRounds = 10
+)
+
+type (
+	// Clock is a virtual time source shared by all nodes and the network.
+	// Time only changes when Advance is called, all timers and message
+	// deliveries scheduled up to the new time are fired in order then.
+	Clock struct {
+		lock sync.Mutex
+		now  time.Time
+		// seq is used to order events scheduled for the same time.
+		seq    uint64
+		events []*clockEvent
+		// activity is increased on every clock-related action, the
+		// network is considered to be settled when it doesn't change.
+		activity uint64
+	}
+
+	clockEvent struct {
+		at  time.Time
+		seq uint64
+		f   func()
+	}
+
+	// nodeTimer implements dbft.Timer with the Clock.
+	nodeTimer struct {
+		clock *Clock
+
+		lock   sync.Mutex
+		height uint32
+		view   byte
+		start  time.Time
+		d      time.Duration
+		event  *clockEvent
+		ch     chan time.Time
+	}
+)
+
+var _ dbft.Timer = (*nodeTimer)(nil)
+
+// NewClock returns a new clock starting at the given time.
+func NewClock(start time.Time) *Clock {
+	return &Clock{now: start}
+}
+
+// Now returns the current virtual time.
+func (c *Clock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.now
+}
+
+// afterFunc schedules f to be called once the clock is advanced by d. The
+// event returned can be cancelled with cancel.
+func (c *Clock) afterFunc(d time.Duration, f func()) *clockEvent {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.seq++
+	c.activity++
+	e := &clockEvent{at: c.now.Add(d), seq: c.seq, f: f}
+	i := sort.Search(len(c.events), func(i int) bool {
+		return e.before(c.events[i])
+	})
+	c.events = append(c.events, nil)
+	copy(c.events[i+1:], c.events[i:])
+	c.events[i] = e
+	return e
+}
+
+// cancel removes the scheduled event, it's a no-op if the event is already
+// fired.
+func (c *Clock) cancel(e *clockEvent) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.activity++
+	for i := range c.events {
+		if c.events[i] == e {
+			c.events = append(c.events[:i], c.events[i+1:]...)
+			return
+		}
+	}
+}
+
+// Advance moves the clock forward by d firing all events scheduled up to the
+// new time. Events scheduled for the same time are fired together, then
+// the clock waits for the network to settle (so that all reactions to these
+// events are scheduled) before moving to the next ones.
+func (c *Clock) Advance(d time.Duration) {
+	target := c.Now().Add(d)
+	for {
+		c.settle()
+		c.lock.Lock()
+		if len(c.events) == 0 || c.events[0].at.After(target) {
+			c.now = target
+			c.lock.Unlock()
+			return
+		}
+		if c.events[0].at.After(c.now) {
+			c.now = c.events[0].at
+		}
+		var due []*clockEvent
+		for len(c.events) != 0 && !c.events[0].at.After(c.now) {
+			due = append(due, c.events[0])
+			c.events = c.events[1:]
+		}
+		c.activity++
+		c.lock.Unlock()
+		for _, e := range due {
+			e.f()
+		}
+	}
+}
+
+// touch records some activity of the network.
+func (c *Clock) touch() {
+	c.lock.Lock()
+	c.activity++
+	c.lock.Unlock()
+}
+
+// settle waits until there is no network activity for settleRounds checks.
+// Node services process messages asynchronously and there is no way to know
+// when they're done with it other than to wait for them to stop doing
+// anything observable (scheduling messages and timers).
+func (c *Clock) settle() {
+	c.lock.Lock()
+	last := c.activity
+	c.lock.Unlock()
+	for idle := 0; idle < settleRounds; {
+		time.Sleep(settlePoll)
+		c.lock.Lock()
+		a := c.activity
+		c.lock.Unlock()
+		if a != last {
+			last, idle = a, 0
+		} else {
+			idle++
+		}
+	}
+}
+
+func (e *clockEvent) before(o *clockEvent) bool {
+	return e.at.Before(o.at) || (e.at.Equal(o.at) && e.seq < o.seq)
+}
+
+func newNodeTimer(c *Clock) *nodeTimer {
+	return &nodeTimer{
+		clock: c,
+		ch:    make(chan time.Time, 1),
+	}
+}
+
+// Now implements the dbft.Timer interface.
+func (t *nodeTimer) Now() time.Time {
+	return t.clock.Now()
+}
+
+// Reset implements the dbft.Timer interface.
+func (t *nodeTimer) Reset(height uint32, view byte, d time.Duration) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.stop()
+	t.height = height
+	t.view = view
+	t.start = t.clock.Now()
+	t.d = d
+	t.schedule(d)
+}
+
+// Sleep implements the dbft.Timer interface.
+func (t *nodeTimer) Sleep(d time.Duration) {
+	done := make(chan struct{})
+	t.clock.afterFunc(d, func() { close(done) })
+	<-done
+}
+
+// Extend implements the dbft.Timer interface.
+func (t *nodeTimer) Extend(d time.Duration) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.d += d
+	if elapsed := t.clock.Now().Sub(t.start); t.d > elapsed {
+		t.stop()
+		t.schedule(t.d - elapsed)
+	}
+}
+
+// Stop implements the dbft.Timer interface.
+func (t *nodeTimer) Stop() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.stop()
+}
+
+// Height implements the dbft.Timer interface.
+func (t *nodeTimer) Height() uint32 {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.height
+}
+
+// View implements the dbft.Timer interface.
+func (t *nodeTimer) View() byte {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.view
+}
+
+// C implements the dbft.Timer interface.
+func (t *nodeTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+// schedule fires the timer after d, it must be called with the lock held.
+func (t *nodeTimer) schedule(d time.Duration) {
+	var e *clockEvent
+	e = t.clock.afterFunc(d, func() {
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		if t.event != e {
+			return // Stopped or reset concurrently.
+		}
+		t.event = nil
+		t.fire()
+	})
+	t.event = e
+}
+
+// stop cancels the scheduled timer event and drops the one that is not yet
+// received, it must be called with the lock held.
+func (t *nodeTimer) stop() {
+	if t.event != nil {
+		t.clock.cancel(t.event)
+		t.event = nil
+	}
+	select {
+	case <-t.ch:
+	default:
+	}
+}
+
+// fire sends the current time to the timer channel, it must be called with
+// the lock held.
+func (t *nodeTimer) fire() {
+	select {
+	case <-t.ch:
+	default:
+	}
+	t.ch <- t.clock.Now()
+	t.clock.touch()
+}
@@ -0,0 +1,268 @@
+package simulator
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/consensus"
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/network/extpool"
+	npayload "github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// extensiblePoolSize is the number of payloads kept in the node extensible
+// pool for every sender (the same as the default network server setting).
+const extensiblePoolSize = 20
+
+// errStopped is returned when something is delivered to the stopped node.
+var errStopped = errors.New("node is stopped")
+
+// Node is a single consensus node of the simulated network.
+type Node struct {
+	// Index is the node index which is also its validator index.
+	Index int
+	// Chain is the node blockchain.
+	Chain *core.Blockchain
+
+	net        *Network
+	key        *keys.PrivateKey
+	walletPath string
+	log        *zap.Logger
+	// pool is used to verify incoming consensus payloads the same way
+	// network server does.
+	pool *extpool.Pool
+
+	// lock protects service, it's taken for writing when the node is
+	// stopped and for reading when anything is delivered to the node.
+	lock    sync.RWMutex
+	service consensus.Service
+	// syncLock serializes block additions.
+	syncLock sync.Mutex
+}
+
+// blockQueue implements consensus.BlockQueuer for the node.
+type blockQueue struct {
+	node *Node
+}
+
+func newNode(n *Network, i int, bc *core.Blockchain, key *keys.PrivateKey, walletPath string, log *zap.Logger) *Node {
+	return &Node{
+		Index:      i,
+		Chain:      bc,
+		net:        n,
+		key:        key,
+		walletPath: walletPath,
+		log:        log,
+		pool:       extpool.New(bc, extensiblePoolSize),
+	}
+}
+
+// PrivateKey returns the node validator key.
+func (n *Node) PrivateKey() *keys.PrivateKey {
+	return n.key
+}
+
+// Service returns the current node consensus service, it's nil if the node
+// is not running.
+func (n *Node) Service() consensus.Service {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	return n.service
+}
+
+// Running returns true if the node is running.
+func (n *Node) Running() bool {
+	return n.Service() != nil
+}
+
+// Timeline returns the consensus timeline of the node (nil if the node is
+// not running). It's reset on node restart.
+func (n *Node) Timeline() []consensus.RoundTimeline {
+	s, ok := n.Service().(consensus.TimelineReporter)
+	if !ok {
+		return nil
+	}
+	return s.Timeline()
+}
+
+// Start starts the node with a new consensus service instance. Stopped node
+// synchronizes blocks from its peers before starting the consensus (like
+// the real node does after the restart). It's a no-op for running nodes.
+func (n *Node) Start() {
+	// Peers are checked below, so concurrent starts can't be allowed.
+	n.net.startLock.Lock()
+	defer n.net.startLock.Unlock()
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if n.service != nil {
+		return
+	}
+	for _, peer := range n.net.nodes {
+		if peer != n && peer.Running() && n.net.connected(peer.Index, n.Index) {
+			_ = n.syncFrom(peer, peer.Chain.BlockHeight())
+		}
+	}
+	cfg := n.Chain.GetConfig()
+	srv, err := consensus.NewService(consensus.Config{
+		Logger:                n.log,
+		Broadcast:             n.broadcast,
+		Chain:                 n.Chain,
+		BlockQueue:            blockQueue{node: n},
+		ProtocolConfiguration: cfg.ProtocolConfiguration,
+		RequestTx:             n.requestTx,
+		StopTxFlow:            func() {},
+		TimePerBlock:          cfg.TimePerBlock,
+		Wallet: config.Wallet{
+			Path:     n.walletPath,
+			Password: walletPassword,
+		},
+		Timer: newNodeTimer(n.net.clock),
+	})
+	require.NoError(n.net.t, err)
+	n.service = srv
+	srv.Start()
+}
+
+// Stop stops the node consensus service, the node doesn't receive any
+// messages, blocks or transactions until it's started again. It's a no-op
+// for stopped nodes.
+func (n *Node) Stop() {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if n.service == nil {
+		return
+	}
+	n.service.Shutdown()
+	n.service = nil
+}
+
+// Sign signs the given consensus payload with the node key, it's useful for
+// filters modifying messages on behalf of Byzantine nodes.
+func (n *Node) Sign(p *npayload.Extensible) {
+	// Payload hash is cached, so we need a fresh copy to sign the modified one.
+	*p = *clonePayload(p)
+	p.Sender = n.key.GetScriptHash()
+	buf := io.NewBufBinWriter()
+	emit.Bytes(buf.BinWriter, n.key.SignHashable(uint32(n.Chain.GetConfig().Magic), p))
+	p.Witness = transaction.Witness{
+		InvocationScript:   buf.Bytes(),
+		VerificationScript: n.key.PublicKey().GetVerificationScript(),
+	}
+}
+
+// broadcast is a consensus.Config.Broadcast callback.
+func (n *Node) broadcast(p *npayload.Extensible) {
+	n.net.broadcast(n.Index, p)
+}
+
+// requestTx is a consensus.Config.RequestTx callback. Transactions are
+// requested from all reachable peers.
+func (n *Node) requestTx(hashes ...util.Uint256) {
+	for _, peer := range n.net.nodes {
+		if peer == n {
+			continue
+		}
+		var txs []*transaction.Transaction
+		for _, h := range hashes {
+			if tx, ok := peer.Chain.GetMemPool().TryGetValue(h); ok {
+				txs = append(txs, tx)
+			}
+		}
+		if len(txs) == 0 {
+			continue
+		}
+		n.net.schedule(peer.Index, n.Index, n.net.opts.Latency, func() {
+			for _, tx := range txs {
+				_ = n.addTx(tx)
+			}
+		})
+	}
+}
+
+// addTx adds the transaction to the node mempool and passes it to the
+// consensus service.
+func (n *Node) addTx(tx *transaction.Transaction) error {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	if n.service == nil {
+		return errStopped
+	}
+	err := n.Chain.PoolTx(tx)
+	if err != nil && !errors.Is(err, core.ErrAlreadyInPool) {
+		return err
+	}
+	n.service.OnTransaction(tx)
+	return nil
+}
+
+// onPayload verifies the consensus payload and passes it to the consensus
+// service.
+func (n *Node) onPayload(p *npayload.Extensible) error {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	if n.service == nil {
+		return errStopped
+	}
+	_, err := n.pool.Add(p)
+	if err != nil {
+		n.log.Debug("invalid consensus payload", zap.Error(err))
+		return err
+	}
+	return n.service.OnPayload(p)
+}
+
+// onBlock handles a new block announced by the peer, all blocks up to the
+// given index are fetched from it.
+func (n *Node) onBlock(from *Node, index uint32) {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	if n.service == nil {
+		return
+	}
+	_ = n.syncFrom(from, index)
+}
+
+// syncFrom adds all blocks up to the given height from the peer chain.
+func (n *Node) syncFrom(peer *Node, height uint32) error {
+	n.syncLock.Lock()
+	defer n.syncLock.Unlock()
+	for i := n.Chain.BlockHeight() + 1; i <= height; i++ {
+		b, err := peer.Chain.GetBlock(peer.Chain.GetHeaderHash(i))
+		if err != nil {
+			return err
+		}
+		err = n.Chain.AddBlock(b)
+		if err != nil {
+			n.log.Warn("failed to add block", zap.Uint32("index", i), zap.Error(err))
+			return err
+		}
+	}
+	n.pool.RemoveStale(n.Chain.BlockHeight())
+	return nil
+}
+
+// PutBlock implements consensus.BlockQueuer interface. Block is added to
+// the node chain and relayed to other nodes.
+func (q blockQueue) PutBlock(b *block.Block) error {
+	n := q.node
+	n.syncLock.Lock()
+	err := n.Chain.AddBlock(b)
+	if err == nil {
+		n.pool.RemoveStale(b.Index)
+	}
+	n.syncLock.Unlock()
+	if err != nil {
+		return err
+	}
+	n.net.relayBlock(n.Index, b)
+	return nil
+}
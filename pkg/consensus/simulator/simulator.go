@@ -0,0 +1,347 @@
+/*
+Package simulator runs several consensus services in-process over a simulated
+network, it's intended to be used in tests of consensus-related settings
+(TimePerBlock, the number of validators and alike) and node behaviour under
+adverse network conditions.
+
+Every node has its own Blockchain instance (created with neotest/chain) and
+a regular consensus.Service, nodes exchange consensus payloads, blocks and
+transactions via the Network that can delay, lose, filter and modify messages,
+split nodes into isolated partitions and stop/restart nodes. Byzantine behaviour
+can be modelled with Filter functions that have access to every consensus
+message and can change it (re-signing it with the sender's key if needed).
+
+Network fault decisions (message loss and latency jitter) are derived from the
+Seed and the message being delivered, so they're reproducible for the same
+message flow. The network has a virtual Clock used for message deliveries,
+consensus timers and block timestamps, time only passes when the test
+advances it with Run, RunUntil or WaitForHeight.
+*/
+package simulator
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/neotest"
+	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest"
+)
+
+const (
+	// DefaultValidators is the default number of consensus nodes.
+	DefaultValidators = 4
+	// DefaultTimePerBlock is the default TimePerBlock setting used for
+	// the simulated network, it's much shorter than the real one to speed
+	// tests up.
+	DefaultTimePerBlock = 200 * time.Millisecond
+
+	// walletPassword is used for all generated node wallets.
+	walletPassword = "one"
+)
+
+// walletScrypt contains weak scrypt parameters to make wallet decryption fast.
+var walletScrypt = keys.ScryptParams{N: 2, R: 1, P: 1}
+
+// Options contains simulated network parameters.
+type Options struct {
+	// Validators is the number of consensus nodes, DefaultValidators is used
+	// if not set. All of them are standby committee members and validators.
+	Validators int
+	// TimePerBlock is the protocol TimePerBlock setting, DefaultTimePerBlock
+	// is used if not set.
+	TimePerBlock time.Duration
+	// Seed is used to generate validator keys and to make network fault
+	// decisions.
+	Seed int64
+	// Latency is the base delay of every message delivery.
+	Latency time.Duration
+	// Jitter is the maximum random delay added to Latency.
+	Jitter time.Duration
+	// Loss is the probability (from 0 to 1) of consensus message loss. Blocks
+	// and transactions are never lost (P2P layer requests them again in the
+	// real network), but they're subject to Latency and partitions.
+	Loss float64
+	// BlockchainConfigHook allows to adjust the blockchain configuration of
+	// every node, it's applied after simulator-specific settings.
+	BlockchainConfigHook func(*config.Blockchain)
+	// Logger is a base logger for nodes. If not set, zaptest logger with
+	// Info level is used.
+	Logger *zap.Logger
+}
+
+// Stats contains consensus message delivery statistics.
+type Stats struct {
+	// Sent is the number of messages sent from one node to another.
+	Sent int
+	// Delivered is the number of messages passed to the receiver's
+	// consensus service.
+	Delivered int
+	// Dropped is the number of messages dropped by the Network because of
+	// loss, partitions, stopped receivers or filters.
+	Dropped int
+	// Rejected is the number of messages that were delivered, but failed
+	// the receiver's verification (invalid witness or height).
+	Rejected int
+}
+
+// Network is a simulated network of consensus nodes.
+type Network struct {
+	t          testing.TB
+	opts       Options
+	nodes      []*Node
+	validators neotest.MultiSigner
+	committee  neotest.MultiSigner
+
+	startLock sync.Mutex
+	clock     *Clock
+
+	lock   sync.RWMutex
+	closed bool
+	// groups contains partition group of every node, nodes can only
+	// communicate within the same group.
+	groups  []int
+	filters []Filter
+	stats   Stats
+}
+
+// New creates a simulated network with the given options. Nodes are created,
+// but not started, see Start. The network is stopped automatically when the
+// test completes.
+func New(t testing.TB, opts Options) *Network {
+	if opts.Validators <= 0 {
+		opts.Validators = DefaultValidators
+	}
+	if opts.TimePerBlock <= 0 {
+		opts.TimePerBlock = DefaultTimePerBlock
+	}
+	if opts.Logger == nil {
+		opts.Logger = zaptest.NewLogger(t, zaptest.Level(zapcore.InfoLevel))
+	}
+
+	var (
+		n = &Network{
+			t:      t,
+			opts:   opts,
+			clock:  NewClock(time.Now()),
+			groups: make([]int, opts.Validators),
+		}
+		privs = make([]*keys.PrivateKey, opts.Validators)
+		seed  = make([]byte, 8+4)
+	)
+	binary.LittleEndian.PutUint64(seed, uint64(opts.Seed))
+	for i := range privs {
+		binary.LittleEndian.PutUint32(seed[8:], uint32(i))
+		h := sha256.Sum256(seed)
+		k, err := keys.NewPrivateKeyFromBytes(h[:])
+		require.NoError(t, err)
+		privs[i] = k
+	}
+	// Node index is the same as the validator index then.
+	sort.Slice(privs, func(i, j int) bool {
+		return privs[i].PublicKey().Cmp(privs[j].PublicKey()) < 0
+	})
+	committee := make([]string, len(privs))
+	for i := range privs {
+		committee[i] = privs[i].PublicKey().StringCompressed()
+	}
+	n.validators = newMultiSigner(t, privs, smartcontract.GetDefaultHonestNodeCount(len(privs)))
+	n.committee = newMultiSigner(t, privs, smartcontract.GetMajorityHonestNodeCount(len(privs)))
+
+	hook := func(cfg *config.Blockchain) {
+		cfg.StandbyCommittee = committee
+		cfg.ValidatorsCount = uint32(len(committee))
+		cfg.TimePerBlock = opts.TimePerBlock
+		if opts.BlockchainConfigHook != nil {
+			opts.BlockchainConfigHook(cfg)
+		}
+	}
+	dir := t.TempDir()
+	for i := range privs {
+		log := opts.Logger.With(zap.Int("node", i))
+		bc, _, _ := chain.NewMultiWithOptions(t, &chain.Options{
+			Logger:               log,
+			BlockchainConfigHook: hook,
+		})
+		path := filepath.Join(dir, "wallet"+strconv.Itoa(i)+".json")
+		w, err := wallet.NewWallet(path)
+		require.NoError(t, err)
+		w.Scrypt = walletScrypt
+		// The key is destroyed when the wallet is closed, so it's copied.
+		k, err := keys.NewPrivateKeyFromBytes(privs[i].Bytes())
+		require.NoError(t, err)
+		acc := wallet.NewAccountFromPrivateKey(k)
+		require.NoError(t, acc.Encrypt(walletPassword, walletScrypt))
+		w.AddAccount(acc)
+		require.NoError(t, w.Save())
+		w.Close()
+
+		n.nodes = append(n.nodes, newNode(n, i, bc, privs[i], path, log))
+	}
+	t.Cleanup(n.Close)
+	return n
+}
+
+func newMultiSigner(t testing.TB, privs []*keys.PrivateKey, m int) neotest.MultiSigner {
+	pubs := make(keys.PublicKeys, len(privs))
+	for i := range privs {
+		pubs[i] = privs[i].PublicKey()
+	}
+	accs := make([]*wallet.Account, len(privs))
+	for i := range privs {
+		accs[i] = wallet.NewAccountFromPrivateKey(privs[i])
+		require.NoError(t, accs[i].ConvertMultisig(m, pubs))
+	}
+	return neotest.NewMultiSigner(accs...)
+}
+
+// Nodes returns all nodes of the network, node index is the same as its
+// validator index.
+func (n *Network) Nodes() []*Node {
+	return n.nodes
+}
+
+// Node returns the node with the given index.
+func (n *Network) Node(i int) *Node {
+	return n.nodes[i]
+}
+
+// Validators returns a signer for the standby validators multisignature
+// account (that owns all NEO and GAS after the genesis).
+func (n *Network) Validators() neotest.MultiSigner {
+	return n.validators
+}
+
+// Committee returns a signer for the committee multisignature account.
+func (n *Network) Committee() neotest.MultiSigner {
+	return n.committee
+}
+
+// Executor returns neotest.Executor for the i-th node chain. It can be used
+// to create transactions, but blocks must not be added with it, that's what
+// the consensus is for.
+func (n *Network) Executor(i int) *neotest.Executor {
+	return neotest.NewExecutor(n.t, n.nodes[i].Chain, n.validators, n.committee)
+}
+
+// Primary returns the index of the primary node for the given height and view.
+func (n *Network) Primary(height uint32, view byte) int {
+	p := (int(height) - int(view)) % len(n.nodes)
+	if p < 0 {
+		p += len(n.nodes)
+	}
+	return p
+}
+
+// Start starts all nodes that are not running yet.
+func (n *Network) Start() {
+	for _, node := range n.nodes {
+		node.Start()
+	}
+}
+
+// Close stops all nodes, messages that are still in flight are never
+// delivered. It's called automatically on test cleanup.
+func (n *Network) Close() {
+	n.lock.Lock()
+	if n.closed {
+		n.lock.Unlock()
+		return
+	}
+	n.closed = true
+	n.lock.Unlock()
+	for _, node := range n.nodes {
+		node.Stop()
+	}
+}
+
+// Clock returns the virtual clock of the network.
+func (n *Network) Clock() *Clock {
+	return n.clock
+}
+
+// Run advances the network clock by d.
+func (n *Network) Run(d time.Duration) {
+	n.clock.Advance(d)
+}
+
+// RunUntil advances the network clock until cond returns true and fails the
+// test if it doesn't happen before the timeout (in virtual time).
+func (n *Network) RunUntil(cond func() bool, timeout time.Duration, msgAndArgs ...any) {
+	var (
+		step     = n.opts.TimePerBlock / 10
+		deadline = n.clock.Now().Add(timeout)
+	)
+	for !cond() {
+		if !n.clock.Now().Before(deadline) {
+			require.FailNow(n.t, "condition is not satisfied in time", msgAndArgs...)
+		}
+		n.clock.Advance(step)
+	}
+}
+
+// Stats returns message delivery statistics.
+func (n *Network) Stats() Stats {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	return n.stats
+}
+
+// Heights returns current block heights of all nodes.
+func (n *Network) Heights() []uint32 {
+	res := make([]uint32, len(n.nodes))
+	for i, node := range n.nodes {
+		res[i] = node.Chain.BlockHeight()
+	}
+	return res
+}
+
+// WaitForHeight advances the network clock until all running nodes reach the
+// given height and fails the test if they don't do this before the timeout
+// (in virtual time).
+func (n *Network) WaitForHeight(height uint32, timeout time.Duration) {
+	n.RunUntil(func() bool {
+		for _, node := range n.nodes {
+			if node.Running() && node.Chain.BlockHeight() < height {
+				return false
+			}
+		}
+		return true
+	}, timeout, "height %d is not reached, current heights: %v", height, n.Heights())
+}
+
+// SendTx adds the transaction to the memory pool of every running node (that
+// is the way it's usually propagated in the real network before consensus
+// starts). An error is returned if none of the nodes accepted it.
+func (n *Network) SendTx(tx *transaction.Transaction) error {
+	var (
+		accepted bool
+		firstErr error
+	)
+	for _, node := range n.nodes {
+		err := node.addTx(tx)
+		if err == nil {
+			accepted = true
+		} else if firstErr == nil {
+			firstErr = err
+		}
+	}
+	if accepted {
+		return nil
+	}
+	return firstErr
+}
@@ -0,0 +1,204 @@
+package simulator
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/dbft"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativehashes"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+const waitTimeout = 20 * time.Second
+
+// requireSameChain checks that all nodes have the same blocks up to the
+// given height.
+func requireSameChain(t *testing.T, net *Network, height uint32) {
+	for i := uint32(1); i <= height; i++ {
+		h := net.Node(0).Chain.GetHeaderHash(i)
+		for _, node := range net.Nodes()[1:] {
+			require.Equal(t, h, node.Chain.GetHeaderHash(i), "node %d, block %d", node.Index, i)
+		}
+	}
+}
+
+// maxView returns the maximum view number the block at the given height was
+// accepted with according to the node timelines.
+func maxView(net *Network, height uint32) byte {
+	var v byte
+	for _, node := range net.Nodes() {
+		for _, r := range node.Timeline() {
+			if r.Height == height && r.View > v {
+				v = r.View
+			}
+		}
+	}
+	return v
+}
+
+func TestNetwork(t *testing.T) {
+	net := New(t, Options{Latency: 5 * time.Millisecond, Jitter: 5 * time.Millisecond})
+	vals, err := net.Node(0).Chain.GetNextBlockValidators()
+	require.NoError(t, err)
+	for i, node := range net.Nodes() {
+		require.True(t, vals[i].Equal(node.PrivateKey().PublicKey()))
+	}
+	net.Start()
+	net.WaitForHeight(3, waitTimeout)
+
+	e := net.Executor(0)
+	tx := e.NewUnsignedTx(t, nativehashes.GasToken, "transfer",
+		net.Validators().ScriptHash(), util.Uint160{1, 2, 3}, 1, nil)
+	tx.ValidUntilBlock += 100
+	tx = e.SignTx(t, tx, -1, net.Validators())
+	require.NoError(t, net.SendTx(tx))
+	net.RunUntil(func() bool {
+		for _, node := range net.Nodes() {
+			if _, err := node.Chain.GetAppExecResults(tx.Hash(), trigger.Application); err != nil {
+				return false
+			}
+		}
+		return true
+	}, waitTimeout)
+	e.CheckHalt(t, tx.Hash())
+
+	net.WaitForHeight(net.Node(0).Chain.BlockHeight()+1, waitTimeout)
+	requireSameChain(t, net, net.Node(0).Chain.BlockHeight()-1)
+	st := net.Stats()
+	require.NotZero(t, st.Delivered)
+	require.Zero(t, st.Rejected)
+	require.Zero(t, st.Dropped)
+}
+
+func TestNetwork_Config(t *testing.T) {
+	net := New(t, Options{
+		Validators:   7,
+		TimePerBlock: 300 * time.Millisecond,
+		BlockchainConfigHook: func(cfg *config.Blockchain) {
+			cfg.StateRootInHeader = true
+		},
+	})
+	require.Len(t, net.Nodes(), 7)
+	require.Equal(t, 300*time.Millisecond, net.Node(0).Chain.GetConfig().TimePerBlock)
+	net.Start()
+	net.WaitForHeight(3, waitTimeout)
+	requireSameChain(t, net, 3)
+	b, err := net.Node(6).Chain.GetBlock(net.Node(6).Chain.GetHeaderHash(3))
+	require.NoError(t, err)
+	require.True(t, b.StateRootEnabled)
+	prev, err := net.Node(6).Chain.GetBlock(b.PrevHash)
+	require.NoError(t, err)
+	// Timestamps are in milliseconds, there are no network delays, so the
+	// primary proposes the block exactly TimePerBlock after the previous one.
+	require.Equal(t, uint64(300), b.Timestamp-prev.Timestamp)
+}
+
+func TestNetwork_PrimaryFailure(t *testing.T) {
+	net := New(t, Options{})
+	net.Start()
+	net.WaitForHeight(2, waitTimeout)
+
+	// Stop the primary for the next height.
+	h := net.Node(0).Chain.BlockHeight() + 1
+	primary := net.Primary(h, 0)
+	net.Node(primary).Stop()
+	net.WaitForHeight(h+1, waitTimeout)
+	require.NotZero(t, maxView(net, h))
+
+	// Restarted node catches up.
+	net.Node(primary).Start()
+	net.WaitForHeight(h+2, waitTimeout)
+	requireSameChain(t, net, h+2)
+}
+
+func TestNetwork_MuteNode(t *testing.T) {
+	net := New(t, Options{})
+	net.AddFilter(DropFrom(1))
+	net.Start()
+	net.WaitForHeight(5, waitTimeout)
+	require.NotZero(t, net.Stats().Dropped)
+	require.NotZero(t, maxView(net, 1)+maxView(net, 5), "node 1 is primary for heights 1 and 5")
+}
+
+func TestNetwork_Partition(t *testing.T) {
+	net := New(t, Options{})
+	net.Start()
+	net.WaitForHeight(1, waitTimeout)
+
+	// 2+2 split, neither part can produce blocks.
+	net.Partition([]int{0, 1}, []int{2, 3})
+	var h uint32
+	for _, node := range net.Nodes() {
+		if node.Chain.BlockHeight() > h {
+			h = node.Chain.BlockHeight()
+		}
+	}
+	net.Run(4 * net.opts.TimePerBlock)
+	for _, node := range net.Nodes() {
+		require.LessOrEqual(t, node.Chain.BlockHeight(), h+1)
+	}
+
+	// 3+1 split, majority continues.
+	net.Partition([]int{0, 1, 2})
+	net.RunUntil(func() bool {
+		return net.Node(0).Chain.BlockHeight() >= h+3
+	}, waitTimeout)
+	require.Less(t, net.Node(3).Chain.BlockHeight(), net.Node(0).Chain.BlockHeight())
+
+	net.Heal()
+	h = net.Node(0).Chain.BlockHeight() + 2
+	net.WaitForHeight(h, waitTimeout)
+	requireSameChain(t, net, h)
+}
+
+func TestNetwork_Loss(t *testing.T) {
+	net := New(t, Options{Seed: 42, Loss: 0.2, Latency: time.Millisecond})
+	net.Start()
+	net.WaitForHeight(5, 2*waitTimeout)
+	requireSameChain(t, net, 5)
+	require.NotZero(t, net.Stats().Dropped)
+}
+
+func TestNetwork_Byzantine(t *testing.T) {
+	t.Run("forged", func(t *testing.T) {
+		// Messages modified without proper signature are rejected.
+		net := New(t, Options{})
+		net.AddFilter(func(m *Message) bool {
+			if m.From == 2 {
+				m.Payload.Data = bytes.Clone(m.Payload.Data)
+				m.Payload.Data[len(m.Payload.Data)-1] ^= 0xff
+			}
+			return true
+		})
+		net.Start()
+		net.WaitForHeight(3, waitTimeout)
+		require.NotZero(t, net.Stats().Rejected)
+	})
+	t.Run("corrupted", func(t *testing.T) {
+		// Node 2 sends garbage signed with its key, it can't prevent
+		// others from reaching an agreement.
+		net := New(t, Options{})
+		net.AddFilter(func(m *Message) bool {
+			if m.From == 2 && m.Type != dbft.RecoveryRequestType {
+				m.Payload.Data = []byte{byte(m.Type), 0xff}
+				net.Node(2).Sign(m.Payload)
+			}
+			return true
+		})
+		net.Start()
+		net.WaitForHeight(3, waitTimeout)
+		requireSameChain(t, net, 3)
+		require.Zero(t, net.Stats().Rejected)
+	})
+}
+
+func TestNetwork_Validators(t *testing.T) {
+	net := New(t, Options{Validators: 1})
+	require.Equal(t, net.Validators().ScriptHash(), net.Committee().ScriptHash())
+	net.Start()
+	net.WaitForHeight(2, waitTimeout)
+}
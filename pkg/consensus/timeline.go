@@ -0,0 +1,224 @@
+package consensus
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/dbft"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// timelineRounds is the number of completed rounds kept in the timeline.
+	timelineRounds = 10
+	// maxTimelineMessages limits the number of messages recorded per round.
+	maxTimelineMessages = 1024
+)
+
+type (
+	// RoundTimeline contains consensus messages sent and received during
+	// a single dBFT round (height).
+	RoundTimeline struct {
+		Height uint32
+		Start  time.Time
+		// End is the time the round has finished at, it's zero for the
+		// current round.
+		End time.Time
+		// View is the last view number of the round.
+		View     byte
+		Messages []TimelineMessage
+	}
+
+	// TimelineMessage is a single consensus message of the round timeline.
+	TimelineMessage struct {
+		Time time.Time
+		Type dbft.MessageType
+		// Height is the message block index, it can differ from the round
+		// height for messages from nodes that are behind or ahead.
+		Height    uint32
+		View      byte
+		Validator uint16
+		// Sent is true for messages sent by this node.
+		Sent bool
+	}
+
+	// timeline keeps the current round and a number of previous ones.
+	timeline struct {
+		lock      sync.RWMutex
+		current   *RoundTimeline
+		prepared  bool
+		committed bool
+		// history is a list of completed rounds, the oldest one goes first.
+		history []RoundTimeline
+	}
+)
+
+// MarshalLogObject implements the zapcore.ObjectMarshaler interface.
+func (m TimelineMessage) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddTime("time", m.Time)
+	enc.AddString("type", m.Type.String())
+	enc.AddUint32("height", m.Height)
+	enc.AddUint8("view", m.View)
+	enc.AddUint16("validator", m.Validator)
+	enc.AddBool("sent", m.Sent)
+	return nil
+}
+
+// start begins a new round moving the current one (if any) to the history.
+func (t *timeline) start(height uint32, now time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.current != nil {
+		t.pushCurrent(now)
+	}
+	t.current = &RoundTimeline{Height: height, Start: now}
+	t.prepared = false
+	t.committed = false
+}
+
+// finish completes the current round and returns it, nil is returned if
+// there is no current round.
+func (t *timeline) finish(view byte, now time.Time) *RoundTimeline {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.current == nil {
+		return nil
+	}
+	t.current.View = view
+	r := t.pushCurrent(now)
+	t.current = nil
+	return &r
+}
+
+// pushCurrent moves the current round to the history, it must be called with
+// the lock held.
+func (t *timeline) pushCurrent(now time.Time) RoundTimeline {
+	r := *t.current
+	r.End = now
+	if len(t.history) == timelineRounds {
+		copy(t.history, t.history[1:])
+		t.history = t.history[:timelineRounds-1]
+	}
+	t.history = append(t.history, r)
+	return r
+}
+
+// add records the message in the current round. It returns the time elapsed
+// since the round start for the first PrepareRequest and the first Commit of
+// the round (zero otherwise).
+func (t *timeline) add(m TimelineMessage) time.Duration {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.current == nil {
+		return 0
+	}
+	if len(t.current.Messages) < maxTimelineMessages {
+		t.current.Messages = append(t.current.Messages, m)
+	}
+	if m.Height != t.current.Height {
+		return 0
+	}
+	switch {
+	case m.Type == dbft.PrepareRequestType && !t.prepared:
+		t.prepared = true
+	case m.Type == dbft.CommitType && !t.committed:
+		t.committed = true
+	default:
+		return 0
+	}
+	return m.Time.Sub(t.current.Start)
+}
+
+// setView updates the current round view number.
+func (t *timeline) setView(view byte) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.current != nil {
+		t.current.View = view
+	}
+}
+
+// rounds returns a copy of the history followed by the current round.
+func (t *timeline) rounds() []RoundTimeline {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	res := make([]RoundTimeline, 0, len(t.history)+1)
+	res = append(res, t.history...)
+	if t.current != nil {
+		res = append(res, *t.current)
+	}
+	for i := range res {
+		res[i].Messages = append([]TimelineMessage(nil), res[i].Messages...)
+	}
+	return res
+}
+
+// Timeline implements the TimelineReporter interface.
+func (s *service) Timeline() []RoundTimeline {
+	return s.timeline.rounds()
+}
+
+// trackMessage records the consensus message sent or received in the round
+// timeline and updates metrics.
+func (s *service) trackMessage(p *Payload, sent bool) {
+	m := TimelineMessage{
+		Time:      s.dbft.Timer.Now(),
+		Type:      p.Type(),
+		Height:    p.BlockIndex,
+		View:      p.ViewNumber(),
+		Validator: p.ValidatorIndex(),
+		Sent:      sent,
+	}
+	switch m.Type {
+	case dbft.ChangeViewType:
+		addChangeViewMetric(p.GetChangeView().Reason())
+	case dbft.RecoveryRequestType:
+		addRecoveryRequestMetric(sent)
+	}
+	if d := s.timeline.add(m); d != 0 {
+		if m.Type == dbft.PrepareRequestType {
+			addPrepareTimeMetric(d)
+		} else {
+			addCommitTimeMetric(d)
+		}
+	}
+}
+
+// trackViewChange updates metrics if dBFT has changed its view since the
+// given height and view were observed. Primaries of all views passed are
+// considered to be missed.
+func (s *service) trackViewChange(height uint32, view byte) {
+	if s.dbft.BlockIndex != height || s.dbft.ViewNumber <= view {
+		return
+	}
+	for v := view; v < s.dbft.ViewNumber; v++ {
+		if i := s.dbft.GetPrimaryIndex(v); int(i) < len(s.dbft.Validators) {
+			addMissedPrimaryMetric(s.dbft.Validators[i].(*publicKey).StringCompressed())
+		}
+	}
+	addViewChangesMetric(int(s.dbft.ViewNumber - view))
+	s.timeline.setView(s.dbft.ViewNumber)
+}
+
+// startRound starts a new round in the timeline.
+func (s *service) startRound() {
+	s.timeline.start(s.dbft.BlockIndex, s.dbft.Timer.Now())
+}
+
+// finishRound completes the current round when the block is accepted.
+func (s *service) finishRound(b uint32) {
+	r := s.timeline.finish(s.dbft.ViewNumber, s.dbft.Timer.Now())
+	if r == nil {
+		return
+	}
+	d := r.End.Sub(r.Start)
+	if r.Height == b {
+		addRoundTimeMetric(d)
+	}
+	s.log.Debug("round timeline",
+		zap.Uint32("height", r.Height),
+		zap.Uint8("view", r.View),
+		zap.Duration("duration", d),
+		zap.Objects("messages", r.Messages))
+}
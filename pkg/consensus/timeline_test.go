@@ -0,0 +1,92 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/dbft"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeline(t *testing.T) {
+	var (
+		tl  timeline
+		now = time.Now()
+	)
+	require.Nil(t, tl.finish(0, now))
+	require.Zero(t, tl.add(TimelineMessage{Type: dbft.PrepareRequestType}))
+	require.Empty(t, tl.rounds())
+
+	for h := uint32(1); h <= timelineRounds+2; h++ {
+		start := now.Add(time.Duration(h) * time.Minute)
+		tl.start(h, start)
+		// Messages for other heights are recorded, but don't affect metrics.
+		require.Zero(t, tl.add(TimelineMessage{Time: start.Add(time.Second), Type: dbft.PrepareRequestType, Height: h + 1}))
+		require.Equal(t, 2*time.Second, tl.add(TimelineMessage{Time: start.Add(2 * time.Second), Type: dbft.PrepareRequestType, Height: h}))
+		require.Zero(t, tl.add(TimelineMessage{Time: start.Add(3 * time.Second), Type: dbft.PrepareRequestType, Height: h}))
+		require.Zero(t, tl.add(TimelineMessage{Time: start.Add(3 * time.Second), Type: dbft.PrepareResponseType, Height: h}))
+		require.Equal(t, 4*time.Second, tl.add(TimelineMessage{Time: start.Add(4 * time.Second), Type: dbft.CommitType, Height: h}))
+		require.Zero(t, tl.add(TimelineMessage{Time: start.Add(5 * time.Second), Type: dbft.CommitType, Height: h}))
+		tl.setView(1)
+
+		rs := tl.rounds()
+		cur := rs[len(rs)-1]
+		require.Equal(t, h, cur.Height)
+		require.True(t, cur.End.IsZero())
+		require.Equal(t, byte(1), cur.View)
+		require.Equal(t, 6, len(cur.Messages))
+
+		if h%2 == 0 {
+			r := tl.finish(2, start.Add(10*time.Second))
+			require.Equal(t, h, r.Height)
+			require.Equal(t, byte(2), r.View)
+			require.Equal(t, 10*time.Second, r.End.Sub(r.Start))
+		}
+	}
+	// The last round is finished, so only the history is left.
+	rs := tl.rounds()
+	require.Equal(t, timelineRounds, len(rs))
+	for i, r := range rs {
+		require.Equal(t, uint32(i+3), r.Height)
+		require.False(t, r.End.IsZero())
+	}
+
+	// Returned rounds are copies.
+	rs[0].Messages[0].Height = 100
+	require.Equal(t, uint32(4), tl.rounds()[0].Messages[0].Height)
+}
+
+func TestService_Timeline(t *testing.T) {
+	srv := newTestService(t)
+	srv.dbft.Start(0)
+	srv.startRound()
+	height := srv.dbft.BlockIndex
+
+	// Three validators (M of 4) want to change view.
+	for i := 0; i < 3; i++ {
+		p := new(Payload)
+		p.message.Type = messageType(dbft.ChangeViewType)
+		p.payload = &changeView{newViewNumber: 1, timestamp: uint64(time.Now().UnixNano() / nsInMs), reason: dbft.CVTxNotFound}
+		p.BlockIndex = height
+		p.message.ValidatorIndex = byte(i + 1)
+		priv, _ := getTestValidator(i + 1)
+		require.NoError(t, p.Sign(priv))
+
+		view := srv.dbft.ViewNumber
+		srv.trackMessage(p, false)
+		srv.dbft.OnReceive(p)
+		srv.trackViewChange(height, view)
+	}
+	require.Equal(t, byte(1), srv.dbft.ViewNumber)
+
+	rs := srv.Timeline()
+	require.Equal(t, 1, len(rs))
+	require.Equal(t, height, rs[0].Height)
+	require.Equal(t, byte(1), rs[0].View)
+	require.Equal(t, 3, len(rs[0].Messages))
+	for i, m := range rs[0].Messages {
+		require.Equal(t, dbft.ChangeViewType, m.Type)
+		require.Equal(t, uint16(i+1), m.Validator)
+		require.False(t, m.Sent)
+	}
+}
@@ -45,12 +45,13 @@ import (
 
 // Tuning parameters.
 const (
-	version = "0.2.12"
+	version = "0.2.13"
 
 	// DefaultInitialGAS is the default amount of GAS emitted to the standby validators
 	// multisignature account during native GAS contract initialization.
 	DefaultInitialGAS                      = 52000000_00000000
 	defaultGCPeriod                        = 10000
+	defaultGCBudget                        = 10000
 	defaultMemPoolSize                     = 50000
 	defaultP2PNotaryRequestPayloadPoolSize = 1000
 	defaultMaxBlockSize                    = 262144
@@ -277,11 +278,13 @@ func NewBlockchain(s storage.Store, cfg config.Blockchain, log *zap.Logger) (*Bl
 		if cfg.KeepOnlyLatestState && !cfg.RemoveUntraceableBlocks {
 			return nil, errors.New("P2PStateExchangeExtensions can be enabled either on MPT-complete node (KeepOnlyLatestState=false) or on light GC-enabled node (RemoveUntraceableBlocks=true)")
 		}
-		if cfg.StateSyncInterval <= 0 {
-			cfg.StateSyncInterval = defaultStateSyncInterval
-			log.Info("StateSyncInterval is not set or wrong, using default value",
-				zap.Int("StateSyncInterval", cfg.StateSyncInterval))
-		}
+	}
+	if cfg.StateSyncInterval <= 0 {
+		// State sync points are also used by snapshots, so it's needed
+		// even without P2P state exchange.
+		cfg.StateSyncInterval = defaultStateSyncInterval
+		log.Info("StateSyncInterval is not set or wrong, using default value",
+			zap.Int("StateSyncInterval", cfg.StateSyncInterval))
 	}
 	if cfg.Hardforks == nil {
 		cfg.Hardforks = map[string]uint32{}
@@ -306,6 +309,10 @@ func NewBlockchain(s storage.Store, cfg config.Blockchain, log *zap.Logger) (*Bl
 		cfg.Ledger.GarbageCollectionPeriod = defaultGCPeriod
 		log.Info("GarbageCollectionPeriod is not set or wrong, using default value", zap.Uint32("GarbageCollectionPeriod", cfg.Ledger.GarbageCollectionPeriod))
 	}
+	if cfg.Ledger.RemoveUntraceableBlocks && cfg.Ledger.GarbageCollectionBudget == 0 {
+		cfg.Ledger.GarbageCollectionBudget = defaultGCBudget
+		log.Info("GarbageCollectionBudget is not set or wrong, using default value", zap.Uint32("GarbageCollectionBudget", cfg.Ledger.GarbageCollectionBudget))
+	}
 	bc := &Blockchain{
 		config:      cfg,
 		dao:         dao.NewSimple(s, cfg.StateRootInHeader),
@@ -481,8 +488,8 @@ func (bc *Blockchain) init() error {
 		if (stateChStage[0] & stateResetBit) != 0 {
 			return bc.resetStateInternal(stateSyncPoint, stateChangeStage(stateChStage[0]&(^stateResetBit)))
 		}
-		if !(bc.config.P2PStateExchangeExtensions && bc.config.Ledger.RemoveUntraceableBlocks) {
-			return errors.New("state jump was not completed, but P2PStateExchangeExtensions are disabled or archival node capability is on. " +
+		if !bc.config.Ledger.RemoveUntraceableBlocks {
+			return errors.New("state jump was not completed, but archival node capability is on. " +
 				"To start an archival node drop the database manually and restart the node")
 		}
 		return bc.jumpToStateInternal(stateSyncPoint, stateChangeStage(stateChStage[0]))
@@ -632,14 +639,32 @@ func (bc *Blockchain) jumpToStateInternal(p uint32, stage stateChangeStage) erro
 	default:
 		return fmt.Errorf("unknown state jump stage: %d", stage)
 	}
-	block, err := bc.dao.GetBlock(bc.GetHeaderHash(p + 1))
-	if err != nil {
-		return fmt.Errorf("failed to get block to init MPT: %w", err)
+	var (
+		sr  *state.MPTRoot
+		err error
+	)
+	if bc.config.StateRootInHeader {
+		block, err := bc.dao.GetBlock(bc.GetHeaderHash(p + 1))
+		if err != nil {
+			return fmt.Errorf("failed to get block to init MPT: %w", err)
+		}
+		sr = &state.MPTRoot{
+			Index: p,
+			Root:  block.PrevStateRoot,
+		}
+		// Keep the witness if the state root was restored from snapshot.
+		if stored, err := bc.stateRoot.GetStateRoot(p); err == nil && stored.Root.Equals(sr.Root) {
+			sr = stored
+		}
+	} else {
+		// Without StateRootInHeader the validated state root is stored by
+		// the state sync module.
+		sr, err = bc.stateRoot.GetStateRoot(p)
+		if err != nil {
+			return fmt.Errorf("failed to get state root to init MPT: %w", err)
+		}
 	}
-	bc.stateRoot.JumpToState(&state.MPTRoot{
-		Index: p,
-		Root:  block.PrevStateRoot,
-	})
+	bc.stateRoot.JumpToState(sr)
 
 	bc.dao.Store.Delete(jumpStageKey)
 
@@ -1127,6 +1152,9 @@ func (bc *Blockchain) tryRunGC(oldHeight uint32) time.Duration {
 	var dur time.Duration
 
 	newHeight := atomic.LoadUint32(&bc.persistedHeight)
+	if newHeight <= oldHeight {
+		return 0
+	}
 	var tgtBlock = int64(newHeight)
 
 	tgtBlock -= int64(bc.config.MaxTraceableBlocks)
@@ -1138,16 +1166,21 @@ func (bc *Blockchain) tryRunGC(oldHeight uint32) time.Duration {
 			tgtBlock = int64(syncP)
 		}
 	}
-	// Always round to the GCP.
+	if tgtBlock <= 0 {
+		return 0
+	}
+	// MPT GC is incremental, every persisted block allows to remove some
+	// number of stale nodes.
+	budget := int(newHeight-oldHeight) * int(bc.config.Ledger.GarbageCollectionBudget)
+	_, dur = bc.stateRoot.GC(uint32(tgtBlock), budget, bc.store)
+
+	// Transfer logs are still removed once per GCP.
 	tgtBlock /= int64(bc.config.Ledger.GarbageCollectionPeriod)
 	tgtBlock *= int64(bc.config.Ledger.GarbageCollectionPeriod)
 	// Count periods.
 	oldHeight /= bc.config.Ledger.GarbageCollectionPeriod
 	newHeight /= bc.config.Ledger.GarbageCollectionPeriod
 	if tgtBlock > int64(bc.config.Ledger.GarbageCollectionPeriod) && newHeight != oldHeight {
-		tgtBlock /= int64(bc.config.Ledger.GarbageCollectionPeriod)
-		tgtBlock *= int64(bc.config.Ledger.GarbageCollectionPeriod)
-		dur = bc.stateRoot.GC(uint32(tgtBlock), bc.store)
 		dur += bc.removeOldTransfers(uint32(tgtBlock))
 	}
 	return dur
@@ -1,6 +1,7 @@
 package mempool
 
 import (
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"math/bits"
@@ -64,6 +65,9 @@ type Pool struct {
 	conflicts map[util.Uint256][]util.Uint256
 	// oracleResp contains the ids of oracle responses for the tx in the pool.
 	oracleResp map[uint64]util.Uint256
+	// shortHashes maps short hashes (see ShortHash) of the tx in the pool to
+	// their full hashes, there can be several tx with the same short hash.
+	shortHashes map[uint32][]util.Uint256
 
 	capacity        int
 	feePerByte      int64
@@ -252,6 +256,7 @@ func (mp *Pool) Add(t *transaction.Transaction, fee Feer, data ...any) error {
 		unlucky := mp.verifiedTxes[len(mp.verifiedTxes)-1]
 		delete(mp.verifiedMap, unlucky.txn.Hash())
 		mp.removeConflictsOf(unlucky.txn)
+		mp.removeShortHash(unlucky.txn.Hash())
 		if attrs := unlucky.txn.GetAttributes(transaction.OracleResponseT); len(attrs) != 0 {
 			delete(mp.oracleResp, attrs[0].Value.(*transaction.OracleResponse).ID)
 		}
@@ -271,6 +276,8 @@ func (mp *Pool) Add(t *transaction.Transaction, fee Feer, data ...any) error {
 		mp.verifiedTxes[n] = pItem
 	}
 	mp.verifiedMap[t.Hash()] = t
+	sh := ShortHash(t.Hash())
+	mp.shortHashes[sh] = append(mp.shortHashes[sh], t.Hash())
 	// Add conflicting hashes to the mp.conflicts list.
 	for _, attr := range t.GetAttributes(transaction.ConflictsT) {
 		hash := attr.Value.(*transaction.Conflicts).Hash
@@ -324,6 +331,7 @@ func (mp *Pool) removeInternal(hash util.Uint256, feer Feer) {
 		mp.fees[payer] = senderFee
 		// remove all conflicting hashes from mp.conflicts list
 		mp.removeConflictsOf(tx)
+		mp.removeShortHash(hash)
 		if attrs := tx.GetAttributes(transaction.OracleResponseT); len(attrs) != 0 {
 			delete(mp.oracleResp, attrs[0].Value.(*transaction.OracleResponse).ID)
 		}
@@ -372,6 +380,7 @@ func (mp *Pool) RemoveStale(isOK func(*transaction.Transaction) bool, feer Feer)
 			}
 		} else {
 			delete(mp.verifiedMap, itm.txn.Hash())
+			mp.removeShortHash(itm.txn.Hash())
 			if attrs := itm.txn.GetAttributes(transaction.OracleResponseT); len(attrs) != 0 {
 				delete(mp.oracleResp, attrs[0].Value.(*transaction.OracleResponse).ID)
 			}
@@ -420,6 +429,7 @@ func New(capacity int, payerIndex int, enableSubscriptions bool, updateMetricsCb
 		fees:                 make(map[util.Uint160]utilityBalanceAndFees),
 		conflicts:            make(map[util.Uint256][]util.Uint256),
 		oracleResp:           make(map[uint64]util.Uint256),
+		shortHashes:          make(map[uint32][]util.Uint256, capacity),
 		subscriptionsEnabled: enableSubscriptions,
 		stopCh:               make(chan struct{}),
 		events:               make(chan mempoolevent.Event),
@@ -457,6 +467,46 @@ func (mp *Pool) TryGetValue(hash util.Uint256) (*transaction.Transaction, bool)
 	return nil, false
 }
 
+// ShortHash returns a short (32-bit) hash of the transaction with the given
+// hash, it's just the first 4 bytes of it. Short hashes can collide (and
+// collisions can be easily made deliberately), so they can only be used to
+// find candidates that are to be checked in some other way.
+func ShortHash(h util.Uint256) uint32 {
+	return binary.LittleEndian.Uint32(h[:4])
+}
+
+// GetByShortHash returns all transactions from the memory pool with the
+// given short hash (see ShortHash).
+func (mp *Pool) GetByShortHash(s uint32) []*transaction.Transaction {
+	mp.lock.RLock()
+	defer mp.lock.RUnlock()
+	var res = make([]*transaction.Transaction, 0, len(mp.shortHashes[s]))
+	for _, h := range mp.shortHashes[s] {
+		res = append(res, mp.verifiedMap[h])
+	}
+	return res
+}
+
+// removeShortHash removes the transaction with the given hash from the short
+// hash index.
+func (mp *Pool) removeShortHash(h util.Uint256) {
+	var (
+		s      = ShortHash(h)
+		hashes = mp.shortHashes[s]
+	)
+	for i := range hashes {
+		if hashes[i] == h {
+			hashes = append(hashes[:i], hashes[i+1:]...)
+			break
+		}
+	}
+	if len(hashes) == 0 {
+		delete(mp.shortHashes, s)
+	} else {
+		mp.shortHashes[s] = hashes
+	}
+}
+
 // TryGetData returns data associated with the specified transaction if it exists in the memory pool.
 func (mp *Pool) TryGetData(hash util.Uint256) (any, bool) {
 	mp.lock.RLock()
@@ -419,6 +419,46 @@ func TestMempoolAddRemoveOracleResponse(t *testing.T) {
 	}
 }
 
+func TestMempoolShortHash(t *testing.T) {
+	mp := New(2, 0, false, nil)
+	fs := &FeerStub{balance: 10000}
+	txs := make([]*transaction.Transaction, 3)
+	for i := range txs {
+		txs[i] = transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+		txs[i].NetworkFee = int64(i + 1)
+		txs[i].Nonce = uint32(i)
+		txs[i].Signers = []transaction.Signer{{Account: util.Uint160{1, 2, 3}}}
+		require.NoError(t, mp.Add(txs[i], fs))
+	}
+	checkShortHash := func(tx *transaction.Transaction, expected bool) {
+		if expected {
+			require.Contains(t, mp.GetByShortHash(ShortHash(tx.Hash())), tx)
+		} else {
+			require.NotContains(t, mp.GetByShortHash(ShortHash(tx.Hash())), tx)
+		}
+	}
+	// The first one is evicted because of the capacity.
+	checkShortHash(txs[0], false)
+	checkShortHash(txs[1], true)
+	checkShortHash(txs[2], true)
+
+	mp.Remove(txs[1].Hash(), fs)
+	checkShortHash(txs[1], false)
+
+	// Collisions are handled.
+	var (
+		h     = txs[2].Hash()
+		other = h
+	)
+	other[util.Uint256Size-1]++
+	mp.shortHashes[ShortHash(h)] = append([]util.Uint256{other}, mp.shortHashes[ShortHash(h)]...)
+	checkShortHash(txs[2], true)
+
+	mp.RemoveStale(func(*transaction.Transaction) bool { return false }, fs)
+	checkShortHash(txs[2], false)
+	require.Equal(t, []util.Uint256{other}, mp.shortHashes[ShortHash(h)])
+}
+
 func TestMempoolAddRemoveConflicts(t *testing.T) {
 	var (
 		capacity        = 6
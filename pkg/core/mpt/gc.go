@@ -0,0 +1,78 @@
+package mpt
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// staleKeyLen is the length of stale node index key: prefix, height and
+// node hash.
+const staleKeyLen = 1 + 4 + util.Uint256Size
+
+// makeStaleKey returns stale node index key for the node with the given hash
+// that became unreferenced at the given height. Height is stored in BE
+// to have all entries sorted by it.
+func makeStaleKey(index uint32, h util.Uint256) []byte {
+	key := make([]byte, staleKeyLen)
+	key[0] = byte(storage.DataMPTStale)
+	binary.BigEndian.PutUint32(key[1:], index)
+	copy(key[5:], h[:])
+	return key
+}
+
+// CollectGarbage removes nodes that became stale at or before the given index
+// from the store of GC-enabled MPT. Every time node's reference counter drops
+// to zero, the node is marked as stale and an entry is added to the stale nodes
+// index, so that GC doesn't need to iterate over all MPT nodes. The node can be
+// referenced again after that, then it's kept. At most limit index entries are
+// processed. It returns the number of removed nodes and the height stale nodes
+// are removed up to (which is less than index if there are more entries to
+// process). Changes are made to the given store, it's up to the caller to
+// persist them.
+func CollectGarbage(store *storage.MemCachedStore, index uint32, limit int) (int, uint32) {
+	if limit <= 0 {
+		return 0, 0
+	}
+	var (
+		keys   [][]byte
+		height = index
+	)
+	store.Seek(storage.SeekRange{Prefix: []byte{byte(storage.DataMPTStale)}}, func(k, _ []byte) bool {
+		if len(k) != staleKeyLen {
+			return true
+		}
+		h := binary.BigEndian.Uint32(k[1:])
+		if h > index {
+			return false
+		}
+		if len(keys) == limit {
+			height = 0
+			if h > 0 {
+				height = h - 1
+			}
+			return false
+		}
+		keys = append(keys, bytes.Clone(k))
+		return true
+	})
+
+	var (
+		removed int
+		nodeKey = makeStorageKey(util.Uint256{})
+	)
+	for _, k := range keys {
+		copy(nodeKey[1:], k[5:])
+		data, err := store.Get(nodeKey)
+		// The node may have been referenced again (and possibly became stale at
+		// some other height which has its own index entry).
+		if err == nil && !IsActiveValue(data) && binary.LittleEndian.Uint32(data[len(data)-4:]) == binary.BigEndian.Uint32(k[1:]) {
+			store.Delete(nodeKey)
+			removed++
+		}
+		store.Delete(k)
+	}
+	return removed, height
+}
@@ -0,0 +1,75 @@
+package mpt
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func countKeys(t *testing.T, st *storage.MemCachedStore, prefix storage.KeyPrefix) int {
+	var n int
+	st.Seek(storage.SeekRange{Prefix: []byte{byte(prefix)}}, func(k, _ []byte) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+func TestCollectGarbage(t *testing.T) {
+	var (
+		st = newTestStore()
+		tr = NewTrie(nil, ModeGC, st)
+		k1 = []byte{0x11}
+		k2 = []byte{0x12}
+	)
+	require.NoError(t, tr.Put(k1, []byte{1}))
+	require.NoError(t, tr.Put(k2, []byte{1}))
+	tr.Flush(1)
+	r1 := tr.StateRoot()
+	require.Equal(t, 3, countKeys(t, st, storage.DataMPT)) // Extension, branch and a single leaf.
+	require.Equal(t, 0, countKeys(t, st, storage.DataMPTStale))
+
+	require.NoError(t, tr.Put(k1, []byte{2}))
+	tr.Flush(2)
+	require.Equal(t, 6, countKeys(t, st, storage.DataMPT))
+	require.Equal(t, 2, countKeys(t, st, storage.DataMPTStale))
+
+	// Revive nodes that became stale at 2.
+	require.NoError(t, tr.Put(k1, []byte{1}))
+	tr.Flush(3)
+	require.Equal(t, r1, tr.StateRoot())
+	require.Equal(t, 6, countKeys(t, st, storage.DataMPT))
+	require.Equal(t, 5, countKeys(t, st, storage.DataMPTStale))
+
+	t.Run("zero limit", func(t *testing.T) {
+		removed, h := CollectGarbage(st, 3, 0)
+		require.Equal(t, 0, removed)
+		require.Equal(t, uint32(0), h)
+	})
+
+	removed, h := CollectGarbage(st, 1, 100)
+	require.Equal(t, 0, removed)
+	require.Equal(t, uint32(1), h)
+
+	removed, h = CollectGarbage(st, 3, 1)
+	require.Equal(t, 0, removed) // Revived one.
+	require.Equal(t, uint32(1), h)
+	require.Equal(t, 4, countKeys(t, st, storage.DataMPTStale))
+
+	removed, h = CollectGarbage(st, 2, 100)
+	require.Equal(t, 0, removed)
+	require.Equal(t, uint32(2), h)
+	require.Equal(t, 6, countKeys(t, st, storage.DataMPT))
+	require.Equal(t, 3, countKeys(t, st, storage.DataMPTStale))
+
+	removed, h = CollectGarbage(st, 3, 100)
+	require.Equal(t, 3, removed)
+	require.Equal(t, uint32(3), h)
+	require.Equal(t, 3, countKeys(t, st, storage.DataMPT))
+	require.Equal(t, 0, countKeys(t, st, storage.DataMPTStale))
+
+	tr = NewTrie(NewHashNode(r1), ModeGC, st)
+	tr.testHas(t, k1, []byte{1})
+	tr.testHas(t, k2, []byte{1})
+}
@@ -76,3 +76,69 @@ func VerifyProof(rh util.Uint256, key []byte, proofs [][]byte) ([]byte, bool) {
 	}
 	return bytes.Clone(leaf.(*LeafNode).value), true
 }
+
+// VerifyLastProof is similar to VerifyProof, but it also checks that key is
+// the last one with the given prefix in the MPT, that is, there are no
+// greater keys with this prefix. It can be used to prove the completeness of
+// the prefix search results. Branch nodes of the proof contain hashes of all
+// their children, so any key following the given one would be visible as a
+// non-empty child to the right of the path.
+func VerifyLastProof(rh util.Uint256, prefix []byte, key []byte, proofs [][]byte) ([]byte, bool) {
+	if !bytes.HasPrefix(key, prefix) {
+		return nil, false
+	}
+	var (
+		path  = toNibbles(key)
+		plen  = len(prefix) * 2
+		depth int
+		tr    = NewTrie(NewHashNode(rh), ModeAll, storage.NewMemCachedStore(storage.NewMemoryStore()))
+		curr  = tr.root
+		err   error
+	)
+	for i := range proofs {
+		h := hash.DoubleSha256(proofs[i])
+		tr.Store.Put(makeStorageKey(h), proofs[i])
+	}
+	for {
+		switch n := curr.(type) {
+		case *HashNode:
+			curr, err = tr.getFromStore(n.Hash())
+			if err != nil {
+				return nil, false
+			}
+		case *BranchNode:
+			if depth == len(path) {
+				// All non-value children are longer keys.
+				for i := 0; i < lastChild; i++ {
+					if !isEmpty(n.Children[i]) {
+						return nil, false
+					}
+				}
+				curr = n.Children[lastChild]
+				continue
+			}
+			if depth >= plen {
+				for i := int(path[depth]) + 1; i < lastChild; i++ {
+					if !isEmpty(n.Children[i]) {
+						return nil, false
+					}
+				}
+			}
+			curr = n.Children[path[depth]]
+			depth++
+		case *ExtensionNode:
+			if !bytes.HasPrefix(path[depth:], n.key) {
+				return nil, false
+			}
+			depth += len(n.key)
+			curr = n.next
+		case *LeafNode:
+			if depth != len(path) {
+				return nil, false
+			}
+			return bytes.Clone(n.value), true
+		default:
+			return nil, false
+		}
+	}
+}
@@ -74,3 +74,45 @@ func TestVerifyProof(t *testing.T) {
 		require.Equal(t, []byte("somevalue"), v)
 	})
 }
+
+func TestVerifyLastProof(t *testing.T) {
+	tr := NewTrie(nil, ModeAll, newTestStore())
+	for _, k := range [][]byte{{0x12, 0x31}, {0x12, 0x32}, {0x12, 0x41}, {0x13}, {0x20, 0x01}} {
+		require.NoError(t, tr.Put(k, append([]byte("value"), k...)))
+	}
+	check := func(t *testing.T, prefix, key []byte, expected bool) {
+		proof, err := tr.GetProof(key)
+		require.NoError(t, err)
+		v, ok := VerifyLastProof(tr.StateRoot(), prefix, key, proof)
+		require.Equal(t, expected, ok)
+		if expected {
+			require.Equal(t, append([]byte("value"), key...), v)
+		}
+	}
+
+	t.Run("last", func(t *testing.T) {
+		check(t, []byte{0x12}, []byte{0x12, 0x41}, true)
+		check(t, []byte{0x12, 0x3}, []byte{0x12, 0x32}, false) // Not a prefix of the key.
+		check(t, []byte{0x12, 0x32}, []byte{0x12, 0x32}, true)
+		check(t, []byte{}, []byte{0x20, 0x01}, true)
+		check(t, []byte{0x13}, []byte{0x13}, true)
+	})
+	t.Run("not last", func(t *testing.T) {
+		check(t, []byte{0x12}, []byte{0x12, 0x31}, false)
+		check(t, []byte{0x12}, []byte{0x12, 0x32}, false)
+		check(t, []byte{}, []byte{0x13}, false)
+	})
+	t.Run("longer key", func(t *testing.T) {
+		require.NoError(t, tr.Put([]byte{0x12, 0x41, 0x01}, []byte("value\x12\x41\x01")))
+		check(t, []byte{0x12}, []byte{0x12, 0x41}, false)
+		check(t, []byte{0x12}, []byte{0x12, 0x41, 0x01}, true)
+	})
+	t.Run("bad proof", func(t *testing.T) {
+		proof, err := tr.GetProof([]byte{0x20, 0x01})
+		require.NoError(t, err)
+		_, ok := VerifyLastProof(tr.StateRoot(), nil, []byte{0x20, 0x02}, proof)
+		require.False(t, ok)
+		_, ok = VerifyLastProof(tr.StateRoot(), nil, []byte{0x20, 0x01}, proof[1:])
+		require.False(t, ok)
+	})
+}
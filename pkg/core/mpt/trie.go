@@ -471,6 +471,7 @@ func (t *Trie) updateRefCount(h util.Uint256, key []byte, index uint32) int32 {
 			data[len(data)-5] = 0
 			binary.LittleEndian.PutUint32(data[len(data)-4:], index)
 			t.Store.Put(key, data)
+			t.Store.Put(makeStaleKey(index, h), []byte{})
 		}
 	default:
 		binary.LittleEndian.PutUint32(data[len(data)-4:], uint32(cnt))
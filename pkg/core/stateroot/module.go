@@ -208,11 +208,13 @@ func (s *Module) CleanStorage() error {
 		return fmt.Errorf("can't clean MPT data for non-genesis block: expected local stateroot height 0, got %d", lH)
 	}
 	b := storage.NewMemCachedStore(s.Store)
-	s.Store.Seek(storage.SeekRange{Prefix: []byte{byte(storage.DataMPT)}}, func(k, _ []byte) bool {
-		// #1468, but don't need to copy here, because it is done by Store.
-		b.Delete(k)
-		return true
-	})
+	for _, p := range []storage.KeyPrefix{storage.DataMPT, storage.DataMPTStale} {
+		s.Store.Seek(storage.SeekRange{Prefix: []byte{byte(p)}}, func(k, _ []byte) bool {
+			// #1468, but don't need to copy here, because it is done by Store.
+			b.Delete(k)
+			return true
+		})
+	}
 	_, err := b.Persist()
 	if err != nil {
 		return fmt.Errorf("failed to remove outdated MPT-reated items: %w", err)
@@ -295,39 +297,29 @@ func (s *Module) ResetState(height uint32, cache *storage.MemCachedStore) error
 	return nil
 }
 
-// GC performs garbage collection.
-func (s *Module) GC(index uint32, store storage.Store) time.Duration {
+// GC performs incremental garbage collection removing at most limit MPT
+// nodes that became stale at or before the given index. It returns the number
+// of removed nodes and the time spent.
+func (s *Module) GC(index uint32, limit int, store storage.Store) (int, time.Duration) {
 	if !s.mode.GC() {
 		panic("stateroot: GC invoked, but not enabled")
 	}
-	var removed int
-	var stored int64
-	s.log.Info("starting MPT garbage collection", zap.Uint32("index", index))
 	start := time.Now()
-	err := store.SeekGC(storage.SeekRange{
-		Prefix: []byte{byte(storage.DataMPT)},
-	}, func(k, v []byte) bool {
-		stored++
-		if !mpt.IsActiveValue(v) {
-			h := binary.LittleEndian.Uint32(v[len(v)-4:])
-			if h <= index {
-				removed++
-				stored--
-				return false
-			}
-		}
-		return true
-	})
+	b := storage.NewMemCachedStore(store)
+	removed, height := mpt.CollectGarbage(b, index, limit)
+	_, err := b.Persist()
 	dur := time.Since(start)
 	if err != nil {
 		s.log.Error("failed to flush MPT GC changeset", zap.Duration("time", dur), zap.Error(err))
-	} else {
-		s.log.Info("finished MPT garbage collection",
-			zap.Int("removed", removed),
-			zap.Int64("kept", stored),
-			zap.Duration("time", dur))
+		return 0, dur
 	}
-	return dur
+	updateGCMetrics(removed, height, dur)
+	s.log.Debug("MPT garbage collection step",
+		zap.Uint32("index", index),
+		zap.Uint32("height", height),
+		zap.Int("removed", removed),
+		zap.Duration("time", dur))
+	return removed, dur
 }
 
 // AddMPTBatch updates using provided batch.
@@ -363,6 +355,12 @@ func (s *Module) VerifyStateRoot(r *state.MPTRoot) error {
 	if err != nil {
 		return errors.New("can't get previous state root")
 	}
+	return s.VerifyWitness(r)
+}
+
+// VerifyWitness checks that the state root is signed by state validators
+// designated for its height.
+func (s *Module) VerifyWitness(r *state.MPTRoot) error {
 	if len(r.Witness) != 1 {
 		return errors.New("no witness")
 	}
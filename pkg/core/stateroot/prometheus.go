@@ -1,20 +1,63 @@
 package stateroot
 
-import "github.com/prometheus/client_golang/prometheus"
+import (
+	"time"
 
-// stateHeight prometheus metric.
-var stateHeight = prometheus.NewGauge(
-	prometheus.GaugeOpts{
-		Help:      "Current verified state height",
-		Name:      "current_state_height",
-		Namespace: "neogo",
-	},
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics for monitoring service.
+var (
+	// stateHeight prometheus metric.
+	stateHeight = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Help:      "Current verified state height",
+			Name:      "current_state_height",
+			Namespace: "neogo",
+		},
+	)
+	// gcRemovedNodes prometheus metric.
+	gcRemovedNodes = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Help:      "Number of stale MPT nodes removed by GC",
+			Name:      "mpt_gc_removed_nodes",
+			Namespace: "neogo",
+		},
+	)
+	// gcHeight prometheus metric.
+	gcHeight = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Help:      "Height stale MPT nodes are removed up to",
+			Name:      "mpt_gc_height",
+			Namespace: "neogo",
+		},
+	)
+	// gcTime prometheus metric.
+	gcTime = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Help:      "Time spent on a single MPT GC step",
+			Name:      "mpt_gc_time",
+			Namespace: "neogo",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 14),
+		},
+	)
 )
 
 func init() {
-	prometheus.MustRegister(stateHeight)
+	prometheus.MustRegister(
+		stateHeight,
+		gcRemovedNodes,
+		gcHeight,
+		gcTime,
+	)
 }
 
 func updateStateHeightMetric(sHeight uint32) {
 	stateHeight.Set(float64(sHeight))
 }
+
+func updateGCMetrics(removed int, height uint32, dur time.Duration) {
+	gcRemovedNodes.Add(float64(removed))
+	gcHeight.Set(float64(height))
+	gcTime.Observe(dur.Seconds())
+}
@@ -55,6 +55,17 @@ func makeStateRootKey(index uint32) []byte {
 	return key
 }
 
+// PutStateRoot stores the state root signed by state validators without
+// changing the local state. It's used by the state synchronisation for the
+// state root of the state sync point before its MPT is restored.
+func (s *Module) PutStateRoot(sr *state.MPTRoot) error {
+	if err := s.VerifyWitness(sr); err != nil {
+		return err
+	}
+	putStateRoot(s.Store, makeStateRootKey(sr.Index), sr)
+	return nil
+}
+
 // AddStateRoot adds validated state root provided by network.
 func (s *Module) AddStateRoot(sr *state.MPTRoot) error {
 	if err := s.VerifyStateRoot(sr); err != nil {
@@ -29,7 +29,7 @@ func (s *Module) UpdateStateValidators(height uint32, pubs keys.PublicKeys) {
 
 func (s *Module) getKeyCacheForHeight(h uint32) keyCache {
 	for i := len(s.keys) - 1; i >= 0; i-- {
-		if s.keys[i].height <= h && (i+1 == len(s.keys) || s.keys[i+1].height < h) {
+		if s.keys[i].height <= h {
 			return s.keys[i]
 		}
 	}
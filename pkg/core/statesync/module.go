@@ -27,8 +27,10 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/dao"
 	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
 	"github.com/nspcc-dev/neo-go/pkg/core/stateroot"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"go.uber.org/zap"
@@ -64,7 +66,9 @@ const (
 type Ledger interface {
 	AddHeaders(...*block.Header) error
 	BlockHeight() uint32
+	GetBlock(hash util.Uint256) (*block.Block, error)
 	GetConfig() config.Blockchain
+	GetDesignatedByRole(r noderoles.Role) (keys.PublicKeys, uint32, error)
 	GetHeader(hash util.Uint256) (*block.Header, error)
 	GetHeaderHash(uint32) util.Uint256
 	HeaderHeight() uint32
@@ -99,10 +103,13 @@ type Module struct {
 func NewModule(bc Ledger, stateMod *stateroot.Module, log *zap.Logger, s *dao.Simple, jumpCallback func(p uint32) error) *Module {
 	if !(bc.GetConfig().P2PStateExchangeExtensions && bc.GetConfig().Ledger.RemoveUntraceableBlocks) {
 		return &Module{
-			dao:       s,
-			bc:        bc,
-			stateMod:  stateMod,
-			syncStage: inactive,
+			dao:          s,
+			bc:           bc,
+			stateMod:     stateMod,
+			log:          log,
+			syncInterval: uint32(bc.GetConfig().StateSyncInterval),
+			syncStage:    inactive,
+			jumpCallback: jumpCallback,
 		}
 	}
 	return &Module{
@@ -214,22 +221,22 @@ func (s *Module) defineSyncStage() error {
 		s.log.Info("MPT is in sync",
 			zap.Uint32("stateroot height", s.stateMod.CurrentLocalHeight()))
 	} else if s.syncStage&headersSynced != 0 {
-		header, err := s.bc.GetHeader(s.bc.GetHeaderHash(s.syncPoint + 1))
+		root, err := s.syncPointRoot()
 		if err != nil {
-			return fmt.Errorf("failed to get header to initialize MPT billet: %w", err)
+			return fmt.Errorf("failed to initialize MPT billet: %w", err)
 		}
 		var mode mpt.TrieMode
 		// No need to enable GC here, it only has latest things.
 		if s.bc.GetConfig().Ledger.KeepOnlyLatestState || s.bc.GetConfig().Ledger.RemoveUntraceableBlocks {
 			mode |= mpt.ModeLatest
 		}
-		s.billet = mpt.NewBillet(header.PrevStateRoot, mode,
+		s.billet = mpt.NewBillet(root, mode,
 			TemporaryPrefix(s.dao.Version.StoragePrefix), s.dao.Store)
 		s.log.Info("MPT billet initialized",
 			zap.Uint32("height", s.syncPoint),
-			zap.String("state root", header.PrevStateRoot.StringBE()))
+			zap.String("state root", root.StringBE()))
 		pool := NewPool()
-		pool.Add(header.PrevStateRoot, []byte{})
+		pool.Add(root, []byte{})
 		err = s.billet.Traverse(func(_ []byte, n mpt.Node, _ []byte) bool {
 			nPaths, ok := pool.TryGet(n.Hash())
 			if !ok {
@@ -265,6 +272,24 @@ func (s *Module) defineSyncStage() error {
 	return nil
 }
 
+// syncPointRoot returns the state root hash for the current state sync point.
+// It's taken from the next block header if StateRootInHeader is enabled and
+// from the validated state root stored by the snapshot import otherwise.
+func (s *Module) syncPointRoot() (util.Uint256, error) {
+	if !s.bc.GetConfig().StateRootInHeader {
+		sr, err := s.stateMod.GetStateRoot(s.syncPoint)
+		if err != nil {
+			return util.Uint256{}, fmt.Errorf("failed to get state root: %w", err)
+		}
+		return sr.Root, nil
+	}
+	header, err := s.bc.GetHeader(s.bc.GetHeaderHash(s.syncPoint + 1))
+	if err != nil {
+		return util.Uint256{}, fmt.Errorf("failed to get header: %w", err)
+	}
+	return header.PrevStateRoot, nil
+}
+
 // getLatestSavedBlock returns either current block index (if it's still relevant
 // to continue state sync process) or H-1 where H is the index of the earliest
 // block that should be saved next.
@@ -0,0 +1,517 @@
+package statesync
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
+	"github.com/nspcc-dev/neo-go/pkg/core/native"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"go.uber.org/zap"
+)
+
+// Snapshot is a single-file archive containing all the data needed to perform
+// state synchronisation to some state sync point P without P2P exchange. It
+// consists of:
+//
+//   - snapshotMagic, snapshotVersion, network magic and P;
+//   - state root for P (with witness if it was validated on the exporting node);
+//   - StateValidator designations proofs (see snapshotDesignations), empty
+//     if state root has no witness;
+//   - headers from 1 to P+1;
+//   - blocks from P-MaxTraceableBlocks+1 (or 1) to P;
+//   - MPT nodes for P (in traversal order, parents go before children)
+//     terminated by an empty element.
+//
+// All of the data is verified on import: headers are checked by the chain,
+// state root witness is checked against StateValidator nodes designated for P
+// (starting from the ones known from the genesis block), with
+// StateRootInHeader state root must also match the one from P+1 header
+// (signed by consensus nodes) and MPT nodes are checked against this root.
+const (
+	snapshotMagic   uint32 = 0x4e53474e // "NGSN"
+	snapshotVersion byte   = 1
+
+	// snapshotBatchSize is the number of headers or MPT nodes added to the
+	// chain at once.
+	snapshotBatchSize = 2000
+)
+
+// roleManagementID is the ID of native RoleManagement contract, its storage
+// is a part of MPT.
+const roleManagementID = -8
+
+// ErrSnapshotMismatch is returned when the snapshot can't be restored
+// because it's incompatible with the node configuration or state.
+var ErrSnapshotMismatch = errors.New("snapshot mismatch")
+
+// snapshotDesignation is a proof of StateValidator designation for Height
+// taken from the state root for Height-1 signed by the previous StateValidator
+// nodes.
+type snapshotDesignation struct {
+	Root   state.MPTRoot
+	Height uint32
+	Proof  [][]byte
+}
+
+// snapshotDesignations is a chain of StateValidator designations made after
+// the genesis block up to P and the proof of the last one being the last
+// designation in the state root for P.
+type snapshotDesignations struct {
+	List      []snapshotDesignation
+	Last      uint32
+	LastProof [][]byte
+}
+
+// EncodeBinary implements the io.Serializable interface.
+func (d *snapshotDesignations) EncodeBinary(w *io.BinWriter) {
+	w.WriteVarUint(uint64(len(d.List)))
+	for i := range d.List {
+		d.List[i].Root.EncodeBinary(w)
+		w.WriteU32LE(d.List[i].Height)
+		writeProof(w, d.List[i].Proof)
+	}
+	w.WriteU32LE(d.Last)
+	writeProof(w, d.LastProof)
+}
+
+// DecodeBinary implements the io.Serializable interface.
+func (d *snapshotDesignations) DecodeBinary(r *io.BinReader) {
+	n := r.ReadVarUint()
+	if n > maxSnapshotDesignations {
+		r.Err = fmt.Errorf("too many designations: %d", n)
+		return
+	}
+	d.List = make([]snapshotDesignation, n)
+	for i := range d.List {
+		d.List[i].Root.DecodeBinary(r)
+		d.List[i].Height = r.ReadU32LE()
+		d.List[i].Proof = readProof(r)
+	}
+	d.Last = r.ReadU32LE()
+	d.LastProof = readProof(r)
+}
+
+// maxSnapshotDesignations is the maximum number of designations in the
+// snapshot, it's just a sanity limit.
+const maxSnapshotDesignations = 0xffff
+
+func writeProof(w *io.BinWriter, proof [][]byte) {
+	w.WriteVarUint(uint64(len(proof)))
+	for i := range proof {
+		w.WriteVarBytes(proof[i])
+	}
+}
+
+func readProof(r *io.BinReader) [][]byte {
+	n := r.ReadVarUint()
+	if n > mpt.MaxKeyLength*2+1 {
+		r.Err = fmt.Errorf("proof is too long: %d", n)
+		return nil
+	}
+	proof := make([][]byte, n)
+	for i := range proof {
+		proof[i] = r.ReadVarBytes()
+	}
+	return proof
+}
+
+// makeDesignationKey returns MPT key for StateValidator designation made for
+// the given height.
+func makeDesignationKey(height uint32) []byte {
+	var (
+		id  int32 = roleManagementID
+		key       = make([]byte, 9)
+	)
+	binary.LittleEndian.PutUint32(key, uint32(id))
+	key[4] = byte(noderoles.StateValidator)
+	binary.BigEndian.PutUint32(key[5:], height)
+	return key
+}
+
+// dumpDesignations collects proofs for all StateValidator designations made
+// after the genesis block from the state for sr. Every designation must be
+// proven by the signed state root preceding it.
+func (s *Module) dumpDesignations(sr *state.MPTRoot) (*snapshotDesignations, error) {
+	var (
+		res     = new(snapshotDesignations)
+		heights []uint32
+		prefix  = makeDesignationKey(0)[:5]
+	)
+	s.stateMod.SeekStates(sr.Root, prefix, func(k, _ []byte) bool {
+		if len(k) == 4 {
+			heights = append(heights, binary.BigEndian.Uint32(k))
+		}
+		return true
+	})
+	if len(heights) == 0 {
+		return nil, errors.New("no StateValidator designations")
+	}
+	for _, h := range heights {
+		if h <= 1 { // Genesis designation is known to every node.
+			continue
+		}
+		root, err := s.stateMod.GetStateRoot(h - 1)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get state root %d: %w", h-1, err)
+		}
+		if len(root.Witness) == 0 {
+			return nil, fmt.Errorf("state root %d is not signed", h-1)
+		}
+		proof, err := s.stateMod.GetStateProof(root.Root, makeDesignationKey(h))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get designation proof for %d: %w", h, err)
+		}
+		res.List = append(res.List, snapshotDesignation{Root: *root, Height: h, Proof: proof})
+	}
+	res.Last = heights[len(heights)-1]
+	proof, err := s.stateMod.GetStateProof(sr.Root, makeDesignationKey(res.Last))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get designation proof for %d: %w", res.Last, err)
+	}
+	res.LastProof = proof
+	return res, nil
+}
+
+// verifyDesignations checks the chain of designations starting from the
+// genesis StateValidator nodes and updates state validators in the state
+// module. Then it checks that sr witness is valid and that no other
+// designations were made up to sr.
+func (s *Module) verifyDesignations(sr *state.MPTRoot, d *snapshotDesignations) error {
+	pubs, h, err := s.bc.GetDesignatedByRole(noderoles.StateValidator)
+	if err != nil {
+		return fmt.Errorf("failed to get genesis StateValidator nodes: %w", err)
+	}
+	if len(pubs) != 0 {
+		s.stateMod.UpdateStateValidators(h, pubs)
+	}
+	for i := range d.List {
+		des := &d.List[i]
+		if des.Height <= h || des.Root.Index+1 != des.Height || des.Height > sr.Index+1 {
+			return fmt.Errorf("%w: unexpected designation for %d", ErrSnapshotMismatch, des.Height)
+		}
+		err = s.stateMod.VerifyWitness(&des.Root)
+		if err != nil {
+			return fmt.Errorf("%w: invalid state root %d: %v", ErrSnapshotMismatch, des.Root.Index, err)
+		}
+		val, ok := mpt.VerifyProof(des.Root.Root, makeDesignationKey(des.Height), des.Proof)
+		if !ok {
+			return fmt.Errorf("%w: invalid designation proof for %d", ErrSnapshotMismatch, des.Height)
+		}
+		pubs, err = decodeDesignation(val)
+		if err != nil {
+			return fmt.Errorf("%w: designation for %d: %v", ErrSnapshotMismatch, des.Height, err)
+		}
+		h = des.Height
+		s.stateMod.UpdateStateValidators(h, pubs)
+	}
+	if d.Last != h {
+		return fmt.Errorf("%w: designation for %d is missing", ErrSnapshotMismatch, d.Last)
+	}
+	err = s.stateMod.VerifyWitness(sr)
+	if err != nil {
+		return fmt.Errorf("%w: invalid state root: %v", ErrSnapshotMismatch, err)
+	}
+	key := makeDesignationKey(d.Last)
+	val, ok := mpt.VerifyLastProof(sr.Root, key[:5], key, d.LastProof)
+	if !ok {
+		return fmt.Errorf("%w: designation for %d is not the last one", ErrSnapshotMismatch, d.Last)
+	}
+	last, err := decodeDesignation(val)
+	if err != nil || !equalKeys(last, s.stateMod.GetStateValidators(d.Last)) {
+		return fmt.Errorf("%w: designation for %d doesn't match", ErrSnapshotMismatch, d.Last)
+	}
+	return nil
+}
+
+func equalKeys(a, b keys.PublicKeys) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func decodeDesignation(val []byte) (keys.PublicKeys, error) {
+	var ns native.NodeList
+	err := stackitem.DeserializeConvertible(val, &ns)
+	return keys.PublicKeys(ns), err
+}
+
+// LatestSyncPoint returns the latest state sync point that can be exported
+// from the current chain (the next block header is required for it).
+func (s *Module) LatestSyncPoint() (uint32, error) {
+	interval := uint32(s.bc.GetConfig().StateSyncInterval)
+	if interval == 0 {
+		return 0, errors.New("StateSyncInterval is not set")
+	}
+	h := s.bc.BlockHeight()
+	if h == 0 {
+		return 0, errors.New("chain is too low")
+	}
+	return ((h - 1) / interval) * interval, nil
+}
+
+// DumpSnapshot writes the state snapshot for the state sync point p to the
+// given writer. The chain must have headers up to p+1, blocks up to p and
+// MPT for the p height. Signed state roots and MPT for every StateValidator
+// designation are also required to prove the state root for p, without
+// StateRootInHeader it's the only way to verify it.
+func (s *Module) DumpSnapshot(w *io.BinWriter, p uint32) error {
+	cfg := s.bc.GetConfig()
+	if cfg.StateSyncInterval == 0 || p%uint32(cfg.StateSyncInterval) != 0 {
+		return fmt.Errorf("%d is not a state sync point", p)
+	}
+	if s.bc.BlockHeight() < p+1 {
+		return fmt.Errorf("chain is too low (%d) to dump state for %d", s.bc.BlockHeight(), p)
+	}
+	sr, err := s.stateMod.GetStateRoot(p)
+	if err != nil {
+		return fmt.Errorf("failed to get state root for %d: %w", p, err)
+	}
+	var des = new(snapshotDesignations)
+	if len(sr.Witness) != 0 {
+		des, err = s.dumpDesignations(sr)
+		if err != nil && !cfg.StateRootInHeader {
+			return fmt.Errorf("failed to prove state root for %d: %w", p, err)
+		}
+		if err != nil {
+			// The header is enough to verify the state root, so
+			// don't export the witness that can't be checked.
+			s.log.Info("state root witness can't be proven, skipping it", zap.Error(err))
+			unsigned := *sr
+			unsigned.Witness = nil
+			sr, des = &unsigned, new(snapshotDesignations)
+		}
+	} else if !cfg.StateRootInHeader {
+		return fmt.Errorf("state root for %d is not signed", p)
+	}
+	if cfg.StateRootInHeader {
+		next, err := s.bc.GetHeader(s.bc.GetHeaderHash(p + 1))
+		if err != nil {
+			return fmt.Errorf("failed to get header %d: %w", p+1, err)
+		}
+		if !next.PrevStateRoot.Equals(sr.Root) {
+			return fmt.Errorf("state root mismatch for %d: %s vs %s", p, sr.Root.StringLE(), next.PrevStateRoot.StringLE())
+		}
+	}
+
+	w.WriteU32LE(snapshotMagic)
+	w.WriteB(snapshotVersion)
+	w.WriteU32LE(uint32(cfg.Magic))
+	w.WriteU32LE(p)
+	sr.EncodeBinary(w)
+	des.EncodeBinary(w)
+
+	w.WriteU32LE(p + 1)
+	for i := uint32(1); i <= p+1; i++ {
+		h, err := s.bc.GetHeader(s.bc.GetHeaderHash(i))
+		if err != nil {
+			return fmt.Errorf("failed to get header %d: %w", i, err)
+		}
+		h.EncodeBinary(w)
+		if w.Err != nil {
+			return w.Err
+		}
+	}
+
+	var start uint32 = 1
+	if p > cfg.MaxTraceableBlocks {
+		start = p - cfg.MaxTraceableBlocks + 1
+	}
+	w.WriteU32LE(start)
+	w.WriteU32LE(p + 1 - start)
+	for i := start; i <= p; i++ {
+		b, err := s.bc.GetBlock(s.bc.GetHeaderHash(i))
+		if err != nil {
+			return fmt.Errorf("failed to get block %d: %w", i, err)
+		}
+		b.EncodeBinary(w)
+		if w.Err != nil {
+			return w.Err
+		}
+	}
+
+	// The same node can be referenced from several paths, but it's enough
+	// to restore it once.
+	var seen = make(map[util.Uint256]struct{})
+	err = s.Traverse(sr.Root, func(n mpt.Node, nodeBytes []byte) bool {
+		if _, ok := seen[n.Hash()]; ok {
+			return false
+		}
+		seen[n.Hash()] = struct{}{}
+		w.WriteVarBytes(nodeBytes)
+		return w.Err != nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to traverse MPT: %w", err)
+	}
+	w.WriteVarBytes(nil)
+	return w.Err
+}
+
+// RestoreSnapshot reads the state snapshot from the given reader and
+// performs the state jump using it. The module must not be initialized
+// (Init is called internally) and the chain must have the genesis block only.
+// Regular blocks processing can be performed after successful restoration.
+func (s *Module) RestoreSnapshot(r *io.BinReader) error {
+	cfg := s.bc.GetConfig()
+	if magic := r.ReadU32LE(); r.Err == nil && magic != snapshotMagic {
+		return fmt.Errorf("%w: not a snapshot", ErrSnapshotMismatch)
+	}
+	if ver := r.ReadB(); r.Err == nil && ver != snapshotVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrSnapshotMismatch, ver)
+	}
+	if magic := r.ReadU32LE(); r.Err == nil && magic != uint32(cfg.Magic) {
+		return fmt.Errorf("%w: network %d, expected %d", ErrSnapshotMismatch, magic, cfg.Magic)
+	}
+	p := r.ReadU32LE()
+	sr := new(state.MPTRoot)
+	sr.DecodeBinary(r)
+	des := new(snapshotDesignations)
+	des.DecodeBinary(r)
+	if r.Err != nil {
+		return fmt.Errorf("failed to read snapshot header: %w", r.Err)
+	}
+	if sr.Index != p {
+		return fmt.Errorf("%w: state root index %d doesn't match sync point %d", ErrSnapshotMismatch, sr.Index, p)
+	}
+	if !cfg.Ledger.RemoveUntraceableBlocks || s.syncInterval == 0 {
+		return errors.New("RemoveUntraceableBlocks is required for snapshot import")
+	}
+	if p%s.syncInterval != 0 {
+		return fmt.Errorf("%w: %d is not a state sync point", ErrSnapshotMismatch, p)
+	}
+	if s.bc.BlockHeight() != 0 {
+		return fmt.Errorf("%w: chain is not empty", ErrSnapshotMismatch)
+	}
+	if len(sr.Witness) != 0 {
+		err := s.verifyDesignations(sr, des)
+		if err != nil {
+			return err
+		}
+	} else if !cfg.StateRootInHeader {
+		return fmt.Errorf("%w: state root is not signed", ErrSnapshotMismatch)
+	}
+	s.lock.Lock()
+	if s.syncStage == inactive {
+		// Snapshot doesn't need P2PStateExchangeExtensions, so the module
+		// may be disabled for regular synchronisation.
+		s.syncStage = none
+		s.mptpool = NewPool()
+	}
+	s.lock.Unlock()
+	err := s.Init(p)
+	if err != nil {
+		return err
+	}
+	if len(sr.Witness) != 0 {
+		err = s.stateMod.PutStateRoot(sr)
+		if err != nil {
+			return fmt.Errorf("failed to store state root: %w", err)
+		}
+	}
+	if !s.NeedHeaders() {
+		return fmt.Errorf("%w: state sync for %d is not possible", ErrSnapshotMismatch, p)
+	}
+
+	count := r.ReadU32LE()
+	if r.Err == nil && count != p+1 {
+		return fmt.Errorf("%w: expected %d headers, got %d", ErrSnapshotMismatch, p+1, count)
+	}
+	var hdrs = make([]*block.Header, 0, snapshotBatchSize)
+	for i := uint32(0); i < count; i++ {
+		h := &block.Header{StateRootEnabled: cfg.StateRootInHeader}
+		h.DecodeBinary(r)
+		if r.Err != nil {
+			return fmt.Errorf("failed to read header %d: %w", i+1, r.Err)
+		}
+		if h.Index <= s.bc.HeaderHeight() {
+			continue
+		}
+		hdrs = append(hdrs, h)
+		if len(hdrs) == snapshotBatchSize || i == count-1 {
+			err = s.AddHeaders(hdrs...)
+			if err != nil {
+				return fmt.Errorf("failed to add headers: %w", err)
+			}
+			hdrs = hdrs[:0]
+		}
+	}
+	if s.NeedHeaders() {
+		return errors.New("headers are not synchronized")
+	}
+	if cfg.StateRootInHeader {
+		next, err := s.bc.GetHeader(s.bc.GetHeaderHash(p + 1))
+		if err != nil {
+			return fmt.Errorf("failed to get header %d: %w", p+1, err)
+		}
+		if !next.PrevStateRoot.Equals(sr.Root) {
+			return fmt.Errorf("%w: state root %s doesn't match header %d", ErrSnapshotMismatch, sr.Root.StringLE(), p+1)
+		}
+	}
+	s.log.Info("snapshot headers restored", zap.Uint32("headerHeight", s.bc.HeaderHeight()))
+
+	start := r.ReadU32LE()
+	count = r.ReadU32LE()
+	if r.Err != nil {
+		return fmt.Errorf("failed to read blocks: %w", r.Err)
+	}
+	if needed := s.BlockHeight() + 1; start > needed && needed <= p {
+		return fmt.Errorf("%w: snapshot blocks start from %d, but %d is needed", ErrSnapshotMismatch, start, needed)
+	}
+	for i := uint32(0); i < count; i++ {
+		b := block.New(cfg.StateRootInHeader)
+		b.DecodeBinary(r)
+		if r.Err != nil {
+			return fmt.Errorf("failed to read block %d: %w", start+i, r.Err)
+		}
+		if b.Index <= s.BlockHeight() {
+			continue
+		}
+		err = s.AddBlock(b)
+		if err != nil {
+			return fmt.Errorf("failed to add block %d: %w", b.Index, err)
+		}
+	}
+	s.log.Info("snapshot blocks restored", zap.Uint32("blockHeight", s.BlockHeight()))
+
+	var nodes = make([][]byte, 0, snapshotBatchSize)
+	for {
+		nodeBytes := r.ReadVarBytes()
+		if r.Err != nil {
+			return fmt.Errorf("failed to read MPT node: %w", r.Err)
+		}
+		if len(nodeBytes) != 0 {
+			nodes = append(nodes, nodeBytes)
+		}
+		if len(nodes) == snapshotBatchSize || (len(nodeBytes) == 0 && len(nodes) != 0) {
+			if !s.NeedMPTNodes() {
+				return errors.New("unexpected MPT nodes")
+			}
+			err = s.AddMPTNodes(nodes)
+			if err != nil {
+				return fmt.Errorf("failed to add MPT nodes: %w", err)
+			}
+			nodes = nodes[:0]
+		}
+		if len(nodeBytes) == 0 {
+			break
+		}
+	}
+	if s.IsActive() {
+		return errors.New("snapshot is incomplete")
+	}
+	return nil
+}
@@ -0,0 +1,224 @@
+package statesync_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/basicchain"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
+	"github.com/nspcc-dev/neo-go/pkg/core/stateroot"
+	"github.com/nspcc-dev/neo-go/pkg/core/statesync"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/neotest"
+	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateSyncModule_Snapshot(t *testing.T) {
+	const (
+		stateSyncInterval = 4
+		maxTraceable      = 6
+		stateSyncPoint    = 24
+	)
+	spoutCfg := func(c *config.Blockchain) {
+		c.StateRootInHeader = true
+		c.P2PStateExchangeExtensions = true
+		c.StateSyncInterval = stateSyncInterval
+		c.MaxTraceableBlocks = maxTraceable
+		c.P2PSigExtensions = true // `basicchain.Init` assumes Notary is enabled.
+	}
+	bcSpout, validators, committee := chain.NewMultiWithCustomConfig(t, spoutCfg)
+	e := neotest.NewExecutor(t, bcSpout, validators, committee)
+	basicchain.Init(t, "../../../", e)
+	e.AddNewBlock(t)
+	e.AddNewBlock(t) // This block is stateSyncPoint-th block.
+	e.AddNewBlock(t)
+	require.Equal(t, stateSyncPoint+2, int(bcSpout.BlockHeight()))
+
+	spoutModule := bcSpout.GetStateSyncModule()
+	p, err := spoutModule.LatestSyncPoint()
+	require.NoError(t, err)
+	require.Equal(t, uint32(stateSyncPoint), p)
+
+	w := io.NewBufBinWriter()
+	require.NoError(t, spoutModule.DumpSnapshot(w.BinWriter, p))
+	require.NoError(t, w.Err)
+	snapshot := w.Bytes()
+
+	t.Run("error: too high", func(t *testing.T) {
+		w := io.NewBufBinWriter()
+		require.Error(t, spoutModule.DumpSnapshot(w.BinWriter, stateSyncPoint+stateSyncInterval))
+	})
+	t.Run("error: not a sync point", func(t *testing.T) {
+		w := io.NewBufBinWriter()
+		require.Error(t, spoutModule.DumpSnapshot(w.BinWriter, stateSyncPoint-1))
+	})
+
+	boltCfg := func(c *config.Blockchain) {
+		spoutCfg(c)
+		c.Ledger.KeepOnlyLatestState = true
+		c.Ledger.RemoveUntraceableBlocks = true
+	}
+
+	t.Run("error: state sync disabled", func(t *testing.T) {
+		bc, _, _ := chain.NewMultiWithCustomConfig(t, spoutCfg)
+		require.Error(t, bc.GetStateSyncModule().RestoreSnapshot(io.NewBinReaderFromBuf(snapshot)))
+	})
+	t.Run("error: another network", func(t *testing.T) {
+		bc, _, _ := chain.NewMultiWithCustomConfig(t, func(c *config.Blockchain) {
+			boltCfg(c)
+			c.Magic++
+		})
+		err := bc.GetStateSyncModule().RestoreSnapshot(io.NewBinReaderFromBuf(snapshot))
+		require.ErrorIs(t, err, statesync.ErrSnapshotMismatch)
+	})
+	t.Run("error: truncated", func(t *testing.T) {
+		bc, _, _ := chain.NewMultiWithCustomConfig(t, boltCfg)
+		err := bc.GetStateSyncModule().RestoreSnapshot(io.NewBinReaderFromBuf(snapshot[:len(snapshot)-100]))
+		require.Error(t, err)
+		require.Equal(t, uint32(0), bc.BlockHeight())
+	})
+	t.Run("error: corrupted MPT", func(t *testing.T) {
+		bc, _, _ := chain.NewMultiWithCustomConfig(t, boltCfg)
+		bad := bytes.Clone(snapshot)
+		bad[len(bad)-2]++ // The last byte of the last MPT node.
+		err := bc.GetStateSyncModule().RestoreSnapshot(io.NewBinReaderFromBuf(bad))
+		require.Error(t, err)
+		require.Equal(t, uint32(0), bc.BlockHeight())
+	})
+
+	bcBolt, _, _ := chain.NewMultiWithCustomConfig(t, boltCfg)
+	module := bcBolt.GetStateSyncModule()
+	require.NoError(t, module.RestoreSnapshot(io.NewBinReaderFromBuf(snapshot)))
+	require.False(t, module.IsActive())
+	require.Equal(t, uint32(stateSyncPoint), bcBolt.BlockHeight())
+	sr, err := bcSpout.GetStateModule().GetStateRoot(stateSyncPoint)
+	require.NoError(t, err)
+	require.Equal(t, sr.Root, bcBolt.GetStateModule().CurrentLocalStateRoot())
+
+	// Regular synchronisation continues from the state sync point.
+	for i := uint32(stateSyncPoint + 1); i <= bcSpout.BlockHeight(); i++ {
+		b, err := bcSpout.GetBlock(bcSpout.GetHeaderHash(i))
+		require.NoError(t, err)
+		require.NoError(t, bcBolt.AddBlock(b))
+	}
+	require.Equal(t, bcSpout.BlockHeight(), bcBolt.BlockHeight())
+	require.Equal(t, bcSpout.GetStateModule().CurrentLocalStateRoot(), bcBolt.GetStateModule().CurrentLocalStateRoot())
+
+	t.Run("error: chain is not empty", func(t *testing.T) {
+		err := bcBolt.GetStateSyncModule().RestoreSnapshot(io.NewBinReaderFromBuf(snapshot))
+		require.ErrorIs(t, err, statesync.ErrSnapshotMismatch)
+	})
+}
+
+func TestStateSyncModule_SnapshotSignedStateRoot(t *testing.T) {
+	const (
+		stateSyncInterval = 4
+		maxTraceable      = 6
+		stateSyncPoint    = 12
+	)
+	genesisSV, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	newSV, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+
+	spoutCfg := func(c *config.Blockchain) {
+		c.StateSyncInterval = stateSyncInterval
+		c.MaxTraceableBlocks = maxTraceable
+		c.Genesis.Roles = map[noderoles.Role]keys.PublicKeys{
+			noderoles.StateValidator: {genesisSV.PublicKey()},
+		}
+	}
+	bcSpout, validators, committee := chain.NewMultiWithCustomConfig(t, spoutCfg)
+	e := neotest.NewExecutor(t, bcSpout, validators, committee)
+	signRoot := func(t *testing.T, index uint32, sv *keys.PrivateKey) {
+		sr, err := bcSpout.GetStateModule().GetStateRoot(index)
+		require.NoError(t, err)
+		verif, err := smartcontract.CreateDefaultMultiSigRedeemScript(keys.PublicKeys{sv.PublicKey()})
+		require.NoError(t, err)
+		w := io.NewBufBinWriter()
+		emit.Bytes(w.BinWriter, sv.SignHashable(uint32(bcSpout.GetConfig().Magic), sr))
+		sr.Witness = []transaction.Witness{{
+			InvocationScript:   w.Bytes(),
+			VerificationScript: verif,
+		}}
+		require.NoError(t, bcSpout.GetStateModule().(*stateroot.Module).AddStateRoot(sr)) // Take full responsibility here.
+	}
+
+	// New StateValidator is designated for block 2, so state root for block
+	// 1 is signed by the genesis one and the rest is signed by the new one.
+	e.NewInvoker(e.NativeHash(t, nativenames.Designation), validators, committee).Invoke(t, stackitem.Null{},
+		"designateAsRole", int64(noderoles.StateValidator), []any{newSV.PublicKey().Bytes()})
+	signRoot(t, 1, genesisSV)
+	for bcSpout.BlockHeight() <= stateSyncPoint {
+		e.AddNewBlock(t)
+	}
+	signRoot(t, stateSyncPoint, newSV)
+
+	spoutModule := bcSpout.GetStateSyncModule()
+	p, err := spoutModule.LatestSyncPoint()
+	require.NoError(t, err)
+	require.Equal(t, uint32(stateSyncPoint), p)
+
+	w := io.NewBufBinWriter()
+	require.NoError(t, spoutModule.DumpSnapshot(w.BinWriter, p))
+	require.NoError(t, w.Err)
+	snapshot := w.Bytes()
+
+	t.Run("error: unsigned state root", func(t *testing.T) {
+		w := io.NewBufBinWriter()
+		require.Error(t, spoutModule.DumpSnapshot(w.BinWriter, stateSyncPoint-stateSyncInterval))
+	})
+
+	boltCfg := func(c *config.Blockchain) {
+		spoutCfg(c)
+		c.Ledger.KeepOnlyLatestState = true
+		c.Ledger.RemoveUntraceableBlocks = true
+	}
+	t.Run("error: bad witness", func(t *testing.T) {
+		bc, _, _ := chain.NewMultiWithCustomConfig(t, boltCfg)
+		bad := bytes.Clone(snapshot)
+		// Magic, version, network, P, then state root version, index,
+		// hash, witness count, invocation script length, PUSHDATA1
+		// with its length and the signature.
+		bad[4+1+4+4+1+4+32+1+1+2+10]++
+		err := bc.GetStateSyncModule().RestoreSnapshot(io.NewBinReaderFromBuf(bad))
+		require.ErrorIs(t, err, statesync.ErrSnapshotMismatch)
+		require.Equal(t, uint32(0), bc.BlockHeight())
+	})
+	t.Run("error: another StateValidator", func(t *testing.T) {
+		bc, _, _ := chain.NewMultiWithCustomConfig(t, func(c *config.Blockchain) {
+			boltCfg(c)
+			c.Genesis.Roles = map[noderoles.Role]keys.PublicKeys{
+				noderoles.StateValidator: {newSV.PublicKey()},
+			}
+		})
+		err := bc.GetStateSyncModule().RestoreSnapshot(io.NewBinReaderFromBuf(snapshot))
+		require.Error(t, err)
+		require.Equal(t, uint32(0), bc.BlockHeight())
+	})
+
+	bcBolt, _, _ := chain.NewMultiWithCustomConfig(t, boltCfg)
+	module := bcBolt.GetStateSyncModule()
+	require.NoError(t, module.RestoreSnapshot(io.NewBinReaderFromBuf(snapshot)))
+	require.False(t, module.IsActive())
+	require.Equal(t, uint32(stateSyncPoint), bcBolt.BlockHeight())
+	sr, err := bcSpout.GetStateModule().GetStateRoot(stateSyncPoint)
+	require.NoError(t, err)
+	require.Equal(t, sr.Root, bcBolt.GetStateModule().CurrentLocalStateRoot())
+	require.Equal(t, uint32(stateSyncPoint), bcBolt.GetStateModule().CurrentValidatedHeight())
+
+	for i := uint32(stateSyncPoint + 1); i <= bcSpout.BlockHeight(); i++ {
+		b, err := bcSpout.GetBlock(bcSpout.GetHeaderHash(i))
+		require.NoError(t, err)
+		require.NoError(t, bcBolt.AddBlock(b))
+	}
+	require.Equal(t, bcSpout.GetStateModule().CurrentLocalStateRoot(), bcBolt.GetStateModule().CurrentLocalStateRoot())
+}
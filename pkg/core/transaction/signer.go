@@ -1,7 +1,9 @@
 package transaction
 
 import (
+	"crypto/elliptic"
 	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
@@ -87,6 +89,88 @@ func SignersToStackItem(signers []Signer) stackitem.Item {
 	return stackitem.NewArray(res)
 }
 
+// SignersFromStackItem converts stackitem.Item (in the format produced by
+// SignersToStackItem) to transaction.Signers.
+func SignersFromStackItem(item stackitem.Item) ([]Signer, error) {
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return nil, errors.New("not an array")
+	}
+	res := make([]Signer, len(arr))
+	for i := range arr {
+		err := res[i].fromStackItem(arr[i])
+		if err != nil {
+			return nil, fmt.Errorf("signer #%d: %w", i, err)
+		}
+	}
+	return res, nil
+}
+
+func (c *Signer) fromStackItem(item stackitem.Item) error {
+	fields, ok := item.Value().([]stackitem.Item)
+	if !ok || len(fields) != 5 {
+		return errors.New("not an array of 5 elements")
+	}
+	b, err := fields[0].TryBytes()
+	if err != nil {
+		return fmt.Errorf("invalid account: %w", err)
+	}
+	c.Account, err = util.Uint160DecodeBytesBE(b)
+	if err != nil {
+		return fmt.Errorf("invalid account: %w", err)
+	}
+	scopes, err := fields[1].TryInteger()
+	if err != nil || !scopes.IsInt64() || scopes.Int64() < 0 || scopes.Int64() > 0xff {
+		return errors.New("invalid scopes")
+	}
+	c.Scopes = WitnessScope(scopes.Int64())
+	contracts, ok := fields[2].Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("invalid allowed contracts")
+	}
+	c.AllowedContracts = nil
+	for _, ci := range contracts {
+		b, err := ci.TryBytes()
+		if err != nil {
+			return fmt.Errorf("invalid allowed contract: %w", err)
+		}
+		h, err := util.Uint160DecodeBytesBE(b)
+		if err != nil {
+			return fmt.Errorf("invalid allowed contract: %w", err)
+		}
+		c.AllowedContracts = append(c.AllowedContracts, h)
+	}
+	groups, ok := fields[3].Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("invalid allowed groups")
+	}
+	c.AllowedGroups = nil
+	for _, gi := range groups {
+		b, err := gi.TryBytes()
+		if err != nil {
+			return fmt.Errorf("invalid allowed group: %w", err)
+		}
+		k, err := keys.NewPublicKeyFromBytes(b, elliptic.P256())
+		if err != nil {
+			return fmt.Errorf("invalid allowed group: %w", err)
+		}
+		c.AllowedGroups = append(c.AllowedGroups, k)
+	}
+	rules, ok := fields[4].Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("invalid rules")
+	}
+	c.Rules = nil
+	for i := range rules {
+		var r WitnessRule
+		if err := r.FromStackItem(rules[i]); err != nil {
+			return fmt.Errorf("rule #%d: %w", i, err)
+		}
+		c.Rules = append(c.Rules, r)
+	}
+	return nil
+}
+
 // Copy creates a deep copy of the Signer.
 func (c *Signer) Copy() *Signer {
 	if c == nil {
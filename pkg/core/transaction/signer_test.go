@@ -6,6 +6,7 @@ import (
 	"github.com/nspcc-dev/neo-go/internal/testserdes"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/stretchr/testify/require"
 )
 
@@ -33,6 +34,36 @@ func TestCosignerMarshallUnmarshallJSON(t *testing.T) {
 	testserdes.MarshalUnmarshalJSON(t, expected, actual)
 }
 
+func TestSignersFromStackItem(t *testing.T) {
+	pk, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	expected := []Signer{{
+		Account:          util.Uint160{1, 2, 3, 4, 5},
+		Scopes:           CustomContracts | CustomGroups | Rules,
+		AllowedContracts: []util.Uint160{{1, 2, 3, 4}, {6, 7, 8, 9}},
+		AllowedGroups:    []*keys.PublicKey{pk.PublicKey()},
+		Rules:            []WitnessRule{{Action: WitnessAllow, Condition: ConditionCalledByEntry{}}},
+	}, {
+		Account: util.Uint160{5, 4, 3, 2, 1},
+		Scopes:  CalledByEntry,
+	}}
+	actual, err := SignersFromStackItem(SignersToStackItem(expected))
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+
+	for _, itm := range []stackitem.Item{
+		stackitem.Make(1),
+		stackitem.Make([]stackitem.Item{stackitem.Make(1)}),
+		stackitem.Make([]stackitem.Item{stackitem.Make([]stackitem.Item{
+			stackitem.Make([]byte{1}), stackitem.Make(1), stackitem.Make([]stackitem.Item{}),
+			stackitem.Make([]stackitem.Item{}), stackitem.Make([]stackitem.Item{}),
+		})}),
+	} {
+		_, err = SignersFromStackItem(itm)
+		require.Error(t, err)
+	}
+}
+
 func TestSignerCopy(t *testing.T) {
 	pk, err := keys.NewPrivateKey()
 	require.NoError(t, err)
@@ -1,8 +1,10 @@
 package transaction
 
 import (
+	"crypto/elliptic"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
@@ -717,3 +719,103 @@ func condToStackItem(typ WitnessConditionType, c any) stackitem.Item {
 	}
 	return stackitem.NewArray(res)
 }
+
+// ConditionFromStackItem converts the given stack item (in the format
+// produced by ToStackItem method of any WitnessCondition) into condition.
+func ConditionFromStackItem(item stackitem.Item) (WitnessCondition, error) {
+	return conditionFromStackItem(item, MaxConditionNesting)
+}
+
+func conditionFromStackItem(item stackitem.Item, maxDepth int) (WitnessCondition, error) {
+	if maxDepth <= 0 {
+		return nil, errors.New("too many nesting levels")
+	}
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return nil, errors.New("not an array")
+	}
+	if len(arr) == 0 {
+		return nil, errors.New("empty array")
+	}
+	t, err := arr[0].TryInteger()
+	if err != nil {
+		return nil, fmt.Errorf("invalid condition type: %w", err)
+	}
+	if !t.IsInt64() || t.Int64() < 0 || t.Int64() > 0xff {
+		return nil, errors.New("invalid condition type")
+	}
+	typ := WitnessConditionType(t.Int64())
+	if typ == WitnessCalledByEntry {
+		if len(arr) != 1 {
+			return nil, errors.New("wrong number of elements")
+		}
+		return ConditionCalledByEntry{}, nil
+	}
+	if len(arr) != 2 {
+		return nil, errors.New("wrong number of elements")
+	}
+	switch typ {
+	case WitnessBoolean:
+		v, err := arr[1].TryBool()
+		if err != nil {
+			return nil, err
+		}
+		return (*ConditionBoolean)(&v), nil
+	case WitnessNot:
+		v, err := conditionFromStackItem(arr[1], maxDepth-1)
+		if err != nil {
+			return nil, err
+		}
+		return &ConditionNot{Condition: v}, nil
+	case WitnessAnd, WitnessOr:
+		ops, ok := arr[1].Value().([]stackitem.Item)
+		if !ok {
+			return nil, errors.New("operands are not an array")
+		}
+		if len(ops) == 0 {
+			return nil, errors.New("empty array of conditions")
+		}
+		if len(ops) > maxSubitems {
+			return nil, errors.New("too many elements")
+		}
+		v := make([]WitnessCondition, len(ops))
+		for i := range ops {
+			v[i], err = conditionFromStackItem(ops[i], maxDepth-1)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if typ == WitnessAnd {
+			return (*ConditionAnd)(&v), nil
+		}
+		return (*ConditionOr)(&v), nil
+	case WitnessScriptHash, WitnessCalledByContract:
+		b, err := arr[1].TryBytes()
+		if err != nil {
+			return nil, err
+		}
+		h, err := util.Uint160DecodeBytesBE(b)
+		if err != nil {
+			return nil, err
+		}
+		if typ == WitnessScriptHash {
+			return (*ConditionScriptHash)(&h), nil
+		}
+		return (*ConditionCalledByContract)(&h), nil
+	case WitnessGroup, WitnessCalledByGroup:
+		b, err := arr[1].TryBytes()
+		if err != nil {
+			return nil, err
+		}
+		k, err := keys.NewPublicKeyFromBytes(b, elliptic.P256())
+		if err != nil {
+			return nil, err
+		}
+		if typ == WitnessGroup {
+			return (*ConditionGroup)(k), nil
+		}
+		return (*ConditionCalledByGroup)(k), nil
+	default:
+		return nil, errors.New("invalid condition type")
+	}
+}
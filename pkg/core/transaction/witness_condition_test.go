@@ -120,6 +120,32 @@ func TestWitnessConditionSerDes(t *testing.T) {
 			}
 		}
 	})
+	t.Run("from stackitem", func(t *testing.T) {
+		for i, c := range cases {
+			if _, ok := c.condition.(InvalidCondition); ok {
+				continue
+			}
+			res, err := ConditionFromStackItem(c.condition.ToStackItem())
+			if !c.success {
+				require.Errorf(t, err, "case %d", i)
+				continue
+			}
+			require.NoErrorf(t, err, "case %d", i)
+			require.Equal(t, c.condition, res)
+		}
+		for _, itm := range []stackitem.Item{
+			stackitem.Make(1),
+			stackitem.Make([]stackitem.Item{}),
+			stackitem.Make([]stackitem.Item{stackitem.Make(0xff)}),
+			stackitem.Make([]stackitem.Item{stackitem.Make(WitnessCalledByEntry), stackitem.Make(1)}),
+			stackitem.Make([]stackitem.Item{stackitem.Make(WitnessScriptHash), stackitem.Make([]byte{1})}),
+			stackitem.Make([]stackitem.Item{stackitem.Make(WitnessGroup), stackitem.Make([]byte{1})}),
+			stackitem.Make([]stackitem.Item{stackitem.Make(WitnessAnd), stackitem.Make(1)}),
+		} {
+			_, err := ConditionFromStackItem(itm)
+			require.Error(t, err)
+		}
+	})
 }
 
 func TestWitnessConditionZeroDeser(t *testing.T) {
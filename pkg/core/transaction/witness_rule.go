@@ -3,6 +3,7 @@ package transaction
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/nspcc-dev/neo-go/pkg/io"
@@ -95,6 +96,28 @@ func (w *WitnessRule) ToStackItem() stackitem.Item {
 	})
 }
 
+// FromStackItem implements Convertible interface.
+func (w *WitnessRule) FromStackItem(item stackitem.Item) error {
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok || len(arr) != 2 {
+		return errors.New("not an array of 2 elements")
+	}
+	action, err := arr[0].TryInteger()
+	if err != nil {
+		return fmt.Errorf("invalid action: %w", err)
+	}
+	if !action.IsInt64() || (action.Int64() != int64(WitnessDeny) && action.Int64() != int64(WitnessAllow)) {
+		return errors.New("invalid action")
+	}
+	cond, err := ConditionFromStackItem(arr[1])
+	if err != nil {
+		return fmt.Errorf("invalid condition: %w", err)
+	}
+	w.Action = WitnessAction(action.Int64())
+	w.Condition = cond
+	return nil
+}
+
 // Copy creates a deep copy of the WitnessRule.
 func (w *WitnessRule) Copy() *WitnessRule {
 	return &WitnessRule{
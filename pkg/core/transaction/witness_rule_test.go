@@ -74,6 +74,27 @@ func TestWitnessRule_ToStackItem(t *testing.T) {
 	}
 }
 
+func TestWitnessRule_FromStackItem(t *testing.T) {
+	var b = true
+	for _, act := range []WitnessAction{WitnessDeny, WitnessAllow} {
+		expected := &WitnessRule{
+			Action:    act,
+			Condition: (*ConditionBoolean)(&b),
+		}
+		actual := new(WitnessRule)
+		require.NoError(t, actual.FromStackItem(expected.ToStackItem()))
+		require.Equal(t, expected, actual)
+	}
+	for _, itm := range []stackitem.Item{
+		stackitem.Make(1),
+		stackitem.Make([]stackitem.Item{stackitem.Make(1)}),
+		stackitem.Make([]stackitem.Item{stackitem.Make(5), stackitem.Make([]stackitem.Item{stackitem.Make(WitnessBoolean), stackitem.Make(b)})}),
+		stackitem.Make([]stackitem.Item{stackitem.Make(1), stackitem.Make(1)}),
+	} {
+		require.Error(t, new(WitnessRule).FromStackItem(itm))
+	}
+}
+
 func TestWitnessRule_Copy(t *testing.T) {
 	b := true
 	wr := &WitnessRule{
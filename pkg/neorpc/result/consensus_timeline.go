@@ -0,0 +1,32 @@
+package result
+
+import "time"
+
+type (
+	// RoundTimeline represents a single dBFT round of getconsensustimeline
+	// RPC call result.
+	RoundTimeline struct {
+		Height uint32    `json:"height"`
+		Start  time.Time `json:"start"`
+		// End is omitted for the current round.
+		End *time.Time `json:"end,omitempty"`
+		// View is the last view number of the round.
+		View     byte              `json:"view"`
+		Messages []TimelineMessage `json:"messages"`
+	}
+
+	// TimelineMessage is a consensus message sent or received during the
+	// round.
+	TimelineMessage struct {
+		Time time.Time `json:"time"`
+		// Type is a consensus message type name (like "PrepareRequest").
+		Type string `json:"type"`
+		// Height is the message block index, it can differ from the
+		// round height.
+		Height    uint32 `json:"height"`
+		View      byte   `json:"view"`
+		Validator uint16 `json:"validator"`
+		// Sent is true for messages sent by the node itself.
+		Sent bool `json:"sent"`
+	}
+)
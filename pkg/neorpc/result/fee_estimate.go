@@ -0,0 +1,21 @@
+package result
+
+// FeeEstimate represents a result of getfeeestimate RPC call. All values are
+// fee-per-byte (network fee divided by transaction size, the same value is
+// used to order transactions in the memory pool) in GAS fractions.
+type FeeEstimate struct {
+	// FeePerByte is the minimum fee-per-byte value set by the Policy
+	// contract.
+	FeePerByte int64 `json:"feeperbyte"`
+	// Low is the value that is expected to be enough to get into the next
+	// block, but the transaction can be outbid by any newer one.
+	Low int64 `json:"low"`
+	// Medium is the median value of the next block.
+	Medium int64 `json:"medium"`
+	// High is the 90th percentile value of the next block.
+	High int64 `json:"high"`
+	// MempoolSize is the number of transactions in the memory pool.
+	MempoolSize int `json:"mempoolsize"`
+	// Blocks is the number of recent blocks analyzed.
+	Blocks int `json:"blocks"`
+}
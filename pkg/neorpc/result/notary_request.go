@@ -0,0 +1,58 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+type (
+	// NotaryRequest represents a result of `getnotaryrequest` RPC call
+	// (and an element of `getnotaryrequests` result). It describes the state
+	// of notary request processing by the node's notary service.
+	NotaryRequest struct {
+		// Hash is the main transaction hash.
+		Hash util.Uint256 `json:"hash"`
+		// Valid is false if the main transaction witnesses can't be
+		// completed, only fallbacks are sent in this case.
+		Valid bool `json:"valid"`
+		// Sent is true if the main transaction was completed and sent to
+		// the network.
+		Sent bool `json:"sent"`
+		// NotValidBefore is the minimum NotValidBefore height of fallbacks,
+		// the main transaction can't be sent after this height.
+		NotValidBefore uint32 `json:"notvalidbefore"`
+		// SignaturesLeft is the total number of signatures left to collect
+		// for the main transaction.
+		SignaturesLeft int            `json:"signaturesleft"`
+		Signers        []NotarySigner `json:"signers"`
+		// Fallbacks contains pending fallback transactions.
+		Fallbacks []NotaryFallback `json:"fallbacks"`
+		// SentFallbacks contains fallback transactions sent to the network.
+		SentFallbacks []NotaryFallback `json:"sentfallbacks"`
+	}
+
+	// NotarySigner is the signature collection progress for a main
+	// transaction signer.
+	NotarySigner struct {
+		Account util.Uint160 `json:"account"`
+		// Type is either "signature", "multisignature" or "contract".
+		Type           string          `json:"type"`
+		SignaturesLeft int             `json:"signaturesleft"`
+		Keys           keys.PublicKeys `json:"keys"`
+		// Signed contains keys that provided valid signatures.
+		Signed keys.PublicKeys `json:"signed"`
+	}
+
+	// NotaryFallback is a fallback transaction description.
+	NotaryFallback struct {
+		Hash            util.Uint256 `json:"hash"`
+		NotValidBefore  uint32       `json:"notvalidbefore"`
+		ValidUntilBlock uint32       `json:"validuntilblock"`
+	}
+)
+
+// IsCompleted returns true if the request processing is finished: either the
+// main transaction or some fallback transaction was sent to the network.
+func (r *NotaryRequest) IsCompleted() bool {
+	return r.Sent || len(r.SentFallbacks) != 0
+}
@@ -0,0 +1,278 @@
+package network
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+)
+
+const (
+	// defaultFetchWindow is the initial number of blocks requested from a
+	// single peer in headers-first synchronization mode.
+	defaultFetchWindow = 64
+	// minFetchWindow is the minimum number of blocks requested from a peer.
+	minFetchWindow = 8
+	// maxFetchWindow is the maximum number of blocks requested from a peer,
+	// it's limited by the GetBlockByIndex payload.
+	maxFetchWindow = payload.MaxHashesCount
+	// minFetchTimeout and maxFetchTimeout limit adaptive per-peer request
+	// timeouts.
+	minFetchTimeout = time.Second
+	maxFetchTimeout = time.Minute
+)
+
+type (
+	// blockFetcher distributes block body requests between peers in the
+	// headers-first synchronization mode. Blocks are requested in windows
+	// (contiguous ranges of indexes), each peer has at most one window in
+	// flight at any moment. Window sizes and timeouts are adjusted for every
+	// peer depending on how fast it answers, windows that are not delivered
+	// in time are reassigned to other peers.
+	blockFetcher struct {
+		lock sync.Mutex
+		// next is the first block index that wasn't assigned to any peer yet.
+		next uint32
+		// released contains (sorted by start) windows that were not delivered
+		// in time or were abandoned by disconnected peers.
+		released []fetchRange
+		peers    map[Peer]*fetchPeer
+		// defTimeout is the initial request timeout for every peer.
+		defTimeout time.Duration
+
+		// hdrStart is the start of the last headers request and hdrDeadline is
+		// the time after which the same headers can be requested again.
+		hdrStart    uint32
+		hdrDeadline time.Time
+	}
+
+	// fetchRange is a [start, start+count) range of block indexes.
+	fetchRange struct {
+		start uint32
+		count uint32
+	}
+
+	// fetchWindow is a range of blocks requested from some peer.
+	fetchWindow struct {
+		fetchRange
+		received uint32
+		sent     time.Time
+		deadline time.Time
+	}
+
+	// fetchPeer is a per-peer fetching state.
+	fetchPeer struct {
+		size    uint32
+		timeout time.Duration
+		active  *fetchWindow
+	}
+)
+
+func newBlockFetcher(timeout time.Duration) *blockFetcher {
+	return &blockFetcher{
+		peers:      make(map[Peer]*fetchPeer),
+		defTimeout: clampFetchTimeout(timeout),
+	}
+}
+
+func clampFetchTimeout(t time.Duration) time.Duration {
+	if t < minFetchTimeout {
+		return minFetchTimeout
+	}
+	if t > maxFetchTimeout {
+		return maxFetchTimeout
+	}
+	return t
+}
+
+func (r fetchRange) end() uint32 {
+	return r.start + r.count - 1
+}
+
+// requestHeaders returns true if headers starting from the given index should
+// be requested now. It prevents multiple peers from being asked for the same
+// headers simultaneously, but allows to retry the request if no answer is
+// received in time.
+func (f *blockFetcher) requestHeaders(start uint32, now time.Time) bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.hdrStart == start && now.Before(f.hdrDeadline) {
+		return false
+	}
+	f.hdrStart = start
+	f.hdrDeadline = now.Add(f.defTimeout)
+	return true
+}
+
+// headersReceived allows to immediately request the next batch of headers.
+func (f *blockFetcher) headersReceived() {
+	f.lock.Lock()
+	f.hdrDeadline = time.Time{}
+	f.lock.Unlock()
+}
+
+// nextWindow assigns a new window of blocks to the given peer. height is the
+// current height of the chain (all blocks up to it are known), limit is the
+// maximum block index that can be requested from this peer. It returns false
+// if the peer already has a window in flight or there is nothing to request.
+func (f *blockFetcher) nextWindow(p Peer, height uint32, limit uint32, now time.Time) (fetchRange, bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.expire(now)
+	f.prune(height)
+
+	fp := f.peers[p]
+	if fp == nil {
+		fp = &fetchPeer{size: defaultFetchWindow, timeout: f.defTimeout}
+		f.peers[p] = fp
+	}
+	if fp.active != nil {
+		if fp.active.end() > height {
+			return fetchRange{}, false
+		}
+		// Chain has moved past this window using other sources.
+		fp.active = nil
+	}
+
+	var r fetchRange
+	for i := range f.released {
+		if f.released[i].start > limit {
+			break
+		}
+		r = f.released[i]
+		if r.count > fp.size {
+			r.count = fp.size
+		}
+		if r.end() > limit {
+			r.count = limit - r.start + 1
+		}
+		if r.count == f.released[i].count {
+			f.released = append(f.released[:i], f.released[i+1:]...)
+		} else {
+			f.released[i].start += r.count
+			f.released[i].count -= r.count
+		}
+		break
+	}
+	if r.count == 0 {
+		if f.next > limit {
+			return fetchRange{}, false
+		}
+		r = fetchRange{start: f.next, count: fp.size}
+		if r.end() > limit {
+			r.count = limit - r.start + 1
+		}
+		f.next += r.count
+	}
+	fp.active = &fetchWindow{
+		fetchRange: r,
+		sent:       now,
+		deadline:   now.Add(fp.timeout),
+	}
+	return r, true
+}
+
+// blockReceived accounts for the block with the given index received from the
+// given peer. It returns true if the peer has completed its window, so that
+// it can be given the next one.
+func (f *blockFetcher) blockReceived(p Peer, index uint32, now time.Time) bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	fp := f.peers[p]
+	if fp == nil || fp.active == nil {
+		return false
+	}
+	w := fp.active
+	// Blocks are sent in order by GetBlockByIndex handler.
+	if index != w.start+w.received {
+		return false
+	}
+	w.received++
+	if w.received < w.count {
+		return false
+	}
+	fp.active = nil
+	took := now.Sub(w.sent)
+	if took < fp.timeout/2 && w.count == fp.size {
+		fp.size *= 2
+		if fp.size > maxFetchWindow {
+			fp.size = maxFetchWindow
+		}
+	}
+	// Keep a safety margin of three times the last response time, but don't
+	// drop the timeout abruptly to handle occasional spikes.
+	fp.timeout = clampFetchTimeout((fp.timeout + 3*took) / 2)
+	return true
+}
+
+// removePeer releases the window assigned to the disconnected peer.
+func (f *blockFetcher) removePeer(p Peer) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	fp := f.peers[p]
+	if fp == nil {
+		return
+	}
+	if fp.active != nil {
+		f.release(fp.active)
+	}
+	delete(f.peers, p)
+}
+
+// expire releases windows that were not delivered in time and penalizes
+// corresponding peers. It must be called with the lock held.
+func (f *blockFetcher) expire(now time.Time) {
+	for _, fp := range f.peers {
+		if fp.active == nil || now.Before(fp.active.deadline) {
+			continue
+		}
+		f.release(fp.active)
+		fp.active = nil
+		fp.size /= 2
+		if fp.size < minFetchWindow {
+			fp.size = minFetchWindow
+		}
+		fp.timeout = clampFetchTimeout(fp.timeout * 2)
+		updateFetchTimeoutsMetric()
+	}
+}
+
+// release puts the undelivered part of the window back for reassignment. It
+// must be called with the lock held.
+func (f *blockFetcher) release(w *fetchWindow) {
+	if w.received >= w.count {
+		return
+	}
+	r := fetchRange{start: w.start + w.received, count: w.count - w.received}
+	i := sort.Search(len(f.released), func(i int) bool {
+		return f.released[i].start >= r.start
+	})
+	f.released = append(f.released, fetchRange{})
+	copy(f.released[i+1:], f.released[i:])
+	f.released[i] = r
+}
+
+// prune drops everything that is not relevant anymore because the chain
+// has already reached the given height. It must be called with the lock held.
+func (f *blockFetcher) prune(height uint32) {
+	if f.next <= height {
+		f.next = height + 1
+	}
+	var i int
+	for _, r := range f.released {
+		if r.end() <= height {
+			continue
+		}
+		if r.start <= height {
+			r.count -= height - r.start + 1
+			r.start = height + 1
+		}
+		f.released[i] = r
+		i++
+	}
+	f.released = f.released[:i]
+}
@@ -0,0 +1,97 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockFetcherWindows(t *testing.T) {
+	var (
+		f   = newBlockFetcher(10 * time.Second)
+		now = time.Now()
+		p1  = &localPeer{}
+		p2  = &localPeer{}
+		p3  = &localPeer{}
+	)
+
+	r, ok := f.nextWindow(p1, 0, 1000, now)
+	require.True(t, ok)
+	require.Equal(t, fetchRange{start: 1, count: defaultFetchWindow}, r)
+
+	// Single window per peer.
+	_, ok = f.nextWindow(p1, 0, 1000, now)
+	require.False(t, ok)
+
+	// Other peers get subsequent windows.
+	r, ok = f.nextWindow(p2, 0, 1000, now)
+	require.True(t, ok)
+	require.Equal(t, fetchRange{start: 1 + defaultFetchWindow, count: defaultFetchWindow}, r)
+
+	// Limited by the given index.
+	r, ok = f.nextWindow(p3, 0, 2*defaultFetchWindow+10, now)
+	require.True(t, ok)
+	require.Equal(t, fetchRange{start: 1 + 2*defaultFetchWindow, count: 10}, r)
+
+	t.Run("complete and grow", func(t *testing.T) {
+		for i := uint32(1); i < defaultFetchWindow; i++ {
+			require.False(t, f.blockReceived(p1, i, now.Add(time.Second)))
+		}
+		// Out of order blocks are not accounted for.
+		require.False(t, f.blockReceived(p1, 2*defaultFetchWindow, now.Add(time.Second)))
+		require.True(t, f.blockReceived(p1, defaultFetchWindow, now.Add(time.Second)))
+
+		r, ok := f.nextWindow(p1, defaultFetchWindow, 1000, now.Add(time.Second))
+		require.True(t, ok)
+		require.Equal(t, fetchRange{start: 2*defaultFetchWindow + 11, count: 2 * defaultFetchWindow}, r)
+	})
+
+	t.Run("timeout and reassign", func(t *testing.T) {
+		later := now.Add(11 * time.Second)
+		// Peer 2 sent some blocks of its window.
+		for i := uint32(1 + defaultFetchWindow); i < 11+defaultFetchWindow; i++ {
+			require.False(t, f.blockReceived(p2, i, now.Add(time.Second)))
+		}
+		// All windows have timed out, the lowest one goes first and
+		// timed out peers get smaller windows.
+		r, ok := f.nextWindow(p3, defaultFetchWindow, 1000, later)
+		require.True(t, ok)
+		require.Equal(t, fetchRange{start: 11 + defaultFetchWindow, count: defaultFetchWindow / 2}, r)
+
+		r, ok = f.nextWindow(p2, defaultFetchWindow, 1000, later)
+		require.True(t, ok)
+		require.Equal(t, fetchRange{start: 11 + defaultFetchWindow + defaultFetchWindow/2, count: defaultFetchWindow/2 - 10}, r)
+
+		r, ok = f.nextWindow(&localPeer{}, defaultFetchWindow, 1000, later)
+		require.True(t, ok)
+		require.Equal(t, fetchRange{start: 1 + 2*defaultFetchWindow, count: 10}, r)
+
+		r, ok = f.nextWindow(p1, defaultFetchWindow, 1000, later)
+		require.True(t, ok)
+		require.Equal(t, fetchRange{start: 2*defaultFetchWindow + 11, count: defaultFetchWindow}, r)
+	})
+
+	t.Run("disconnect", func(t *testing.T) {
+		_, ok := f.nextWindow(p3, defaultFetchWindow, 1000, now.Add(12*time.Second))
+		require.False(t, ok)
+
+		f.removePeer(p1)
+		r, ok := f.nextWindow(&localPeer{}, 2*defaultFetchWindow+10, 1000, now.Add(12*time.Second))
+		require.True(t, ok)
+		require.Equal(t, fetchRange{start: 2*defaultFetchWindow + 11, count: defaultFetchWindow}, r)
+	})
+}
+
+func TestBlockFetcherHeaders(t *testing.T) {
+	var (
+		f   = newBlockFetcher(time.Second)
+		now = time.Now()
+	)
+	require.True(t, f.requestHeaders(1, now))
+	require.False(t, f.requestHeaders(1, now))
+	require.True(t, f.requestHeaders(1, now.Add(2*time.Second)))
+	f.headersReceived()
+	require.True(t, f.requestHeaders(1, now.Add(2*time.Second)))
+	require.True(t, f.requestHeaders(2001, now.Add(2*time.Second)))
+}
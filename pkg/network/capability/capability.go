@@ -9,13 +9,18 @@ import (
 // MaxCapabilities is the maximum number of capabilities per payload.
 const MaxCapabilities = 32
 
+// MaxUnknownDataSize is the maximum size of unknown capability data.
+const MaxUnknownDataSize = 1024
+
 // Capabilities is a list of Capability.
 type Capabilities []Capability
 
 // DecodeBinary implements io.Serializable.
 func (cs *Capabilities) DecodeBinary(br *io.BinReader) {
 	br.ReadArray(cs, MaxCapabilities)
-	br.Err = cs.checkUniqueCapabilities()
+	if br.Err == nil {
+		br.Err = cs.checkUniqueCapabilities()
+	}
 }
 
 // EncodeBinary implements io.Serializable.
@@ -26,7 +31,7 @@ func (cs *Capabilities) EncodeBinary(br *io.BinWriter) {
 // checkUniqueCapabilities checks whether payload capabilities have a unique type.
 func (cs Capabilities) checkUniqueCapabilities() error {
 	err := errors.New("capabilities with the same type are not allowed")
-	var isFullNode, isTCP, isWS bool
+	var isFullNode, isTCP, isWS, isCompact bool
 	for _, cap := range cs {
 		switch cap.Type {
 		case FullNode:
@@ -44,6 +49,11 @@ func (cs Capabilities) checkUniqueCapabilities() error {
 				return err
 			}
 			isWS = true
+		case CompactBlocks:
+			if isCompact {
+				return err
+			}
+			isCompact = true
 		}
 	}
 	return nil
@@ -63,9 +73,14 @@ func (c *Capability) DecodeBinary(br *io.BinReader) {
 		c.Data = &Node{}
 	case TCPServer, WSServer:
 		c.Data = &Server{}
+	case CompactBlocks:
+		c.Data = &Compact{}
 	default:
-		br.Err = errors.New("unknown node capability type")
-		return
+		if c.Type < MinExtensionType {
+			br.Err = errors.New("unknown node capability type")
+			return
+		}
+		c.Data = &Unknown{}
 	}
 	c.Data.DecodeBinary(br)
 }
@@ -110,3 +125,42 @@ func (s *Server) DecodeBinary(br *io.BinReader) {
 func (s *Server) EncodeBinary(bw *io.BinWriter) {
 	bw.WriteU16LE(s.Port)
 }
+
+// Compact represents compact block relay capability with the supported
+// compact blocks protocol version. It's encoded as a variable-length byte
+// array like any other extension (see Unknown).
+type Compact struct {
+	Version byte
+}
+
+// DecodeBinary implements io.Serializable.
+func (c *Compact) DecodeBinary(br *io.BinReader) {
+	data := br.ReadVarBytes(MaxUnknownDataSize)
+	if br.Err != nil {
+		return
+	}
+	if len(data) == 0 {
+		br.Err = errors.New("empty compact blocks capability")
+		return
+	}
+	c.Version = data[0]
+}
+
+// EncodeBinary implements io.Serializable.
+func (c *Compact) EncodeBinary(bw *io.BinWriter) {
+	bw.WriteVarBytes([]byte{c.Version})
+}
+
+// Unknown represents an extension capability of an unknown type (see
+// MinExtensionType), its data is kept as is.
+type Unknown []byte
+
+// DecodeBinary implements io.Serializable.
+func (u *Unknown) DecodeBinary(br *io.BinReader) {
+	*u = br.ReadVarBytes(MaxUnknownDataSize)
+}
+
+// EncodeBinary implements io.Serializable.
+func (u *Unknown) EncodeBinary(bw *io.BinWriter) {
+	bw.WriteVarBytes(*u)
+}
@@ -0,0 +1,62 @@
+package capability
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/testserdes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapabilityDecodeBinary(t *testing.T) {
+	t.Run("known", func(t *testing.T) {
+		for _, c := range []*Capability{
+			{Type: TCPServer, Data: &Server{Port: 10333}},
+			{Type: WSServer, Data: &Server{Port: 10334}},
+			{Type: FullNode, Data: &Node{StartHeight: 100500}},
+			{Type: CompactBlocks, Data: &Compact{Version: 1}},
+		} {
+			testserdes.EncodeDecodeBinary(t, c, new(Capability))
+		}
+	})
+	t.Run("unknown extension", func(t *testing.T) {
+		testserdes.EncodeDecodeBinary(t, &Capability{Type: 0xfe, Data: &Unknown{1, 2, 3}}, new(Capability))
+
+		// Compact blocks capability can be skipped by nodes not
+		// supporting it.
+		data, err := testserdes.EncodeBinary(&Capability{Type: CompactBlocks, Data: &Compact{Version: 1}})
+		require.NoError(t, err)
+		var u Unknown
+		require.NoError(t, testserdes.DecodeBinary(data[1:], &u))
+		require.Equal(t, Unknown{1}, u)
+	})
+	t.Run("unknown", func(t *testing.T) {
+		for _, typ := range []Type{0x00, 0x03, 0x11, MinExtensionType - 1} {
+			require.Error(t, testserdes.DecodeBinary([]byte{byte(typ), 1, 2}, new(Capability)), typ)
+		}
+	})
+	t.Run("bad compact", func(t *testing.T) {
+		require.Error(t, testserdes.DecodeBinary([]byte{byte(CompactBlocks), 0}, new(Capability)))
+	})
+}
+
+func TestCapabilitiesUnique(t *testing.T) {
+	var cs = Capabilities{
+		{Type: CompactBlocks, Data: &Compact{}},
+		{Type: CompactBlocks, Data: &Compact{}},
+	}
+	data, err := testserdes.EncodeBinary(&cs)
+	require.NoError(t, err)
+	require.Error(t, testserdes.DecodeBinary(data, new(Capabilities)))
+
+	// Unknown extensions are not checked.
+	cs = Capabilities{
+		{Type: 0xfe, Data: &Unknown{}},
+		{Type: 0xfe, Data: &Unknown{}},
+	}
+	data, err = testserdes.EncodeBinary(&cs)
+	require.NoError(t, err)
+	require.NoError(t, testserdes.DecodeBinary(data, new(Capabilities)))
+
+	// Decoding errors are not hidden by the check.
+	require.Error(t, testserdes.DecodeBinary([]byte{1, 0x03, 1, 2}, new(Capabilities)))
+}
@@ -10,4 +10,13 @@ const (
 	WSServer Type = 0x02
 	// FullNode represents full node capability type.
 	FullNode Type = 0x10
+	// CompactBlocks represents compact block relay capability type, it's
+	// a NeoGo extension.
+	CompactBlocks Type = 0xf1
 )
+
+// MinExtensionType is the first capability type of the range reserved for
+// protocol extensions. Extension capabilities are encoded as variable-length
+// byte arrays, so unknown ones from this range can be skipped, while unknown
+// capabilities of other types are still treated as an error.
+const MinExtensionType Type = 0xf0
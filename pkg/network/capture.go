@@ -0,0 +1,225 @@
+package network
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	gio "io"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"go.uber.org/zap"
+)
+
+// CaptureDirection is a direction of the captured P2P message.
+type CaptureDirection byte
+
+// Possible capture directions.
+const (
+	// CaptureIn is used for messages received from the peer.
+	CaptureIn CaptureDirection = iota
+	// CaptureOut is used for messages sent to the peer.
+	CaptureOut
+)
+
+// captureMagic is the capture file signature.
+var captureMagic = [4]byte{'N', 'G', 'P', 'C'}
+
+// captureVersion is the current capture file format version.
+const captureVersion = 0
+
+const (
+	// maxCaptureAddrLen is the maximum length of the peer address in the capture.
+	maxCaptureAddrLen = 256
+	// maxCaptureDataLen is the maximum length of the record data, outgoing
+	// packets can contain several messages.
+	maxCaptureDataLen = 2 * payload.MaxSize
+)
+
+var errInvalidCapture = errors.New("invalid capture file")
+
+// CaptureRecord is a single record of the P2P capture file. Capture file
+// starts with a header containing 'NGPC' signature, format version byte,
+// network magic (uint32) and StateRootInHeader setting (bool), it's followed
+// by records each containing timestamp (int64, nanoseconds since Unix
+// epoch), direction byte, peer ID (uint32, version nonce of the peer or 0
+// if the peer hasn't sent its version yet), peer address (var-string)
+// and var-bytes data with one or more serialized Messages.
+type CaptureRecord struct {
+	Time      time.Time
+	Direction CaptureDirection
+	PeerID    uint32
+	PeerAddr  string
+	Data      []byte
+}
+
+// CaptureWriter writes P2P capture files, it's safe for concurrent use.
+type CaptureWriter struct {
+	lock sync.Mutex
+	w    *io.BinWriter
+	c    gio.Closer
+}
+
+// CaptureReader reads P2P capture files.
+type CaptureReader struct {
+	r *io.BinReader
+
+	// Magic is the network magic of the captured network.
+	Magic netmode.Magic
+	// StateRootInHeader is the StateRootInHeader setting of the captured
+	// network, it's needed to decode blocks properly.
+	StateRootInHeader bool
+}
+
+// EncodeBinary implements the io.Serializable interface.
+func (r *CaptureRecord) EncodeBinary(w *io.BinWriter) {
+	w.WriteU64LE(uint64(r.Time.UnixNano()))
+	w.WriteB(byte(r.Direction))
+	w.WriteU32LE(r.PeerID)
+	w.WriteString(r.PeerAddr)
+	w.WriteVarBytes(r.Data)
+}
+
+// DecodeBinary implements the io.Serializable interface.
+func (r *CaptureRecord) DecodeBinary(br *io.BinReader) {
+	r.Time = time.Unix(0, int64(br.ReadU64LE()))
+	r.Direction = CaptureDirection(br.ReadB())
+	if br.Err == nil && r.Direction > CaptureOut {
+		br.Err = fmt.Errorf("%w: bad direction %d", errInvalidCapture, r.Direction)
+	}
+	r.PeerID = br.ReadU32LE()
+	r.PeerAddr = br.ReadString(maxCaptureAddrLen)
+	r.Data = br.ReadVarBytes(maxCaptureDataLen)
+}
+
+// Messages decodes all messages contained in the record.
+func (r *CaptureRecord) Messages(stateRootInHeader bool) ([]*Message, error) {
+	var (
+		res []*Message
+		br  = io.NewBinReaderFromBuf(r.Data)
+	)
+	for br.Len() > 0 {
+		msg := &Message{StateRootInHeader: stateRootInHeader}
+		err := msg.Decode(br)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, msg)
+	}
+	return res, nil
+}
+
+// NewCaptureWriter creates a new CaptureWriter writing to the given
+// WriteCloser (which is closed by Close).
+func NewCaptureWriter(w gio.WriteCloser, magic netmode.Magic, stateRootInHeader bool) (*CaptureWriter, error) {
+	bw := io.NewBinWriterFromIO(w)
+	bw.WriteBytes(captureMagic[:])
+	bw.WriteB(captureVersion)
+	bw.WriteU32LE(uint32(magic))
+	bw.WriteBool(stateRootInHeader)
+	if bw.Err != nil {
+		return nil, bw.Err
+	}
+	return &CaptureWriter{w: bw, c: w}, nil
+}
+
+// Write writes the given record into the capture.
+func (c *CaptureWriter) Write(rec *CaptureRecord) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	rec.EncodeBinary(c.w)
+	return c.w.Err
+}
+
+// Close closes the underlying writer.
+func (c *CaptureWriter) Close() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.c.Close()
+}
+
+// NewCaptureReader creates a new CaptureReader reading the capture from the
+// given reader. It reads and checks the capture header.
+func NewCaptureReader(r gio.Reader) (*CaptureReader, error) {
+	var (
+		br  = io.NewBinReaderFromIO(r)
+		sig [4]byte
+	)
+	br.ReadBytes(sig[:])
+	ver := br.ReadB()
+	magic := br.ReadU32LE()
+	srInHeader := br.ReadBool()
+	if br.Err != nil {
+		return nil, fmt.Errorf("failed to read capture header: %w", br.Err)
+	}
+	if sig != captureMagic {
+		return nil, fmt.Errorf("%w: bad signature", errInvalidCapture)
+	}
+	if ver != captureVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", errInvalidCapture, ver)
+	}
+	return &CaptureReader{
+		r:                 br,
+		Magic:             netmode.Magic(magic),
+		StateRootInHeader: srInHeader,
+	}, nil
+}
+
+// Read reads the next record from the capture. It returns io.EOF when there
+// are no more records.
+func (c *CaptureReader) Read() (*CaptureRecord, error) {
+	var rec = new(CaptureRecord)
+	rec.DecodeBinary(c.r)
+	if c.r.Err != nil {
+		if errors.Is(c.r.Err, gio.EOF) {
+			return nil, gio.EOF
+		}
+		return nil, c.r.Err
+	}
+	return rec, nil
+}
+
+// captureBuffer is an io.Reader that stores everything read from the
+// underlying reader, it's used to capture raw incoming messages.
+type captureBuffer struct {
+	r   gio.Reader
+	buf bytes.Buffer
+}
+
+func (c *captureBuffer) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.buf.Write(p[:n])
+	return n, err
+}
+
+// take returns everything read since the previous call.
+func (c *captureBuffer) take() []byte {
+	b := bytes.Clone(c.buf.Bytes())
+	c.buf.Reset()
+	return b
+}
+
+// capture writes a record for the data sent to or received from the peer if
+// capturing is enabled.
+func (s *Server) capture(p Peer, dir CaptureDirection, data []byte) {
+	if s.captureW == nil {
+		return
+	}
+	var id uint32
+	if ver := p.Version(); ver != nil {
+		id = ver.Nonce
+	}
+	err := s.captureW.Write(&CaptureRecord{
+		Time:      time.Now(),
+		Direction: dir,
+		PeerID:    id,
+		PeerAddr:  p.RemoteAddr().String(),
+		Data:      data,
+	})
+	if err != nil && s.captureFailed.CompareAndSwap(false, true) {
+		s.log.Warn("failed to write P2P capture", zap.Error(err))
+	}
+}
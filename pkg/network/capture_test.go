@@ -0,0 +1,164 @@
+package network
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/internal/fakechain"
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/network/capability"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaptureReadWrite(t *testing.T) {
+	var (
+		path = filepath.Join(t.TempDir(), "capture")
+		ping = NewMessage(CMDPing, payload.NewPing(1, 2))
+		pong = NewMessage(CMDPong, payload.NewPing(3, 4))
+	)
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	w, err := NewCaptureWriter(f, netmode.UnitTestNet, true)
+	require.NoError(t, err)
+
+	pingB, err := ping.Bytes()
+	require.NoError(t, err)
+	pongB, err := pong.Bytes()
+	require.NoError(t, err)
+	recs := []*CaptureRecord{
+		{Time: time.Unix(0, 1), Direction: CaptureIn, PeerID: 1, PeerAddr: "1.2.3.4:10333", Data: pingB},
+		{Time: time.Unix(0, 2), Direction: CaptureOut, PeerID: 1, PeerAddr: "1.2.3.4:10333", Data: append(pongB, pingB...)},
+	}
+	for _, r := range recs {
+		require.NoError(t, w.Write(r))
+	}
+	require.NoError(t, w.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	r, err := NewCaptureReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, netmode.UnitTestNet, r.Magic)
+	require.True(t, r.StateRootInHeader)
+	for _, expected := range recs {
+		actual, err := r.Read()
+		require.NoError(t, err)
+		require.Equal(t, expected.Time.UnixNano(), actual.Time.UnixNano())
+		require.Equal(t, expected.Direction, actual.Direction)
+		require.Equal(t, expected.PeerID, actual.PeerID)
+		require.Equal(t, expected.PeerAddr, actual.PeerAddr)
+		require.Equal(t, expected.Data, actual.Data)
+	}
+	_, err = r.Read()
+	require.ErrorIs(t, err, io.EOF)
+
+	msgs, err := recs[1].Messages(false)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(msgs))
+	require.Equal(t, CMDPong, msgs[0].Command)
+	require.Equal(t, pong.Payload, msgs[0].Payload)
+	require.Equal(t, CMDPing, msgs[1].Command)
+
+	t.Run("bad header", func(t *testing.T) {
+		_, err := NewCaptureReader(bytes.NewReader([]byte("NGPX\x00\x00\x00\x00\x00\x00")))
+		require.ErrorIs(t, err, errInvalidCapture)
+		_, err = NewCaptureReader(bytes.NewReader([]byte("NGPC\x01\x00\x00\x00\x00\x00")))
+		require.ErrorIs(t, err, errInvalidCapture)
+		_, err = NewCaptureReader(bytes.NewReader([]byte("NGPC")))
+		require.Error(t, err)
+	})
+}
+
+func TestServerCapture(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "capture")
+	s := newTestServer(t, ServerConfig{UserAgent: "/test/", CaptureFile: path})
+	p := newLocalPeer(t, s)
+	p.version = &payload.Version{Nonce: 42}
+	s.capture(p, CaptureIn, []byte{1, 2, 3})
+	require.NoError(t, s.captureW.Close())
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	r, err := NewCaptureReader(f)
+	require.NoError(t, err)
+	rec, err := r.Read()
+	require.NoError(t, err)
+	require.Equal(t, CaptureIn, rec.Direction)
+	require.Equal(t, uint32(42), rec.PeerID)
+	require.Equal(t, p.RemoteAddr().String(), rec.PeerAddr)
+	require.Equal(t, []byte{1, 2, 3}, rec.Data)
+}
+
+func TestServerReplay(t *testing.T) {
+	s := startTestServer(t)
+	s.chain.(*fakechain.FakeChain).Blockheight.Store(9)
+
+	var (
+		buf = &closeBuffer{}
+		now = time.Now()
+	)
+	w, err := NewCaptureWriter(buf, s.Net, false)
+	require.NoError(t, err)
+	write := func(dir CaptureDirection, id uint32, addr string, msgs ...*Message) {
+		var data []byte
+		for _, m := range msgs {
+			b, err := m.Bytes()
+			require.NoError(t, err)
+			data = append(data, b...)
+		}
+		require.NoError(t, w.Write(&CaptureRecord{Time: now, Direction: dir, PeerID: id, PeerAddr: addr, Data: data}))
+	}
+	blockMsg := func(index uint32) *Message {
+		b := block.New(false)
+		b.Index = index
+		return NewMessage(CMDBlock, b)
+	}
+	ver := payload.NewVersion(s.Net, 1, "/other/", []capability.Capability{{
+		Type: capability.FullNode,
+		Data: &capability.Node{StartHeight: 12},
+	}})
+	write(CaptureIn, 0, "1.2.3.4:10333", NewMessage(CMDVersion, ver))
+	write(CaptureOut, 1, "1.2.3.4:10333", NewMessage(CMDVerack, payload.NewNullPayload()))
+	write(CaptureIn, 1, "1.2.3.4:10333", NewMessage(CMDVerack, payload.NewNullPayload()), blockMsg(11))
+	write(CaptureIn, 2, "5.6.7.8:10333", blockMsg(10))
+	write(CaptureIn, 1, "1.2.3.4:10333", blockMsg(12), NewMessage(CMDPing, payload.NewPing(12, 0)))
+	write(CaptureIn, 1, "1.2.3.4:10333", NewMessage(CMDGetAddr, payload.NewNullPayload()))
+
+	t.Run("filter", func(t *testing.T) {
+		r, err := NewCaptureReader(bytes.NewReader(buf.Bytes()))
+		require.NoError(t, err)
+		stats, err := s.Replay(r, func(rec *CaptureRecord) bool { return rec.PeerID == 2 })
+		require.NoError(t, err)
+		require.Equal(t, ReplayStats{Records: 1, Messages: 1, Peers: 1}, stats)
+		require.Equal(t, uint32(10), s.chain.BlockHeight())
+	})
+
+	r, err := NewCaptureReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	stats, err := s.Replay(r, nil)
+	require.NoError(t, err)
+	require.Equal(t, ReplayStats{Records: 5, Messages: 7, Peers: 2}, stats)
+	require.Equal(t, uint32(12), s.chain.BlockHeight())
+
+	t.Run("bad magic", func(t *testing.T) {
+		r, err := NewCaptureReader(bytes.NewReader(buf.Bytes()))
+		require.NoError(t, err)
+		r.Magic++
+		_, err = s.Replay(r, nil)
+		require.ErrorIs(t, err, errInvalidNetwork)
+	})
+}
+
+// closeBuffer is a bytes.Buffer implementing io.Closer.
+type closeBuffer struct {
+	bytes.Buffer
+}
+
+func (b *closeBuffer) Close() error { return nil }
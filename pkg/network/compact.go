@@ -0,0 +1,231 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/network/capability"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"go.uber.org/zap"
+)
+
+// maxPendingCompactBlocks is the maximum number of compact blocks waiting for
+// missing transactions.
+const maxPendingCompactBlocks = 16
+
+// Possible compact block reconstruction results used in metrics.
+const (
+	compactResultMempool = "mempool"
+	compactResultFetched = "fetched"
+	compactResultFailed  = "failed"
+)
+
+type (
+	// compactBlocks keeps compact blocks that can't be reconstructed from
+	// the memory pool until missing transactions are received.
+	compactBlocks struct {
+		lock    sync.Mutex
+		pending map[util.Uint256]*pendingCompact
+	}
+
+	// pendingCompact is a partially reconstructed compact block.
+	pendingCompact struct {
+		block   *block.Block
+		ids     []uint64
+		missing []uint16
+		peer    Peer
+	}
+)
+
+var errBadCompactBlock = errors.New("bad compact block")
+
+func newCompactBlocks() *compactBlocks {
+	return &compactBlocks{pending: make(map[util.Uint256]*pendingCompact)}
+}
+
+// add stores the pending block evicting outdated ones (those that are below
+// the given height) and, if there are still too many of them, a random one.
+func (c *compactBlocks) add(pc *pendingCompact, height uint32) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for h, p := range c.pending {
+		if p.block.Index <= height {
+			delete(c.pending, h)
+		}
+	}
+	for h := range c.pending {
+		if len(c.pending) < maxPendingCompactBlocks {
+			break
+		}
+		delete(c.pending, h)
+	}
+	c.pending[pc.block.Hash()] = pc
+}
+
+// take returns the pending block with the given hash requested from the given
+// peer and removes it.
+func (c *compactBlocks) take(h util.Uint256, p Peer) *pendingCompact {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	pc := c.pending[h]
+	if pc == nil || pc.peer != p {
+		return nil
+	}
+	delete(c.pending, h)
+	return pc
+}
+
+// removePeer drops all blocks pending from the disconnected peer.
+func (c *compactBlocks) removePeer(p Peer) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for h, pc := range c.pending {
+		if pc.peer == p {
+			delete(c.pending, h)
+		}
+	}
+}
+
+// supportsCompactBlocks checks whether the peer has advertised compact block
+// relay capability.
+func supportsCompactBlocks(p Peer) bool {
+	v := p.Version()
+	if v == nil {
+		return false
+	}
+	for _, c := range v.Capabilities {
+		if c.Type == capability.CompactBlocks {
+			return true
+		}
+	}
+	return false
+}
+
+// relayBlock announces a new block to the peers, compact blocks are sent
+// directly to peers supporting them, other peers receive an inventory.
+func (s *Server) relayBlock(b *block.Block) {
+	// Filter out nodes that are more current (avoid spamming the network
+	// during initial sync).
+	needBlock := func(p Peer) bool {
+		return p.Handshaked() && p.LastBlockIndex() < b.Index
+	}
+	if s.compact != nil {
+		msg := NewMessage(CMDCompactBlock, payload.NewCompactBlock(b))
+		s.iteratePeersWithSendMsg(msg, Peer.BroadcastHPPacket, func(p Peer) bool {
+			return needBlock(p) && supportsCompactBlocks(p)
+		})
+	}
+	msg := NewMessage(CMDInv, payload.NewInventory(payload.BlockType, []util.Uint256{b.Hash()}))
+	s.iteratePeersWithSendMsg(msg, Peer.BroadcastPacket, func(p Peer) bool {
+		return needBlock(p) && (s.compact == nil || !supportsCompactBlocks(p))
+	})
+}
+
+// handleCompactBlockCmd reconstructs the block from the compact block received
+// using memory pool transactions, missing transactions are requested from the
+// peer.
+func (s *Server) handleCompactBlockCmd(p Peer, cb *payload.CompactBlock) error {
+	if s.compact == nil || s.stateSync.IsActive() {
+		return nil
+	}
+	h := cb.Hash()
+	if cb.Index <= s.chain.BlockHeight() || s.chain.HasBlock(h) {
+		return nil
+	}
+	var (
+		b       = block.New(s.config.StateRootInHeader)
+		missing []uint16
+	)
+	b.Header = *cb.Header
+	b.Transactions = make([]*transaction.Transaction, len(cb.ShortIDs))
+	for i, id := range cb.ShortIDs {
+		// Lower 32 bits are the mempool short hash, see payload.ShortTxID.
+		for _, tx := range s.mempool.GetByShortHash(uint32(id)) {
+			if payload.ShortTxID(h, tx.Hash()) == id {
+				b.Transactions[i] = tx
+				break
+			}
+		}
+		if b.Transactions[i] == nil {
+			missing = append(missing, uint16(i))
+		}
+	}
+	if len(missing) == 0 {
+		return s.completeCompactBlock(p, b, compactResultMempool)
+	}
+	s.compact.add(&pendingCompact{
+		block:   b,
+		ids:     cb.ShortIDs,
+		missing: missing,
+		peer:    p,
+	}, s.chain.BlockHeight())
+	return p.EnqueueP2PMessage(NewMessage(CMDGetBlockTxn, &payload.GetBlockTxn{BlockHash: h, Indexes: missing}))
+}
+
+// handleBlockTxnCmd completes the pending compact block with transactions
+// received.
+func (s *Server) handleBlockTxnCmd(p Peer, bt *payload.BlockTxn) error {
+	if s.compact == nil {
+		return nil
+	}
+	pc := s.compact.take(bt.BlockHash, p)
+	if pc == nil {
+		return nil
+	}
+	if len(bt.Transactions) != len(pc.missing) {
+		return fmt.Errorf("%w: %d transactions requested, %d received", errBadCompactBlock, len(pc.missing), len(bt.Transactions))
+	}
+	for i, tx := range bt.Transactions {
+		idx := pc.missing[i]
+		if payload.ShortTxID(bt.BlockHash, tx.Hash()) != pc.ids[idx] {
+			return fmt.Errorf("%w: unexpected transaction %s", errBadCompactBlock, tx.Hash().StringLE())
+		}
+		pc.block.Transactions[idx] = tx
+	}
+	return s.completeCompactBlock(p, pc.block, compactResultFetched)
+}
+
+// completeCompactBlock checks the reconstructed block and processes it as
+// a regular one. If the block doesn't match its header (which can happen
+// because of short ID collisions), the full block is requested.
+func (s *Server) completeCompactBlock(p Peer, b *block.Block, result string) error {
+	if b.ComputeMerkleRoot() != b.MerkleRoot {
+		s.log.Debug("compact block reconstruction failed",
+			zap.Uint32("index", b.Index),
+			zap.Stringer("hash", b.Hash()))
+		return s.requestFullBlock(p, b.Hash())
+	}
+	addCompactBlockMetric(result)
+	return s.handleBlockCmd(p, b)
+}
+
+// requestFullBlock requests the block that can't be reconstructed from the
+// compact one.
+func (s *Server) requestFullBlock(p Peer, h util.Uint256) error {
+	addCompactBlockMetric(compactResultFailed)
+	return p.EnqueueP2PMessage(NewMessage(CMDGetData, payload.NewInventory(payload.BlockType, []util.Uint256{h})))
+}
+
+// handleGetBlockTxnCmd sends transactions requested for the compact block
+// reconstruction.
+func (s *Server) handleGetBlockTxnCmd(p Peer, g *payload.GetBlockTxn) error {
+	b, err := s.chain.GetBlock(g.BlockHash)
+	if err != nil {
+		return p.EnqueueP2PMessage(NewMessage(CMDNotFound, payload.NewInventory(payload.BlockType, []util.Uint256{g.BlockHash})))
+	}
+	res := &payload.BlockTxn{
+		BlockHash:    g.BlockHash,
+		Transactions: make([]*transaction.Transaction, 0, len(g.Indexes)),
+	}
+	for _, i := range g.Indexes {
+		if int(i) >= len(b.Transactions) {
+			return fmt.Errorf("%w: invalid transaction index %d", errBadCompactBlock, i)
+		}
+		res.Transactions = append(res.Transactions, b.Transactions[i])
+	}
+	return p.EnqueueP2PMessage(NewMessage(CMDBlockTxn, res))
+}
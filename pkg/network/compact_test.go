@@ -0,0 +1,252 @@
+package network
+
+import (
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/internal/fakechain"
+	"github.com/nspcc-dev/neo-go/internal/random"
+	"github.com/nspcc-dev/neo-go/internal/testserdes"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/network/capability"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func newCompactTestBlock(index uint32, txs ...*transaction.Transaction) *block.Block {
+	b := block.New(false)
+	b.Index = index
+	b.PrevHash = random.Uint256()
+	b.Transactions = txs
+	b.MerkleRoot = b.ComputeMerkleRoot()
+	b.Hash()
+	return b
+}
+
+func TestCompactBlocksCapability(t *testing.T) {
+	getVersion := func(t *testing.T, cfg ServerConfig) *payload.Version {
+		s := newTestServer(t, cfg)
+		msg, err := s.getVersionMsg(nil)
+		require.NoError(t, err)
+		return msg.Payload.(*payload.Version)
+	}
+
+	t.Run("not announced", func(t *testing.T) {
+		ver := getVersion(t, ServerConfig{UserAgent: "/test/", CompactBlocks: true})
+		// Only the capabilities known to any node are advertised, so
+		// nodes without compact blocks support can connect.
+		for _, c := range ver.Capabilities {
+			require.Contains(t, []capability.Type{capability.TCPServer, capability.WSServer, capability.FullNode}, c.Type)
+		}
+		p := newLocalPeer(t, nil)
+		p.version = ver
+		require.False(t, supportsCompactBlocks(p))
+
+		ver = getVersion(t, ServerConfig{UserAgent: "/test/", AnnounceCompactBlocks: true})
+		p.version = ver
+		require.False(t, supportsCompactBlocks(p))
+	})
+	t.Run("announced", func(t *testing.T) {
+		ver := getVersion(t, ServerConfig{UserAgent: "/test/", CompactBlocks: true, AnnounceCompactBlocks: true})
+		p := newLocalPeer(t, nil)
+		require.False(t, supportsCompactBlocks(p))
+		p.version = ver
+		require.True(t, supportsCompactBlocks(p))
+	})
+}
+
+// TestCompactBlocksHandshake checks that nodes without compact blocks support
+// can connect to a node having it enabled.
+func TestCompactBlocksHandshake(t *testing.T) {
+	s := newTestServer(t, ServerConfig{UserAgent: "/test/", CompactBlocks: true})
+	p := newLocalPeer(t, s)
+	na, _ := net.ResolveTCPAddr("tcp", "0.0.0.0:3000")
+	p.netaddr = *na
+
+	var sent []*Message
+	p.messageHandler = func(t *testing.T, msg *Message) {
+		sent = append(sent, msg)
+	}
+	require.NoError(t, p.SendVersion())
+	require.Equal(t, 1, len(sent))
+	require.Equal(t, CMDVersion, sent[0].Command)
+
+	// A peer without compact blocks support decodes the version using only
+	// the capabilities it knows.
+	data, err := testserdes.EncodeBinary(sent[0].Payload.(*payload.Version))
+	require.NoError(t, err)
+	ver := new(payload.Version)
+	require.NoError(t, testserdes.DecodeBinary(data, ver))
+	for _, c := range ver.Capabilities {
+		switch c.Type {
+		case capability.TCPServer, capability.WSServer:
+			require.IsType(t, &capability.Server{}, c.Data)
+		case capability.FullNode:
+			require.IsType(t, &capability.Node{}, c.Data)
+		default:
+			t.Fatalf("unexpected capability %d", c.Type)
+		}
+	}
+
+	// And its version is accepted by the node.
+	data, err = testserdes.EncodeBinary(payload.NewVersion(0, 1337, "/NEO:3.6.0/", []capability.Capability{
+		{Type: capability.TCPServer, Data: &capability.Server{Port: 3000}},
+		{Type: capability.FullNode, Data: &capability.Node{StartHeight: 0}},
+	}))
+	require.NoError(t, err)
+	ver = new(payload.Version)
+	require.NoError(t, testserdes.DecodeBinary(data, ver))
+	require.NoError(t, s.handleVersionCmd(p, ver))
+	require.Equal(t, 2, len(sent))
+	require.Equal(t, CMDVerack, sent[1].Command)
+
+	p.version = ver
+	require.False(t, supportsCompactBlocks(p))
+}
+
+func TestCompactBlocks(t *testing.T) {
+	s := newTestServer(t, ServerConfig{UserAgent: "/test/", CompactBlocks: true})
+	startWithCleanup(t, s)
+	chain := s.chain.(*fakechain.FakeChain)
+	chain.Blockheight.Store(9)
+
+	var msgs []*Message
+	p := newLocalPeer(t, s)
+	p.handshaked = 1
+	p.messageHandler = func(t *testing.T, msg *Message) {
+		msgs = append(msgs, msg)
+	}
+	txs := []*transaction.Transaction{newDummyTx(), newDummyTx(), newDummyTx(), newDummyTx()}
+	require.NoError(t, chain.GetMemPool().Add(txs[0], &feerStub{blockHeight: 9}))
+	require.NoError(t, chain.GetMemPool().Add(txs[2], &feerStub{blockHeight: 9}))
+
+	t.Run("from mempool", func(t *testing.T) {
+		b := newCompactTestBlock(10, txs[0], txs[2])
+		require.NoError(t, s.handleMessage(p, NewMessage(CMDCompactBlock, payload.NewCompactBlock(b))))
+		require.Equal(t, 0, len(msgs))
+		require.Eventually(t, func() bool { return chain.BlockHeight() == 10 }, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("missing transactions", func(t *testing.T) {
+		b := newCompactTestBlock(11, txs[0], txs[1], txs[2], txs[3])
+		cb := NewMessage(CMDCompactBlock, payload.NewCompactBlock(b))
+		require.NoError(t, s.handleMessage(p, cb))
+		require.Equal(t, 1, len(msgs))
+		require.Equal(t, CMDGetBlockTxn, msgs[0].Command)
+		require.Equal(t, &payload.GetBlockTxn{BlockHash: b.Hash(), Indexes: []uint16{1, 3}}, msgs[0].Payload)
+
+		// Unexpected transactions.
+		require.ErrorIs(t, s.handleMessage(p, NewMessage(CMDBlockTxn, &payload.BlockTxn{
+			BlockHash:    b.Hash(),
+			Transactions: []*transaction.Transaction{txs[3], txs[1]},
+		})), errBadCompactBlock)
+		// Not pending anymore.
+		require.NoError(t, s.handleMessage(p, NewMessage(CMDBlockTxn, &payload.BlockTxn{
+			BlockHash:    b.Hash(),
+			Transactions: []*transaction.Transaction{txs[1], txs[3]},
+		})))
+		require.Equal(t, uint32(10), chain.BlockHeight())
+
+		require.NoError(t, s.handleMessage(p, cb))
+		require.Equal(t, 2, len(msgs))
+		// Only the requested peer is accepted.
+		bt := NewMessage(CMDBlockTxn, &payload.BlockTxn{
+			BlockHash:    b.Hash(),
+			Transactions: []*transaction.Transaction{txs[1], txs[3]},
+		})
+		other := newLocalPeer(t, s)
+		other.handshaked = 1
+		require.NoError(t, s.handleMessage(other, bt))
+		require.Equal(t, uint32(10), chain.BlockHeight())
+		require.NoError(t, s.handleMessage(p, bt))
+		require.Eventually(t, func() bool { return chain.BlockHeight() == 11 }, time.Second, 10*time.Millisecond)
+		msgs = msgs[:0]
+	})
+
+	t.Run("bad merkle root", func(t *testing.T) {
+		b := newCompactTestBlock(12, txs[0])
+		b.MerkleRoot = util.Uint256{1, 2, 3}
+		require.NoError(t, s.handleMessage(p, NewMessage(CMDCompactBlock, payload.NewCompactBlock(b))))
+		require.Equal(t, 1, len(msgs))
+		require.Equal(t, CMDGetData, msgs[0].Command)
+		require.Equal(t, payload.NewInventory(payload.BlockType, []util.Uint256{b.Hash()}), msgs[0].Payload)
+		msgs = msgs[:0]
+	})
+
+	t.Run("old block", func(t *testing.T) {
+		b := newCompactTestBlock(5, txs[1])
+		require.NoError(t, s.handleMessage(p, NewMessage(CMDCompactBlock, payload.NewCompactBlock(b))))
+		require.Equal(t, 0, len(msgs))
+	})
+
+	t.Run("getblocktxn", func(t *testing.T) {
+		b := newCompactTestBlock(20, txs...)
+		chain.PutBlock(b)
+		require.NoError(t, s.handleMessage(p, NewMessage(CMDGetBlockTxn, &payload.GetBlockTxn{
+			BlockHash: b.Hash(),
+			Indexes:   []uint16{3, 1},
+		})))
+		require.Equal(t, 1, len(msgs))
+		require.Equal(t, CMDBlockTxn, msgs[0].Command)
+		require.Equal(t, &payload.BlockTxn{
+			BlockHash:    b.Hash(),
+			Transactions: []*transaction.Transaction{txs[3], txs[1]},
+		}, msgs[0].Payload)
+
+		require.ErrorIs(t, s.handleMessage(p, NewMessage(CMDGetBlockTxn, &payload.GetBlockTxn{
+			BlockHash: b.Hash(),
+			Indexes:   []uint16{4},
+		})), errBadCompactBlock)
+
+		h := random.Uint256()
+		require.NoError(t, s.handleMessage(p, NewMessage(CMDGetBlockTxn, &payload.GetBlockTxn{
+			BlockHash: h,
+			Indexes:   []uint16{0},
+		})))
+		require.Equal(t, 2, len(msgs))
+		require.Equal(t, CMDNotFound, msgs[1].Command)
+		require.Equal(t, payload.NewInventory(payload.BlockType, []util.Uint256{h}), msgs[1].Payload)
+	})
+}
+
+func TestRelayCompactBlock(t *testing.T) {
+	s := newTestServer(t, ServerConfig{UserAgent: "/test/", CompactBlocks: true})
+	startWithCleanup(t, s)
+
+	var (
+		lock sync.Mutex
+		cmds = make(map[bool][]CommandType)
+	)
+	newPeer := func(compact bool) {
+		p := newLocalPeer(t, s)
+		p.handshaked = 1
+		p.version = &payload.Version{}
+		if compact {
+			p.version.Capabilities = capability.Capabilities{{Type: capability.CompactBlocks, Data: &capability.Compact{}}}
+		}
+		p.messageHandler = func(t *testing.T, msg *Message) {
+			if msg.Command == CMDCompactBlock || msg.Command == CMDInv {
+				lock.Lock()
+				cmds[compact] = append(cmds[compact], msg.Command)
+				lock.Unlock()
+			}
+		}
+		s.register <- p
+	}
+	newPeer(true)
+	newPeer(false)
+	require.Eventually(t, func() bool { return 2 == s.PeerCount() }, time.Second, time.Millisecond*10)
+
+	s.relayBlock(newCompactTestBlock(1, newDummyTx()))
+	require.Eventually(t, func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return len(cmds[true]) == 1 && len(cmds[false]) == 1
+	}, time.Second, time.Millisecond*10)
+	require.Equal(t, []CommandType{CMDCompactBlock}, cmds[true])
+	require.Equal(t, []CommandType{CMDInv}, cmds[false])
+}
@@ -3,6 +3,7 @@ package network
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
@@ -46,6 +47,30 @@ const (
 // CommandType represents the type of a message command.
 type CommandType byte
 
+// commandsByName maps lowercase command names without "CMD" prefix (like "inv"
+// or "getdata") to commands, it's built from the stringer output, so it
+// contains every known command.
+var commandsByName = func() map[string]CommandType {
+	var res = make(map[string]CommandType)
+	for i := 0; i <= 0xff; i++ {
+		var name = CommandType(i).String()
+		if strings.HasPrefix(name, "CMD") {
+			res[strings.ToLower(strings.TrimPrefix(name, "CMD"))] = CommandType(i)
+		}
+	}
+	return res
+}()
+
+// commandFromString returns a command with the given name (as used in the
+// configuration, like "inv" or "getdata"), it's case-insensitive.
+func commandFromString(name string) (CommandType, error) {
+	cmd, ok := commandsByName[strings.ToLower(name)]
+	if !ok {
+		return 0, fmt.Errorf("unknown command %q", name)
+	}
+	return cmd, nil
+}
+
 // Valid protocol commands used to send between nodes.
 const (
 	// Handshaking.
@@ -73,6 +98,9 @@ const (
 	CMDP2PNotaryRequest             = CommandType(payload.P2PNotaryRequestType)
 	CMDGetMPTData       CommandType = 0x51 // 0x5.. commands are used for extensions (P2PNotary, state exchange cmds)
 	CMDMPTData          CommandType = 0x52
+	CMDCompactBlock     CommandType = 0x53
+	CMDGetBlockTxn      CommandType = 0x54
+	CMDBlockTxn         CommandType = 0x55
 	CMDReject           CommandType = 0x2f
 
 	// SPV protocol.
@@ -146,6 +174,12 @@ func (m *Message) decodePayload() error {
 		p = &payload.AddressList{}
 	case CMDBlock:
 		p = block.New(m.StateRootInHeader)
+	case CMDCompactBlock:
+		p = &payload.CompactBlock{StateRootInHeader: m.StateRootInHeader}
+	case CMDGetBlockTxn:
+		p = &payload.GetBlockTxn{}
+	case CMDBlockTxn:
+		p = &payload.BlockTxn{}
 	case CMDExtensible:
 		p = payload.NewExtensible()
 	case CMDP2PNotaryRequest:
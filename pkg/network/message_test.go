@@ -3,6 +3,7 @@ package network
 import (
 	"errors"
 	"math/rand"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/nspcc-dev/neo-go/internal/testserdes"
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/network/capability"
@@ -280,6 +282,36 @@ func TestEncodeDecodeMPTData(t *testing.T) {
 	})
 }
 
+func TestEncodeDecodeCompactBlock(t *testing.T) {
+	b := newDummyBlock(12, 3)
+	cb := testEncodeDecode(t, CMDCompactBlock, payload.NewCompactBlock(b)).Payload.(*payload.CompactBlock)
+	require.Equal(t, b.Hash(), cb.Hash())
+	require.Equal(t, payload.ShortTxID(b.Hash(), b.Transactions[1].Hash()), cb.ShortIDs[1])
+	require.Equal(t, mempool.ShortHash(b.Transactions[1].Hash()), uint32(cb.ShortIDs[1]))
+	require.NotEqual(t, payload.ShortTxID(b.PrevHash, b.Transactions[1].Hash()), cb.ShortIDs[1])
+}
+
+func TestEncodeDecodeGetBlockTxn(t *testing.T) {
+	t.Run("good", func(t *testing.T) {
+		testEncodeDecode(t, CMDGetBlockTxn, &payload.GetBlockTxn{
+			BlockHash: random.Uint256(),
+			Indexes:   []uint16{1, 5},
+		})
+	})
+	t.Run("bad, no indexes", func(t *testing.T) {
+		testEncodeDecodeFail(t, CMDGetBlockTxn, &payload.GetBlockTxn{
+			BlockHash: random.Uint256(),
+		})
+	})
+}
+
+func TestEncodeDecodeBlockTxn(t *testing.T) {
+	testEncodeDecode(t, CMDBlockTxn, &payload.BlockTxn{
+		BlockHash:    random.Uint256(),
+		Transactions: []*transaction.Transaction{newDummyTx(), newDummyTx()},
+	})
+}
+
 func TestInvalidMessages(t *testing.T) {
 	t.Run("CMDBlock, empty payload", func(t *testing.T) {
 		testEncodeDecodeFail(t, CMDBlock, payload.NullPayload{})
@@ -373,3 +405,30 @@ func testEncodeDecodeFail(t *testing.T, cmd CommandType, p payload.Payload) *Mes
 	require.Error(t, testserdes.Decode(data, actual))
 	return actual
 }
+
+func TestCommandFromString(t *testing.T) {
+	for name, expected := range map[string]CommandType{
+		"inv":              CMDInv,
+		"getdata":          CMDGetData,
+		"GetAddr":          CMDGetAddr,
+		"extensible":       CMDExtensible,
+		"p2pnotaryrequest": CMDP2PNotaryRequest,
+	} {
+		cmd, err := commandFromString(name)
+		require.NoError(t, err, name)
+		require.Equal(t, expected, cmd)
+	}
+	_, err := commandFromString("unknown")
+	require.Error(t, err)
+
+	// Every command can be resolved by its name.
+	for i := 0; i <= 0xff; i++ {
+		var name = CommandType(i).String()
+		if !strings.HasPrefix(name, "CMD") {
+			continue
+		}
+		cmd, err := commandFromString(strings.TrimPrefix(name, "CMD"))
+		require.NoError(t, err, name)
+		require.Equal(t, CommandType(i), cmd)
+	}
+}
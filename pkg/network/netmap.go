@@ -0,0 +1,380 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	gio "io"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/network/capability"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+)
+
+const (
+	// DefaultCrawlMaxNodes is the default maximum number of nodes visited
+	// by Crawl.
+	DefaultCrawlMaxNodes = 1000
+	// DefaultCrawlWorkers is the default number of nodes probed by Crawl
+	// simultaneously.
+	DefaultCrawlWorkers = 16
+	// DefaultCrawlTimeout is the default time limit for a single node probe.
+	DefaultCrawlTimeout = 10 * time.Second
+)
+
+type (
+	// CrawlConfig is a network crawler configuration.
+	CrawlConfig struct {
+		// Magic is the network magic used in the handshake.
+		Magic netmode.Magic
+		// Seeds are the addresses crawling starts from.
+		Seeds []string
+		// UserAgent is sent to the nodes in the version message.
+		UserAgent string
+		// DialTimeout limits connection establishment, Timeout is used if
+		// it's not set.
+		DialTimeout time.Duration
+		// Timeout limits the time spent on a single node (including
+		// handshake and address request), DefaultCrawlTimeout is used if
+		// it's not set.
+		Timeout time.Duration
+		// MaxNodes limits the number of nodes visited, DefaultCrawlMaxNodes
+		// is used if it's not set.
+		MaxNodes int
+		// Workers is the number of nodes probed simultaneously,
+		// DefaultCrawlWorkers is used if it's not set.
+		Workers int
+	}
+
+	// NetMap is a network map built by the crawler.
+	NetMap struct {
+		Magic netmode.Magic `json:"magic"`
+		// Nodes are sorted by address.
+		Nodes []NetMapNode `json:"nodes"`
+	}
+
+	// NetMapNode is a single node of the network map.
+	NetMapNode struct {
+		Address string `json:"address"`
+		// Reachable is true if the handshake with the node succeeded.
+		Reachable bool   `json:"reachable"`
+		Error     string `json:"error,omitempty"`
+		UserAgent string `json:"useragent,omitempty"`
+		Nonce     uint32 `json:"nonce,omitempty"`
+		// Height is the node's height at the moment of the handshake, it's
+		// only known for full nodes.
+		Height       uint32   `json:"height,omitempty"`
+		Capabilities []string `json:"capabilities,omitempty"`
+		// Peers are the addresses sent by the node in response to getaddr.
+		Peers []string `json:"peers,omitempty"`
+	}
+)
+
+// Crawl connects to the seed nodes, performs a handshake with each of them,
+// requests their peers and recursively repeats this for all addresses
+// received. It returns the map of all nodes visited (including unreachable
+// ones) with the data they've sent. Crawling stops when there are no more
+// new addresses, the node limit is reached or the context is canceled.
+// Addresses are managed by DefaultDiscovery, so unreachable nodes are retried
+// and blacklisted the same way they are by the node itself.
+func Crawl(ctx context.Context, cfg CrawlConfig) *NetMap {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultCrawlTimeout
+	}
+	if cfg.DialTimeout <= 0 {
+		cfg.DialTimeout = cfg.Timeout
+	}
+	if cfg.MaxNodes <= 0 {
+		cfg.MaxNodes = DefaultCrawlMaxNodes
+	}
+	if cfg.Workers <= 0 {
+		cfg.Workers = DefaultCrawlWorkers
+	}
+	var (
+		t = &crawlTransport{
+			ctx:   ctx,
+			cfg:   cfg,
+			nodes: make(map[string]*NetMapNode),
+		}
+		ticker = time.NewTicker(crawlPollInterval)
+	)
+	defer ticker.Stop()
+	// Seeds are not passed as such, DefaultDiscovery retries them forever.
+	t.disc = NewDefaultDiscovery(nil, cfg.DialTimeout, t)
+	t.backfill(cfg.Seeds)
+	for {
+		t.disc.RequestRemote(cfg.Workers)
+		// Addresses are only removed from the pool after the dial
+		// completes (and new ones are added before that), so an empty
+		// pool means there is nothing left to do.
+		if t.disc.PoolCount() == 0 {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return t.netMap()
+		case <-ticker.C:
+		}
+	}
+	return t.netMap()
+}
+
+// crawlPollInterval is the period of crawler's discovery requests.
+const crawlPollInterval = 50 * time.Millisecond
+
+// crawlTransport is a Transporter probing nodes for Crawl instead of
+// establishing regular connections.
+type crawlTransport struct {
+	ctx  context.Context
+	cfg  CrawlConfig
+	disc *DefaultDiscovery
+
+	lock  sync.Mutex
+	nodes map[string]*NetMapNode
+}
+
+// crawledPeer is an AddressablePeer returned for successfully probed nodes.
+type crawledPeer struct {
+	addr string
+	net.Addr
+}
+
+// ConnectionAddr implements the AddressablePeer interface.
+func (p *crawledPeer) ConnectionAddr() string {
+	return p.addr
+}
+
+// PeerAddr implements the AddressablePeer interface.
+func (p *crawledPeer) PeerAddr() net.Addr {
+	return p.Addr
+}
+
+// Version implements the AddressablePeer interface, crawled nodes are
+// disconnected right after the probe, so there is no version to keep.
+func (p *crawledPeer) Version() *payload.Version {
+	return nil
+}
+
+// backfill adds new addresses to the map (up to the node limit) and to the
+// discovery pool.
+func (t *crawlTransport) backfill(addrs []string) {
+	var fresh []string
+	t.lock.Lock()
+	for _, addr := range addrs {
+		if _, ok := t.nodes[addr]; ok || len(t.nodes) >= t.cfg.MaxNodes {
+			continue
+		}
+		t.nodes[addr] = &NetMapNode{Address: addr}
+		fresh = append(fresh, addr)
+	}
+	t.lock.Unlock()
+	t.disc.BackFill(fresh...)
+}
+
+// Dial implements the Transporter interface, it probes the node and records
+// the result.
+func (t *crawlTransport) Dial(addr string, timeout time.Duration) (AddressablePeer, error) {
+	if err := t.ctx.Err(); err != nil {
+		return nil, err
+	}
+	n := probeNode(t.ctx, t.cfg, addr)
+	t.lock.Lock()
+	*t.nodes[addr] = n
+	t.lock.Unlock()
+	t.backfill(n.Peers)
+	if n.Error != "" {
+		return nil, errors.New(n.Error)
+	}
+	tcp, err := net.ResolveTCPAddr("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return &crawledPeer{addr: addr, Addr: tcp}, nil
+}
+
+// Accept implements the Transporter interface, crawler doesn't accept
+// connections.
+func (t *crawlTransport) Accept() {}
+
+// Proto implements the Transporter interface.
+func (t *crawlTransport) Proto() string {
+	return "tcp"
+}
+
+// HostPort implements the Transporter interface.
+func (t *crawlTransport) HostPort() (string, string) {
+	return "", ""
+}
+
+// Close implements the Transporter interface.
+func (t *crawlTransport) Close() {}
+
+// netMap returns the network map built so far, nodes that were not probed
+// get the context error.
+func (t *crawlTransport) netMap() *NetMap {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	res := &NetMap{Magic: t.cfg.Magic, Nodes: make([]NetMapNode, 0, len(t.nodes))}
+	for _, n := range t.nodes {
+		node := *n
+		if !node.Reachable && node.Error == "" && t.ctx.Err() != nil {
+			node.Error = t.ctx.Err().Error()
+		}
+		res.Nodes = append(res.Nodes, node)
+	}
+	sort.Slice(res.Nodes, func(i, j int) bool {
+		return res.Nodes[i].Address < res.Nodes[j].Address
+	})
+	return res
+}
+
+// probeNode performs a handshake with the node and requests its peers.
+func probeNode(ctx context.Context, cfg CrawlConfig, addr string) NetMapNode {
+	var (
+		n      = NetMapNode{Address: addr}
+		dialer = net.Dialer{Timeout: cfg.DialTimeout}
+	)
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		n.Error = err.Error()
+		return n
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(cfg.Timeout))
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = conn.Close()
+		case <-done:
+		}
+	}()
+
+	err = crawlHandshake(conn, cfg, &n)
+	if err != nil {
+		// Addresses received before the error are still useful.
+		n.Error = err.Error()
+	}
+	return n
+}
+
+// crawlHandshake runs the protocol with a single node filling its map entry.
+func crawlHandshake(conn net.Conn, cfg CrawlConfig, n *NetMapNode) error {
+	send := func(msg *Message) error {
+		b, err := msg.Bytes()
+		if err != nil {
+			return err
+		}
+		_, err = conn.Write(b)
+		return err
+	}
+	err := send(NewMessage(CMDVersion, payload.NewVersion(cfg.Magic, randomID(), cfg.UserAgent, nil)))
+	if err != nil {
+		return err
+	}
+	r := io.NewBinReaderFromIO(conn)
+	for {
+		msg := &Message{}
+		err = msg.Decode(r)
+		if r.Err != nil {
+			if errors.Is(r.Err, gio.EOF) {
+				return errors.New("connection closed by the node")
+			}
+			return r.Err
+		}
+		if err != nil {
+			if msg.Command == CMDAddr {
+				// Nodes without good peers send an empty list
+				// that can't be decoded.
+				return nil
+			}
+			// The payload is skipped anyway, so just ignore the
+			// message (it can be a block with state root in header).
+			continue
+		}
+		switch msg.Command {
+		case CMDVersion:
+			v := msg.Payload.(*payload.Version)
+			n.UserAgent = string(v.UserAgent)
+			n.Nonce = v.Nonce
+			n.Capabilities = n.Capabilities[:0]
+			for _, c := range v.Capabilities {
+				n.Capabilities = append(n.Capabilities, capabilityString(c))
+				if c.Type == capability.FullNode {
+					n.Height = c.Data.(*capability.Node).StartHeight
+				}
+			}
+			if v.Magic != cfg.Magic {
+				return fmt.Errorf("%w: %d", errInvalidNetwork, v.Magic)
+			}
+			err = send(NewMessage(CMDVerack, payload.NewNullPayload()))
+		case CMDVerack:
+			n.Reachable = true
+			err = send(NewMessage(CMDGetAddr, payload.NewNullPayload()))
+		case CMDAddr:
+			for _, a := range msg.Payload.(*payload.AddressList).Addrs {
+				addr, err := a.GetTCPAddress()
+				if err == nil {
+					n.Peers = append(n.Peers, addr)
+				}
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// capabilityString returns a human-readable capability representation.
+func capabilityString(c capability.Capability) string {
+	switch c.Type {
+	case capability.TCPServer:
+		return "TCPServer:" + strconv.Itoa(int(c.Data.(*capability.Server).Port))
+	case capability.WSServer:
+		return "WSServer:" + strconv.Itoa(int(c.Data.(*capability.Server).Port))
+	case capability.FullNode:
+		return "FullNode:" + strconv.FormatUint(uint64(c.Data.(*capability.Node).StartHeight), 10)
+	case capability.CompactBlocks:
+		return "CompactBlocks"
+	default:
+		return fmt.Sprintf("Unknown(%d)", c.Type)
+	}
+}
+
+// WriteDOT writes the network map in Graphviz DOT format. Unreachable nodes
+// are drawn dashed, edges connect nodes with the peers they've sent.
+func (m *NetMap) WriteDOT(w gio.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph netmap {\n")
+	for _, n := range m.Nodes {
+		label := []string{n.Address}
+		if n.UserAgent != "" {
+			label = append(label, n.UserAgent)
+		}
+		if n.Height != 0 {
+			label = append(label, "height "+strconv.FormatUint(uint64(n.Height), 10))
+		}
+		fmt.Fprintf(&b, "\t%q [label=%q", n.Address, strings.Join(label, "\n"))
+		if !n.Reachable {
+			b.WriteString(" style=dashed")
+		}
+		b.WriteString("];\n")
+	}
+	for _, n := range m.Nodes {
+		for _, p := range n.Peers {
+			fmt.Fprintf(&b, "\t%q -> %q;\n", n.Address, p)
+		}
+	}
+	b.WriteString("}\n")
+	_, err := gio.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,168 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/network/capability"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/stretchr/testify/require"
+)
+
+func newFakeListener(t *testing.T) net.Listener {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+	return ln
+}
+
+// serveFakeNode answers the handshake and returns the given peers on getaddr.
+func serveFakeNode(ln net.Listener, magic netmode.Magic, height uint32, peers ...string) {
+	port := ln.Addr().(*net.TCPAddr).Port
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				r := io.NewBinReaderFromIO(conn)
+				send := func(msg *Message) {
+					b, _ := msg.Bytes()
+					_, _ = conn.Write(b)
+				}
+				for {
+					msg := &Message{}
+					if msg.Decode(r) != nil {
+						return
+					}
+					switch msg.Command {
+					case CMDVersion:
+						send(NewMessage(CMDVersion, payload.NewVersion(magic, 42, "/fake/", []capability.Capability{
+							{Type: capability.TCPServer, Data: &capability.Server{Port: uint16(port)}},
+							{Type: capability.FullNode, Data: &capability.Node{StartHeight: height}},
+						})))
+						send(NewMessage(CMDVerack, payload.NewNullPayload()))
+						send(NewMessage(CMDPing, payload.NewPing(height, 1)))
+					case CMDGetAddr:
+						alist := payload.NewAddressList(len(peers))
+						for i, a := range peers {
+							tcp, _ := net.ResolveTCPAddr("tcp", a)
+							alist.Addrs[i] = payload.NewAddressAndTime(tcp, time.Now(), capability.Capabilities{
+								{Type: capability.TCPServer, Data: &capability.Server{Port: uint16(tcp.Port)}},
+							})
+						}
+						send(NewMessage(CMDAddr, alist))
+					}
+				}
+			}()
+		}
+	}()
+}
+
+func TestCrawl(t *testing.T) {
+	// Reserve an address nobody listens on.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	dead := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	var (
+		la, lb, lc, lo = newFakeListener(t), newFakeListener(t), newFakeListener(t), newFakeListener(t)
+		a, b, c, other = la.Addr().String(), lb.Addr().String(), lc.Addr().String(), lo.Addr().String()
+	)
+	serveFakeNode(la, netmode.UnitTestNet, 10, b)
+	serveFakeNode(lb, netmode.UnitTestNet, 20, a, c, dead)
+	serveFakeNode(lc, netmode.UnitTestNet, 30)
+	serveFakeNode(lo, netmode.MainNet, 40, a)
+
+	m := Crawl(context.Background(), CrawlConfig{
+		Magic:     netmode.UnitTestNet,
+		Seeds:     []string{a, other},
+		UserAgent: "/crawler/",
+		Timeout:   2 * time.Second,
+	})
+	require.Equal(t, netmode.UnitTestNet, m.Magic)
+	nodes := make(map[string]NetMapNode)
+	for i, n := range m.Nodes {
+		if i > 0 {
+			require.Less(t, m.Nodes[i-1].Address, n.Address)
+		}
+		nodes[n.Address] = n
+	}
+	require.Equal(t, 5, len(nodes))
+
+	for addr, h := range map[string]uint32{a: 10, b: 20, c: 30} {
+		n := nodes[addr]
+		require.True(t, n.Reachable, addr)
+		require.Empty(t, n.Error, addr)
+		require.Equal(t, "/fake/", n.UserAgent)
+		require.Equal(t, uint32(42), n.Nonce)
+		require.Equal(t, h, n.Height)
+		_, port, _ := net.SplitHostPort(addr)
+		require.Equal(t, []string{"TCPServer:" + port, "FullNode:" + strconv.Itoa(int(h))}, n.Capabilities)
+	}
+	require.Equal(t, []string{b}, nodes[a].Peers)
+	require.Equal(t, []string{a, c, dead}, nodes[b].Peers)
+	require.Empty(t, nodes[c].Peers)
+
+	require.False(t, nodes[dead].Reachable)
+	require.NotEmpty(t, nodes[dead].Error)
+	require.False(t, nodes[other].Reachable)
+	require.Contains(t, nodes[other].Error, errInvalidNetwork.Error())
+	require.Equal(t, uint32(40), nodes[other].Height)
+
+	t.Run("max nodes", func(t *testing.T) {
+		m := Crawl(context.Background(), CrawlConfig{
+			Magic:    netmode.UnitTestNet,
+			Seeds:    []string{a},
+			Timeout:  2 * time.Second,
+			MaxNodes: 2,
+		})
+		require.Equal(t, 2, len(m.Nodes))
+	})
+
+	t.Run("retries", func(t *testing.T) {
+		var (
+			lr       = newFakeListener(t)
+			accepted atomic.Int32
+		)
+		go func() {
+			for {
+				conn, err := lr.Accept()
+				if err != nil {
+					return
+				}
+				accepted.Add(1)
+				_ = conn.Close()
+			}
+		}()
+		m := Crawl(context.Background(), CrawlConfig{
+			Magic:   netmode.UnitTestNet,
+			Seeds:   []string{lr.Addr().String()},
+			Timeout: 2 * time.Second,
+		})
+		require.Equal(t, 1, len(m.Nodes))
+		require.False(t, m.Nodes[0].Reachable)
+		require.NotEmpty(t, m.Nodes[0].Error)
+		require.Equal(t, int32(connRetries), accepted.Load())
+	})
+
+	t.Run("dot", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		require.NoError(t, m.WriteDOT(buf))
+		out := buf.String()
+		require.Contains(t, out, "digraph netmap {\n")
+		require.Contains(t, out, strconv.Quote(a)+" -> "+strconv.Quote(b)+";\n")
+		require.Contains(t, out, strconv.Quote(dead)+" [label="+strconv.Quote(dead)+" style=dashed];\n")
+		require.Contains(t, out, strconv.Quote(c)+" [label="+strconv.Quote(c+"\n/fake/\nheight 30")+"];\n")
+	})
+}
@@ -0,0 +1,134 @@
+package payload
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// CompactBlock is a compact block relay payload. It contains the block header
+// and short transaction IDs that allow the receiver to reconstruct the block
+// using transactions from its memory pool and to request only missing ones.
+type CompactBlock struct {
+	*block.Header
+	// ShortIDs are short IDs of block transactions (see ShortTxID) that
+	// allow to find them in the memory pool. Collisions are possible, but
+	// they only lead to the full block request, because the reconstructed
+	// block is checked against the header's merkle root.
+	ShortIDs []uint64
+	// StateRootInHeader specifies whether the header contains a state root.
+	StateRootInHeader bool
+}
+
+// GetBlockTxn payload is used to request transactions missing for the
+// compact block reconstruction.
+type GetBlockTxn struct {
+	BlockHash util.Uint256
+	// Indexes are the indexes of requested transactions in the block.
+	Indexes []uint16
+}
+
+// BlockTxn payload contains transactions requested via GetBlockTxn.
+type BlockTxn struct {
+	BlockHash    util.Uint256
+	Transactions []*transaction.Transaction
+}
+
+var errInvalidTxIndexCount = errors.New("invalid transaction index count")
+
+// NewCompactBlock creates a compact block payload for the given block.
+func NewCompactBlock(b *block.Block) *CompactBlock {
+	ids := make([]uint64, len(b.Transactions))
+	for i, tx := range b.Transactions {
+		ids[i] = ShortTxID(b.Hash(), tx.Hash())
+	}
+	return &CompactBlock{
+		Header:            &b.Header,
+		ShortIDs:          ids,
+		StateRootInHeader: b.StateRootEnabled,
+	}
+}
+
+// ShortTxID returns a short ID of the transaction with the given hash for the
+// block with the given hash. Its lower 32 bits are the short hash of the
+// transaction (the same as mempool.ShortHash) that is used to find candidate
+// transactions in the memory pool, the higher 32 bits are derived from both
+// hashes, so they can't be predicted before the block is created and they
+// can't be ground to make deliberate short ID collisions.
+func ShortTxID(blockHash util.Uint256, h util.Uint256) uint64 {
+	var buf [2 * util.Uint256Size]byte
+	copy(buf[:], blockHash[:])
+	copy(buf[util.Uint256Size:], h[:])
+	sum := hash.Sha256(buf[:])
+	return uint64(binary.LittleEndian.Uint32(sum[:4]))<<32 | uint64(binary.LittleEndian.Uint32(h[:4]))
+}
+
+// DecodeBinary implements the Serializable interface.
+func (c *CompactBlock) DecodeBinary(br *io.BinReader) {
+	c.Header = &block.Header{StateRootEnabled: c.StateRootInHeader}
+	c.Header.DecodeBinary(br)
+	n := br.ReadVarUint()
+	if br.Err != nil {
+		return
+	}
+	if n > block.MaxTransactionsPerBlock {
+		br.Err = block.ErrMaxContentsPerBlock
+		return
+	}
+	c.ShortIDs = make([]uint64, n)
+	for i := range c.ShortIDs {
+		c.ShortIDs[i] = br.ReadU64LE()
+	}
+}
+
+// EncodeBinary implements the Serializable interface.
+func (c *CompactBlock) EncodeBinary(bw *io.BinWriter) {
+	c.Header.EncodeBinary(bw)
+	bw.WriteVarUint(uint64(len(c.ShortIDs)))
+	for _, id := range c.ShortIDs {
+		bw.WriteU64LE(id)
+	}
+}
+
+// DecodeBinary implements the Serializable interface.
+func (g *GetBlockTxn) DecodeBinary(br *io.BinReader) {
+	g.BlockHash.DecodeBinary(br)
+	n := br.ReadVarUint()
+	if br.Err != nil {
+		return
+	}
+	if n == 0 || n > block.MaxTransactionsPerBlock {
+		br.Err = errInvalidTxIndexCount
+		return
+	}
+	g.Indexes = make([]uint16, n)
+	for i := range g.Indexes {
+		g.Indexes[i] = br.ReadU16LE()
+	}
+}
+
+// EncodeBinary implements the Serializable interface.
+func (g *GetBlockTxn) EncodeBinary(bw *io.BinWriter) {
+	g.BlockHash.EncodeBinary(bw)
+	bw.WriteVarUint(uint64(len(g.Indexes)))
+	for _, i := range g.Indexes {
+		bw.WriteU16LE(i)
+	}
+}
+
+// DecodeBinary implements the Serializable interface.
+func (b *BlockTxn) DecodeBinary(br *io.BinReader) {
+	b.BlockHash.DecodeBinary(br)
+	br.ReadArray(&b.Transactions, block.MaxTransactionsPerBlock)
+}
+
+// EncodeBinary implements the Serializable interface.
+func (b *BlockTxn) EncodeBinary(bw *io.BinWriter) {
+	b.BlockHash.EncodeBinary(bw)
+	bw.WriteArray(b.Transactions)
+}
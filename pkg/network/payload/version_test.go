@@ -7,6 +7,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/network/capability"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestVersionEncodeDecode(t *testing.T) {
@@ -35,6 +36,14 @@ func TestVersionEncodeDecode(t *testing.T) {
 				StartHeight: height,
 			},
 		},
+		{
+			Type: capability.CompactBlocks,
+			Data: &capability.Compact{Version: 1},
+		},
+		{
+			Type: 0xf5,
+			Data: &capability.Unknown{1, 2, 3},
+		},
 	}
 
 	version := NewVersion(magic, id, useragent, capabilities)
@@ -46,3 +55,33 @@ func TestVersionEncodeDecode(t *testing.T) {
 	assert.Equal(t, versionDecoded.UserAgent, []byte(useragent))
 	assert.Equal(t, version, versionDecoded)
 }
+
+func TestVersionUnknownCapability(t *testing.T) {
+	// Compact blocks capability can be skipped by nodes not supporting it.
+	data, err := testserdes.EncodeBinary(&capability.Capability{
+		Type: capability.CompactBlocks,
+		Data: &capability.Compact{Version: 1},
+	})
+	require.NoError(t, err)
+	var u capability.Unknown
+	require.NoError(t, testserdes.DecodeBinary(data[1:], &u))
+	require.Equal(t, capability.Unknown{1}, u)
+
+	// Unknown capability doesn't prevent decoding known ones.
+	version := NewVersion(56753, 13337, "/NEO:0.0.1/", []capability.Capability{
+		{Type: 0xf5, Data: &capability.Unknown{1, 2, 3}},
+		{Type: 0xf5, Data: &capability.Unknown{}},
+		{Type: capability.FullNode, Data: &capability.Node{StartHeight: 100500}},
+	})
+	data, err = testserdes.EncodeBinary(version)
+	require.NoError(t, err)
+	versionDecoded := &Version{}
+	require.NoError(t, testserdes.DecodeBinary(data, versionDecoded))
+	require.Equal(t, version.Capabilities, versionDecoded.Capabilities)
+
+	// But only extension types are allowed to be unknown.
+	version.Capabilities[0].Type = capability.MinExtensionType - 1
+	data, err = testserdes.EncodeBinary(version)
+	require.NoError(t, err)
+	require.Error(t, testserdes.DecodeBinary(data, new(Version)))
+}
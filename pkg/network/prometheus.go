@@ -66,6 +66,36 @@ var (
 			Namespace: "neogo",
 		},
 	)
+	blockFetchTimeouts = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Help:      "Number of block requests not answered in time during headers-first sync",
+			Name:      "block_fetch_timeouts",
+			Namespace: "neogo",
+		},
+	)
+	p2pLimitedMessages = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of P2P messages dropped because of rate limits",
+			Name:      "p2p_limited_messages",
+			Namespace: "neogo",
+		},
+		[]string{"command"},
+	)
+	p2pBannedPeers = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Help:      "Number of peers banned for misbehaviour",
+			Name:      "p2p_banned_peers",
+			Namespace: "neogo",
+		},
+	)
+	compactBlocksCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of compact blocks received by reconstruction result",
+			Name:      "p2p_compact_blocks",
+			Namespace: "neogo",
+		},
+		[]string{"result"},
+	)
 	p2pCmds = make(map[CommandType]prometheus.Histogram)
 
 	// notarypoolUnsortedTx prometheus metric.
@@ -87,14 +117,18 @@ func init() {
 		serverID,
 		poolCount,
 		blockQueueLength,
+		blockFetchTimeouts,
+		p2pLimitedMessages,
+		p2pBannedPeers,
+		compactBlocksCount,
 		notarypoolUnsortedTx,
 	)
 	for _, cmd := range []CommandType{CMDVersion, CMDVerack, CMDGetAddr,
 		CMDAddr, CMDPing, CMDPong, CMDGetHeaders, CMDHeaders, CMDGetBlocks,
 		CMDMempool, CMDInv, CMDGetData, CMDGetBlockByIndex, CMDNotFound,
 		CMDTX, CMDBlock, CMDExtensible, CMDP2PNotaryRequest, CMDGetMPTData,
-		CMDMPTData, CMDReject, CMDFilterLoad, CMDFilterAdd, CMDFilterClear,
-		CMDMerkleBlock, CMDAlert} {
+		CMDMPTData, CMDCompactBlock, CMDGetBlockTxn, CMDBlockTxn, CMDReject,
+		CMDFilterLoad, CMDFilterAdd, CMDFilterClear, CMDMerkleBlock, CMDAlert} {
 		p2pCmds[cmd] = prometheus.NewHistogram(
 			prometheus.HistogramOpts{
 				Help:      "P2P " + cmd.String() + " handling time",
@@ -114,6 +148,10 @@ func updateBlockQueueLenMetric(bqLen int) {
 	blockQueueLength.Set(float64(bqLen))
 }
 
+func updateFetchTimeoutsMetric() {
+	blockFetchTimeouts.Inc()
+}
+
 func updatePoolCountMetric(pCount int) {
 	poolCount.Set(float64(pCount))
 }
@@ -144,6 +182,18 @@ func addCmdTimeMetric(cmd CommandType, t time.Duration) {
 	p2pCmds[cmd].Observe(t.Seconds())
 }
 
+func addLimitedMessageMetric(cmd CommandType) {
+	p2pLimitedMessages.WithLabelValues(strings.ToLower(strings.TrimPrefix(cmd.String(), "CMD"))).Inc()
+}
+
+func addBannedPeerMetric() {
+	p2pBannedPeers.Inc()
+}
+
+func addCompactBlockMetric(result string) {
+	compactBlocksCount.WithLabelValues(result).Inc()
+}
+
 // updateNotarypoolMetrics updates metric of the number of fallback txs inside
 // the notary request pool.
 func updateNotarypoolMetrics(unsortedTxnLen int) {
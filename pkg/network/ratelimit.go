@@ -0,0 +1,216 @@
+package network
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+)
+
+const (
+	// defaultBanScore is the default misbehaviour score after which the peer
+	// is banned.
+	defaultBanScore = 100
+	// defaultBanTime is the default ban duration.
+	defaultBanTime = 10 * time.Minute
+)
+
+// defaultRateLimits are per-peer limits used for commands unless overridden
+// by configuration.
+var defaultRateLimits = map[CommandType]config.RateLimit{
+	CMDInv:              {Rate: 100, Burst: 500},
+	CMDGetData:          {Rate: 100, Burst: 500},
+	CMDGetAddr:          {Rate: 0.1, Burst: 5},
+	CMDAddr:             {Rate: 0.1, Burst: 5},
+	CMDGetBlocks:        {Rate: 10, Burst: 50},
+	CMDGetBlockByIndex:  {Rate: 10, Burst: 50},
+	CMDGetHeaders:       {Rate: 10, Burst: 50},
+	CMDMempool:          {Rate: 0.1, Burst: 3},
+	CMDExtensible:       {Rate: 200, Burst: 1000},
+	CMDP2PNotaryRequest: {Rate: 100, Burst: 500},
+	CMDGetMPTData:       {Rate: 10, Burst: 50},
+	CMDGetBlockTxn:      {Rate: 10, Burst: 50},
+}
+
+type (
+	// rateLimiter limits the rate of messages received from peers and keeps
+	// track of misbehaving peers, banning them when their misbehaviour score
+	// exceeds the limit.
+	rateLimiter struct {
+		peerLimit config.RateLimit
+		limits    map[CommandType]config.RateLimit
+		banScore  int
+		banTime   time.Duration
+
+		lock  sync.Mutex
+		peers map[Peer]*peerLimits
+		// bans maps banned host to the ban expiration time.
+		bans map[string]time.Time
+	}
+
+	// peerLimits is a per-peer limiting state.
+	peerLimits struct {
+		all       *tokenBucket
+		buckets   map[CommandType]*tokenBucket
+		score     int
+		lastScore time.Time
+	}
+
+	// tokenBucket is a simple token bucket rate limiter.
+	tokenBucket struct {
+		rate   float64
+		burst  float64
+		tokens float64
+		last   time.Time
+	}
+)
+
+// newRateLimiter creates a rate limiter from the given configuration, it
+// returns nil if rate limiting is disabled.
+func newRateLimiter(cfg config.RateLimits) (*rateLimiter, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	r := &rateLimiter{
+		peerLimit: cfg.Peer,
+		limits:    make(map[CommandType]config.RateLimit, len(defaultRateLimits)),
+		banScore:  cfg.BanScore,
+		banTime:   cfg.BanTime,
+		peers:     make(map[Peer]*peerLimits),
+		bans:      make(map[string]time.Time),
+	}
+	if r.banScore <= 0 {
+		r.banScore = defaultBanScore
+	}
+	if r.banTime <= 0 {
+		r.banTime = defaultBanTime
+	}
+	for cmd, l := range defaultRateLimits {
+		r.limits[cmd] = l
+	}
+	for name, l := range cfg.Commands {
+		cmd, err := commandFromString(name)
+		if err != nil {
+			return nil, err
+		}
+		if l.Rate < 0 || l.Burst < 0 {
+			return nil, fmt.Errorf("invalid %s rate limit", name)
+		}
+		r.limits[cmd] = l
+	}
+	return r, nil
+}
+
+func newTokenBucket(l config.RateLimit, now time.Time) *tokenBucket {
+	if l.Rate <= 0 {
+		return nil
+	}
+	burst := float64(l.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:   l.Rate,
+		burst:  burst,
+		tokens: burst,
+		last:   now,
+	}
+}
+
+// allow takes a token from the bucket if there is any.
+func (b *tokenBucket) allow(now time.Time) bool {
+	if b == nil {
+		return true
+	}
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// allow checks whether the given command received from the peer fits into
+// limits. If it doesn't, the peer's misbehaviour score is increased and the
+// second value returned tells whether the peer should be banned.
+func (r *rateLimiter) allow(p Peer, cmd CommandType, now time.Time) (bool, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	pl := r.peers[p]
+	if pl == nil {
+		pl = &peerLimits{
+			all:     newTokenBucket(r.peerLimit, now),
+			buckets: make(map[CommandType]*tokenBucket),
+		}
+		r.peers[p] = pl
+	}
+	b, ok := pl.buckets[cmd]
+	if !ok {
+		b = newTokenBucket(r.limits[cmd], now)
+		pl.buckets[cmd] = b
+	}
+	if b.allow(now) && pl.all.allow(now) {
+		return true, false
+	}
+	return false, r.addScore(pl, now)
+}
+
+// addScore increments the peer's score, the score decreases by one every
+// second without misbehaviour. It must be called with the lock held.
+func (r *rateLimiter) addScore(pl *peerLimits, now time.Time) bool {
+	if !pl.lastScore.IsZero() {
+		pl.score -= int(now.Sub(pl.lastScore) / time.Second)
+		if pl.score < 0 {
+			pl.score = 0
+		}
+	}
+	pl.score++
+	pl.lastScore = now
+	return pl.score >= r.banScore
+}
+
+// removePeer drops the limiting state of the disconnected peer.
+func (r *rateLimiter) removePeer(p Peer) {
+	r.lock.Lock()
+	delete(r.peers, p)
+	r.lock.Unlock()
+}
+
+// ban bans the host of the given address for the configured time.
+func (r *rateLimiter) ban(addr net.Addr, now time.Time) {
+	r.lock.Lock()
+	r.bans[hostOf(addr)] = now.Add(r.banTime)
+	r.lock.Unlock()
+}
+
+// isBanned checks whether the host of the given address is banned.
+func (r *rateLimiter) isBanned(addr net.Addr, now time.Time) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	host := hostOf(addr)
+	exp, ok := r.bans[host]
+	if !ok {
+		return false
+	}
+	if now.After(exp) {
+		delete(r.bans, host)
+		return false
+	}
+	return true
+}
+
+func hostOf(addr net.Addr) string {
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+	return host
+}
@@ -0,0 +1,113 @@
+package network
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRateLimiter(t *testing.T) {
+	r, err := newRateLimiter(config.RateLimits{})
+	require.NoError(t, err)
+	require.Nil(t, r)
+
+	_, err = newRateLimiter(config.RateLimits{Enabled: true, Commands: map[string]config.RateLimit{"bad": {}}})
+	require.Error(t, err)
+
+	_, err = newRateLimiter(config.RateLimits{Enabled: true, Commands: map[string]config.RateLimit{"inv": {Rate: -1}}})
+	require.Error(t, err)
+
+	r, err = newRateLimiter(config.RateLimits{Enabled: true, Commands: map[string]config.RateLimit{"inv": {Rate: 1, Burst: 2}}})
+	require.NoError(t, err)
+	require.Equal(t, defaultBanScore, r.banScore)
+	require.Equal(t, defaultBanTime, r.banTime)
+	require.Equal(t, config.RateLimit{Rate: 1, Burst: 2}, r.limits[CMDInv])
+	require.Equal(t, defaultRateLimits[CMDGetData], r.limits[CMDGetData])
+}
+
+func TestRateLimiter(t *testing.T) {
+	r, err := newRateLimiter(config.RateLimits{
+		Enabled:  true,
+		BanScore: 3,
+		BanTime:  time.Minute,
+		Commands: map[string]config.RateLimit{
+			"inv":   {Rate: 1, Burst: 2},
+			"block": {},
+		},
+		Peer: config.RateLimit{Rate: 10, Burst: 4},
+	})
+	require.NoError(t, err)
+
+	var (
+		now = time.Now()
+		p   = &localPeer{}
+	)
+	check := func(cmd CommandType, at time.Time, allowed bool, ban bool) {
+		ok, b := r.allow(p, cmd, at)
+		require.Equal(t, allowed, ok)
+		require.Equal(t, ban, b)
+	}
+
+	check(CMDInv, now, true, false)
+	check(CMDInv, now, true, false)
+	check(CMDInv, now, false, false)
+	// Tokens are refilled with time.
+	check(CMDInv, now.Add(time.Second), true, false)
+	// Per-peer limit is also checked.
+	for i := 0; i < 3; i++ {
+		check(CMDBlock, now.Add(time.Second), true, false)
+	}
+	check(CMDBlock, now.Add(time.Second), false, false)
+	// Score decreases with time.
+	for i := 0; i < 4; i++ {
+		check(CMDBlock, now.Add(3*time.Second), true, false)
+	}
+	check(CMDBlock, now.Add(3*time.Second), false, false)
+	check(CMDBlock, now.Add(3*time.Second), false, false)
+	check(CMDBlock, now.Add(3*time.Second), false, true)
+
+	addr := &net.TCPAddr{IP: net.IPv4(1, 2, 3, 4), Port: 10333}
+	require.False(t, r.isBanned(addr, now))
+	r.ban(addr, now)
+	require.True(t, r.isBanned(&net.TCPAddr{IP: net.IPv4(1, 2, 3, 4), Port: 20333}, now))
+	require.False(t, r.isBanned(&net.TCPAddr{IP: net.IPv4(1, 2, 3, 5), Port: 10333}, now))
+	require.False(t, r.isBanned(addr, now.Add(2*time.Minute)))
+
+	// New peer state after disconnection.
+	r.removePeer(p)
+	check(CMDInv, now.Add(3*time.Second), true, false)
+}
+
+func TestServerRateLimits(t *testing.T) {
+	s := newTestServer(t, ServerConfig{
+		UserAgent: "/test/",
+		RateLimits: config.RateLimits{
+			Enabled:  true,
+			BanScore: 2,
+			Commands: map[string]config.RateLimit{"inv": {Rate: 0.001, Burst: 1}},
+		},
+	})
+	p := newLocalPeer(t, s)
+	p.handshaked = 1
+
+	var getData int
+	p.messageHandler = func(t *testing.T, msg *Message) {
+		if msg.Command == CMDGetData {
+			getData++
+		}
+	}
+	inv := NewMessage(CMDInv, payload.NewInventory(payload.TXType, []util.Uint256{{1}}))
+	require.NoError(t, s.handleMessage(p, inv))
+	require.Equal(t, 1, getData)
+	// Dropped.
+	require.NoError(t, s.handleMessage(p, inv))
+	require.Equal(t, 1, getData)
+	// Banned.
+	require.ErrorIs(t, s.handleMessage(p, inv), errBanned)
+	require.True(t, s.limiter.isBanned(p.RemoteAddr(), time.Now()))
+}
@@ -0,0 +1,264 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	gio "io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/network/bqueue"
+	"github.com/nspcc-dev/neo-go/pkg/network/capability"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"go.uber.org/zap"
+)
+
+// replayStallTimeout is the time after which replaying continues even if the
+// chain doesn't make any progress processing previously replayed blocks.
+const replayStallTimeout = 5 * time.Second
+
+// ReplayStats contains the result of capture replaying.
+type ReplayStats struct {
+	// Records is the number of incoming capture records replayed.
+	Records int
+	// Messages is the number of messages replayed.
+	Messages int
+	// Errors is the number of messages that the server failed to handle.
+	Errors int
+	// Peers is the number of distinct peers replayed.
+	Peers int
+}
+
+// replayPeer is a Peer used to replay captured messages, it's always
+// handshaked and discards everything sent to it.
+type replayPeer struct {
+	addr net.Addr
+
+	lock           sync.RWMutex
+	version        *payload.Version
+	isFullNode     bool
+	lastBlockIndex uint32
+}
+
+// replayAddr is a net.Addr of the replayed peer.
+type replayAddr string
+
+// Network implements the net.Addr interface.
+func (a replayAddr) Network() string { return "replay" }
+
+// String implements the net.Addr interface.
+func (a replayAddr) String() string { return string(a) }
+
+// Replay feeds incoming messages from the given capture to the server as if
+// they were received from the network, outgoing messages are skipped. filter
+// (if not nil) allows to select records to replay. Messages are handled in
+// the capture order and every captured peer is represented by a separate
+// always-handshaked peer that discards everything sent to it, so the server
+// is expected to be started and isolated from the real network (no seeds and
+// no listening addresses). Replay waits for all replayed blocks to be
+// processed before returning.
+func (s *Server) Replay(r *CaptureReader, filter func(*CaptureRecord) bool) (ReplayStats, error) {
+	var (
+		stats     ReplayStats
+		lastBlock uint32
+		peers     = make(map[string]*replayPeer)
+	)
+	if r.Magic != s.Net {
+		return stats, fmt.Errorf("%w: capture magic %d, node magic %d", errInvalidNetwork, r.Magic, s.Net)
+	}
+	if r.StateRootInHeader != s.config.StateRootInHeader {
+		return stats, errors.New("StateRootInHeader setting mismatch")
+	}
+	for {
+		rec, err := r.Read()
+		if err != nil {
+			if errors.Is(err, gio.EOF) {
+				break
+			}
+			return stats, err
+		}
+		if rec.Direction != CaptureIn || (filter != nil && !filter(rec)) {
+			continue
+		}
+		msgs, err := rec.Messages(r.StateRootInHeader)
+		if err != nil {
+			// Capture records contain raw data even for undecodable
+			// messages, the connection was dropped by the node anyway.
+			s.log.Debug("failed to decode captured message", zap.Error(err))
+			stats.Errors++
+			continue
+		}
+		stats.Records++
+		// Peer ID is not known until the version message is handled, so
+		// peers are distinguished by address.
+		p := peers[rec.PeerAddr]
+		if p == nil {
+			p = &replayPeer{addr: replayAddr(rec.PeerAddr)}
+			peers[rec.PeerAddr] = p
+		}
+		for _, msg := range msgs {
+			stats.Messages++
+			if b, ok := msg.Payload.(*block.Block); ok && b.Index > lastBlock {
+				lastBlock = b.Index
+			}
+			if err := s.replayMessage(p, msg); err != nil {
+				s.log.Debug("failed to handle replayed message",
+					zap.String("peer", rec.PeerAddr),
+					zap.Stringer("type", msg.Command),
+					zap.Error(err))
+				stats.Errors++
+			}
+		}
+	}
+	stats.Peers = len(peers)
+	s.waitForBlock(lastBlock)
+	return stats, nil
+}
+
+// replayMessage handles a single replayed message.
+func (s *Server) replayMessage(p *replayPeer, msg *Message) error {
+	switch msg.Command {
+	case CMDVersion:
+		return p.HandleVersion(msg.Payload.(*payload.Version))
+	case CMDVerack:
+		return nil
+	case CMDBlock:
+		// Don't let the block queue drop blocks that are too far ahead.
+		b := msg.Payload.(*block.Block)
+		if b.Index > bqueue.CacheSize {
+			s.waitForBlock(b.Index - bqueue.CacheSize)
+		}
+	}
+	return s.handleMessage(p, msg)
+}
+
+// waitForBlock waits for the chain to reach the given height, it returns
+// early if the chain doesn't make any progress for replayStallTimeout.
+func (s *Server) waitForBlock(index uint32) {
+	var (
+		height = s.chain.BlockHeight()
+		last   = time.Now()
+	)
+	for height < index {
+		time.Sleep(10 * time.Millisecond)
+		h := s.chain.BlockHeight()
+		if h != height {
+			height = h
+			last = time.Now()
+		} else if time.Since(last) > replayStallTimeout {
+			return
+		}
+	}
+}
+
+// ConnectionAddr implements the Peer interface.
+func (p *replayPeer) ConnectionAddr() string { return p.addr.String() }
+
+// RemoteAddr implements the Peer interface.
+func (p *replayPeer) RemoteAddr() net.Addr { return p.addr }
+
+// PeerAddr implements the Peer interface.
+func (p *replayPeer) PeerAddr() net.Addr { return p.addr }
+
+// Version implements the Peer interface.
+func (p *replayPeer) Version() *payload.Version {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.version
+}
+
+// LastBlockIndex implements the Peer interface.
+func (p *replayPeer) LastBlockIndex() uint32 {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.lastBlockIndex
+}
+
+// Disconnect implements the Peer interface, it's a no-op.
+func (p *replayPeer) Disconnect(error) {}
+
+// BroadcastPacket implements the Peer interface, it discards the packet.
+func (p *replayPeer) BroadcastPacket(context.Context, []byte) error { return nil }
+
+// BroadcastHPPacket implements the Peer interface, it discards the packet.
+func (p *replayPeer) BroadcastHPPacket(context.Context, []byte) error { return nil }
+
+// EnqueueP2PMessage implements the Peer interface, it discards the message.
+func (p *replayPeer) EnqueueP2PMessage(*Message) error { return nil }
+
+// EnqueueP2PPacket implements the Peer interface, it discards the packet.
+func (p *replayPeer) EnqueueP2PPacket([]byte) error { return nil }
+
+// EnqueueHPMessage implements the Peer interface, it discards the message.
+func (p *replayPeer) EnqueueHPMessage(*Message) error { return nil }
+
+// EnqueueHPPacket implements the Peer interface, it discards the packet.
+func (p *replayPeer) EnqueueHPPacket([]byte) error { return nil }
+
+// Handshaked implements the Peer interface, replayed peers are always
+// handshaked.
+func (p *replayPeer) Handshaked() bool { return true }
+
+// IsFullNode implements the Peer interface.
+func (p *replayPeer) IsFullNode() bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.isFullNode
+}
+
+// SetPingTimer implements the Peer interface, it's a no-op.
+func (p *replayPeer) SetPingTimer() {}
+
+// SendVersion implements the Peer interface, it's a no-op.
+func (p *replayPeer) SendVersion() error { return nil }
+
+// SendVersionAck implements the Peer interface, it's a no-op.
+func (p *replayPeer) SendVersionAck(*Message) error { return nil }
+
+// StartProtocol implements the Peer interface, it's a no-op.
+func (p *replayPeer) StartProtocol() {}
+
+// HandleVersion implements the Peer interface.
+func (p *replayPeer) HandleVersion(v *payload.Version) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.version = v
+	for _, cap := range v.Capabilities {
+		if cap.Type == capability.FullNode {
+			p.isFullNode = true
+			p.lastBlockIndex = cap.Data.(*capability.Node).StartHeight
+		}
+	}
+	return nil
+}
+
+// HandleVersionAck implements the Peer interface, it's a no-op.
+func (p *replayPeer) HandleVersionAck() error { return nil }
+
+// HandlePing implements the Peer interface.
+func (p *replayPeer) HandlePing(ping *payload.Ping) error {
+	p.setLastBlockIndex(ping.LastBlockIndex)
+	return nil
+}
+
+// HandlePong implements the Peer interface.
+func (p *replayPeer) HandlePong(pong *payload.Ping) error {
+	p.setLastBlockIndex(pong.LastBlockIndex)
+	return nil
+}
+
+func (p *replayPeer) setLastBlockIndex(index uint32) {
+	p.lock.Lock()
+	p.lastBlockIndex = index
+	p.lock.Unlock()
+}
+
+// AddGetAddrSent implements the Peer interface, it's a no-op.
+func (p *replayPeer) AddGetAddrSent() {}
+
+// CanProcessAddr implements the Peer interface, addresses are never
+// processed during replay.
+func (p *replayPeer) CanProcessAddr() bool { return false }
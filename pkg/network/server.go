@@ -9,6 +9,7 @@ import (
 	"math/big"
 	mrand "math/rand"
 	"net"
+	"os"
 	"runtime"
 	"sort"
 	"strconv"
@@ -51,6 +52,8 @@ var (
 	errServerShutdown      = errors.New("server shutdown")
 	errInvalidInvType      = errors.New("invalid inventory type")
 	errBlocksRequestFailed = errors.New("blocks request failed")
+	errHeaderMismatch      = errors.New("block doesn't match the known header")
+	errBanned              = errors.New("peer is banned")
 )
 
 type (
@@ -103,6 +106,11 @@ type (
 		chain             Ledger
 		bQueue            *bqueue.Queue
 		bSyncQueue        *bqueue.Queue
+		fetcher           *blockFetcher
+		limiter           *rateLimiter
+		compact           *compactBlocks
+		captureW          *CaptureWriter
+		captureFailed     atomic.Bool
 		mempool           *mempool.Pool
 		notaryRequestPool *mempool.Pool
 		extensiblePool    *extpool.Pool
@@ -219,6 +227,29 @@ func newServerFromConstructors(config ServerConfig, chain Ledger, stSync StateSy
 	}, updateBlockQueueLenMetric)
 
 	s.bSyncQueue = bqueue.New(s.stateSync, log, nil, updateBlockQueueLenMetric)
+	if s.HeadersFirstSync {
+		s.fetcher = newBlockFetcher(s.TimePerBlock)
+	}
+	if s.CompactBlocks {
+		s.compact = newCompactBlocks()
+	}
+	limiter, err := newRateLimiter(s.RateLimits)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rate limits: %w", err)
+	}
+	s.limiter = limiter
+
+	if s.CaptureFile != "" {
+		f, err := os.OpenFile(s.CaptureFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open capture file: %w", err)
+		}
+		s.captureW, err = NewCaptureWriter(f, s.Net, s.config.StateRootInHeader)
+		if err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("failed to write capture header: %w", err)
+		}
+	}
 
 	if s.MinPeers < 0 {
 		s.log.Info("bad MinPeers configured, using the default value",
@@ -333,6 +364,12 @@ func (s *Server) Shutdown() {
 	<-s.relayFin
 	<-s.runFin
 	s.txHandlerLoopWG.Wait()
+	if s.captureW != nil {
+		err := s.captureW.Close()
+		if err != nil {
+			s.log.Warn("failed to close P2P capture", zap.Error(err))
+		}
+	}
 
 	_ = s.log.Sync()
 }
@@ -375,6 +412,14 @@ func (s *Server) AddConsensusService(svc Service, handler func(*payload.Extensib
 	s.addExtensibleService(svc, payload.ConsensusCategory, handler)
 }
 
+// GetService returns a service registered with the given name or nil if
+// there is none.
+func (s *Server) GetService(name string) Service {
+	s.serviceLock.RLock()
+	defer s.serviceLock.RUnlock()
+	return s.services[name]
+}
+
 // DelService drops a service from the list, use it when the service is stopped
 // outside of the Server.
 func (s *Server) DelService(svc Service) {
@@ -494,6 +539,10 @@ func (s *Server) run() {
 			s.lock.Lock()
 			s.peers[p] = true
 			s.lock.Unlock()
+			if s.limiter != nil && s.limiter.isBanned(p.RemoteAddr(), time.Now()) {
+				// It will send us unregister signal.
+				go p.Disconnect(errBanned)
+			}
 			peerCount := s.PeerCount()
 			s.log.Info("new peer connected", zap.Stringer("addr", p.RemoteAddr()), zap.Int("peerCount", peerCount))
 			if peerCount > s.MaxPeers {
@@ -513,7 +562,16 @@ func (s *Server) run() {
 			if s.peers[drop.peer] {
 				delete(s.peers, drop.peer)
 				s.lock.Unlock()
-				if errors.Is(drop.reason, errInvalidInvType) || errors.Is(drop.reason, errStateMismatch) || errors.Is(drop.reason, errBlocksRequestFailed) {
+				if s.fetcher != nil {
+					s.fetcher.removePeer(drop.peer)
+				}
+				if s.limiter != nil {
+					s.limiter.removePeer(drop.peer)
+				}
+				if s.compact != nil {
+					s.compact.removePeer(drop.peer)
+				}
+				if errors.Is(drop.reason, errInvalidInvType) || errors.Is(drop.reason, errStateMismatch) || errors.Is(drop.reason, errBlocksRequestFailed) || errors.Is(drop.reason, errBanned) {
 					s.log.Warn("peer disconnected",
 						zap.Stringer("addr", drop.peer.RemoteAddr()),
 						zap.Error(drop.reason),
@@ -678,6 +736,12 @@ func (s *Server) getVersionMsg(localAddr net.Addr) (*Message, error) {
 			},
 		})
 	}
+	if s.CompactBlocks && s.AnnounceCompactBlocks {
+		capabilities = append(capabilities, capability.Capability{
+			Type: capability.CompactBlocks,
+			Data: &capability.Compact{},
+		})
+	}
 	payload := payload.NewVersion(
 		s.Net,
 		s.id,
@@ -765,9 +829,29 @@ func (s *Server) handleBlockCmd(p Peer, block *block.Block) error {
 	if s.stateSync.IsActive() {
 		return s.bSyncQueue.PutBlock(block)
 	}
+	if s.fetcher != nil {
+		return s.handleFetchedBlock(p, block)
+	}
 	return s.bQueue.PutBlock(block)
 }
 
+// handleFetchedBlock checks the block received in headers-first sync mode
+// against the known header chain and enqueues it. It requests the next window
+// of blocks from the peer as soon as the previous one is completed.
+func (s *Server) handleFetchedBlock(p Peer, b *block.Block) error {
+	if b.Index <= s.chain.HeaderHeight() && !s.chain.GetHeaderHash(b.Index).Equals(b.Hash()) {
+		return fmt.Errorf("%w: block %d, hash %s", errHeaderMismatch, b.Index, b.Hash().StringLE())
+	}
+	err := s.bQueue.PutBlock(b)
+	if err != nil {
+		return err
+	}
+	if s.fetcher.blockReceived(p, b.Index, time.Now()) {
+		return s.requestHeadersFirst(p)
+	}
+	return nil
+}
+
 // handlePing processes a ping request.
 func (s *Server) handlePing(p Peer, ping *payload.Ping) error {
 	err := p.HandlePing(ping)
@@ -788,6 +872,9 @@ func (s *Server) requestBlocksOrHeaders(p Peer) error {
 		}
 		return nil
 	}
+	if s.fetcher != nil && !s.stateSync.IsActive() {
+		return s.requestHeadersFirst(p)
+	}
 	var (
 		bq              bqueue.Blockqueuer = s.chain
 		requestMPTNodes bool
@@ -815,6 +902,45 @@ func (s *Server) requestHeaders(p Peer) error {
 	return p.EnqueueP2PMessage(NewMessage(CMDGetHeaders, pl))
 }
 
+// requestHeadersFirst implements headers-first synchronization. Headers are
+// requested sequentially (from a single peer at a time), while blocks with
+// known headers are requested in windows distributed between all peers.
+func (s *Server) requestHeadersFirst(p Peer) error {
+	var (
+		now        = time.Now()
+		peerHeight = p.LastBlockIndex()
+		hdrHeight  = s.chain.HeaderHeight()
+		height     = s.chain.BlockHeight()
+	)
+	if hdrHeight < peerHeight && s.fetcher.requestHeaders(hdrHeight+1, now) {
+		err := p.EnqueueP2PMessage(NewMessage(CMDGetHeaders, payload.NewGetBlockByIndex(hdrHeight+1, -1)))
+		if err != nil {
+			return err
+		}
+	}
+	// Blocks can only be requested if we know their headers, the peer has
+	// them and they fit into the block queue.
+	var limit = hdrHeight
+	if peerHeight < limit {
+		limit = peerHeight
+	}
+	if height+bqueue.CacheSize < limit {
+		limit = height + bqueue.CacheSize
+	}
+	if height >= limit {
+		return nil
+	}
+	r, ok := s.fetcher.nextWindow(p, height, limit, now)
+	if !ok {
+		return nil
+	}
+	err := p.EnqueueP2PMessage(NewMessage(CMDGetBlockByIndex, payload.NewGetBlockByIndex(r.start, int16(r.count))))
+	if err != nil {
+		return fmt.Errorf("%w: %w", errBlocksRequestFailed, err)
+	}
+	return nil
+}
+
 // handlePing processes a pong request.
 func (s *Server) handlePong(p Peer, pong *payload.Ping) error {
 	err := p.HandlePong(pong)
@@ -1100,9 +1226,27 @@ func (s *Server) handleGetHeadersCmd(p Peer, gh *payload.GetBlockByIndex) error
 
 // handleHeadersCmd processes headers payload.
 func (s *Server) handleHeadersCmd(p Peer, h *payload.Headers) error {
+	if s.fetcher != nil && !s.stateSync.IsActive() {
+		return s.handleFetchedHeaders(p, h)
+	}
 	return s.stateSync.AddHeaders(h.Hdrs...)
 }
 
+// handleFetchedHeaders adds headers received in headers-first sync mode to the
+// chain (verifying them) and continues synchronization with the peer.
+func (s *Server) handleFetchedHeaders(p Peer, h *payload.Headers) error {
+	if len(h.Hdrs) == 0 || h.Hdrs[0].Index > s.chain.HeaderHeight()+1 {
+		// Stale answer to some previous request, headers will be requested again.
+		return nil
+	}
+	err := s.chain.AddHeaders(h.Hdrs...)
+	if err != nil {
+		return err
+	}
+	s.fetcher.headersReceived()
+	return s.requestHeadersFirst(p)
+}
+
 // handleExtensibleCmd processes the received extensible payload.
 func (s *Server) handleExtensibleCmd(e *payload.Extensible) error {
 	if !s.syncReached.Load() {
@@ -1351,6 +1495,18 @@ func (s *Server) handleMessage(peer Peer, msg *Message) error {
 	defer func() { addCmdTimeMetric(msg.Command, time.Since(start)) }()
 
 	if peer.Handshaked() {
+		if s.limiter != nil {
+			now := time.Now()
+			if ok, ban := s.limiter.allow(peer, msg.Command, now); !ok {
+				addLimitedMessageMetric(msg.Command)
+				if ban {
+					s.limiter.ban(peer.RemoteAddr(), now)
+					addBannedPeerMetric()
+					return fmt.Errorf("%w: too many %s messages", errBanned, msg.Command.String())
+				}
+				return nil
+			}
+		}
 		if inv, ok := msg.Payload.(*payload.Inventory); ok {
 			if !inv.Type.Valid(s.chain.P2PSigExtensionsEnabled()) || len(inv.Hashes) == 0 {
 				return fmt.Errorf("%w: %s", errInvalidInvType, inv.Type.String())
@@ -1393,6 +1549,15 @@ func (s *Server) handleMessage(peer Peer, msg *Message) error {
 		case CMDBlock:
 			block := msg.Payload.(*block.Block)
 			return s.handleBlockCmd(peer, block)
+		case CMDCompactBlock:
+			cb := msg.Payload.(*payload.CompactBlock)
+			return s.handleCompactBlockCmd(peer, cb)
+		case CMDGetBlockTxn:
+			g := msg.Payload.(*payload.GetBlockTxn)
+			return s.handleGetBlockTxnCmd(peer, g)
+		case CMDBlockTxn:
+			bt := msg.Payload.(*payload.BlockTxn)
+			return s.handleBlockTxnCmd(peer, bt)
 		case CMDExtensible:
 			cp := msg.Payload.(*payload.Extensible)
 			return s.handleExtensibleCmd(cp)
@@ -1599,12 +1764,7 @@ mainloop:
 			s.chain.UnsubscribeFromBlocks(ch)
 			break mainloop
 		case b := <-ch:
-			msg := NewMessage(CMDInv, payload.NewInventory(payload.BlockType, []util.Uint256{b.Hash()}))
-			// Filter out nodes that are more current (avoid spamming the network
-			// during initial sync).
-			s.iteratePeersWithSendMsg(msg, Peer.BroadcastPacket, func(p Peer) bool {
-				return p.Handshaked() && p.LastBlockIndex() < b.Index
-			})
+			s.relayBlock(b)
 			s.extensiblePool.RemoveStale(b.Index)
 		}
 	}
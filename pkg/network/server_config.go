@@ -76,6 +76,26 @@ type (
 
 		// BroadcastFactor is the factor (0-100) for fan-out optimization.
 		BroadcastFactor int
+
+		// HeadersFirstSync enables headers-first synchronization mode where
+		// the header chain is fetched first and then block bodies are
+		// downloaded in parallel from multiple peers.
+		HeadersFirstSync bool
+
+		// RateLimits is per-peer message rate limiting configuration.
+		RateLimits config.RateLimits
+
+		// CaptureFile is the file to record all P2P messages to, capturing
+		// is disabled if it's empty.
+		CaptureFile string
+
+		// CompactBlocks enables compact block relay with peers supporting it.
+		CompactBlocks bool
+
+		// AnnounceCompactBlocks enables compact block relay capability
+		// advertisement, nodes that don't support unknown capabilities
+		// can't connect to this node if it's enabled.
+		AnnounceCompactBlocks bool
 	}
 )
 
@@ -107,6 +127,12 @@ func NewServerConfig(cfg config.Config) (ServerConfig, error) {
 		StateRootCfg:       appConfig.StateRoot,
 		ExtensiblePoolSize: appConfig.P2P.ExtensiblePoolSize,
 		BroadcastFactor:    appConfig.P2P.BroadcastFactor,
+		HeadersFirstSync:   appConfig.P2P.HeadersFirstSync,
+		RateLimits:         appConfig.P2P.RateLimits,
+		CaptureFile:        appConfig.P2P.CaptureFile,
+		CompactBlocks:      appConfig.P2P.CompactBlocks,
+
+		AnnounceCompactBlocks: appConfig.P2P.AnnounceCompactBlocks,
 	}
 	return c, nil
 }
@@ -265,6 +265,69 @@ func testGetBlocksByIndex(t *testing.T, cmd CommandType) {
 	checkPingRespond(t, 3, 5000, 2124, 2624, 3124, 3624)
 }
 
+func TestHeadersFirstSync(t *testing.T) {
+	s := newTestServer(t, ServerConfig{UserAgent: "/test/", HeadersFirstSync: true, TimePerBlock: time.Second})
+	chain := s.chain.(*fakechain.FakeChain)
+
+	blocks := make([]*block.Block, 301)
+	for i := range blocks {
+		blocks[i] = newDummyBlock(uint32(i), 0)
+	}
+	chain.PutBlock(blocks[0])
+
+	newPeer := func() (*localPeer, *[]*Message) {
+		var msgs []*Message
+		p := newLocalPeer(t, s)
+		p.handshaked = 1
+		p.lastBlockIndex = 1000
+		p.messageHandler = func(t *testing.T, msg *Message) {
+			msgs = append(msgs, msg)
+		}
+		return p, &msgs
+	}
+	checkRequest := func(t *testing.T, msg *Message, cmd CommandType, start uint32, count int16) {
+		require.Equal(t, cmd, msg.Command)
+		require.Equal(t, payload.NewGetBlockByIndex(start, count), msg.Payload)
+	}
+	p1, msgs1 := newPeer()
+	p2, msgs2 := newPeer()
+
+	// No headers yet, so headers are requested, but not blocks.
+	require.NoError(t, s.requestBlocksOrHeaders(p1))
+	require.Equal(t, 1, len(*msgs1))
+	checkRequest(t, (*msgs1)[0], CMDGetHeaders, 1, -1)
+
+	// The same headers are not requested from the other peer.
+	require.NoError(t, s.requestBlocksOrHeaders(p2))
+	require.Equal(t, 0, len(*msgs2))
+
+	// Headers received, next headers and blocks are requested.
+	hdrs := &payload.Headers{}
+	for _, b := range blocks[1:] {
+		hdrs.Hdrs = append(hdrs.Hdrs, &b.Header)
+	}
+	s.testHandleMessage(t, p1, CMDHeaders, hdrs)
+	require.Equal(t, uint32(300), chain.HeaderHeight())
+	require.Equal(t, 3, len(*msgs1))
+	checkRequest(t, (*msgs1)[1], CMDGetHeaders, 301, -1)
+	checkRequest(t, (*msgs1)[2], CMDGetBlockByIndex, 1, defaultFetchWindow)
+
+	// Blocks are requested from the other peer in parallel.
+	require.NoError(t, s.requestBlocksOrHeaders(p2))
+	require.Equal(t, 1, len(*msgs2))
+	checkRequest(t, (*msgs2)[0], CMDGetBlockByIndex, 1+defaultFetchWindow, defaultFetchWindow)
+
+	// Block that doesn't match the header.
+	require.ErrorIs(t, s.handleMessage(p2, NewMessage(CMDBlock, newDummyBlock(1+defaultFetchWindow, 0))), errHeaderMismatch)
+
+	// Window completed, next one is requested immediately.
+	for _, b := range blocks[1 : 1+defaultFetchWindow] {
+		s.testHandleMessage(t, p1, CMDBlock, b)
+	}
+	require.Equal(t, 4, len(*msgs1))
+	checkRequest(t, (*msgs1)[3], CMDGetBlockByIndex, 1+2*defaultFetchWindow, 2*defaultFetchWindow)
+}
+
 func TestSendVersion(t *testing.T) {
 	var (
 		s = newTestServer(t, ServerConfig{UserAgent: "/test/"})
@@ -0,0 +1,255 @@
+package network
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/internal/fakechain"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// Sync harness parameters. All timings are scaled down 50 times compared to a
+// real network (5s ProtoTickInterval, ~1s to transfer 500 blocks from a good
+// peer) to keep tests fast.
+const (
+	harnessTick     = 100 * time.Millisecond
+	harnessRTT      = 2 * time.Millisecond
+	harnessPerBlock = 40 * time.Microsecond
+	// Headers are much smaller than blocks.
+	harnessPerHeader = harnessPerBlock / 10
+)
+
+// harnessPeerSpeeds are per-block transfer time multipliers for harness
+// peers, real networks always have some slower peers.
+var harnessPeerSpeeds = []time.Duration{1, 1, 2, 8}
+
+// syncChain is a FakeChain that can be used concurrently by the block queue
+// and peer handlers.
+type syncChain struct {
+	*fakechain.FakeChain
+	lock sync.RWMutex
+}
+
+func (c *syncChain) AddBlock(b *block.Block) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.FakeChain.AddBlock(b)
+}
+
+func (c *syncChain) AddHeaders(hdrs ...*block.Header) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.FakeChain.AddHeaders(hdrs...)
+}
+
+func (c *syncChain) GetHeaderHash(i uint32) util.Uint256 {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.FakeChain.GetHeaderHash(i)
+}
+
+// harnessPeer is a remote node serving headers and blocks from the given
+// chain with simulated latency and bandwidth. Requests are served one by one
+// like they're by a real node over a single connection.
+type harnessPeer struct {
+	*localPeer
+	blocks   []*block.Block
+	perBlock time.Duration
+	requests chan *Message
+	served   *servedBlocks
+}
+
+// servedBlocks is a set of block indexes sent by harness peers.
+type servedBlocks struct {
+	lock    sync.Mutex
+	indexes map[uint32]bool
+}
+
+func (s *servedBlocks) add(i uint32) {
+	s.lock.Lock()
+	s.indexes[i] = true
+	s.lock.Unlock()
+}
+
+func newHarnessPeer(t testing.TB, s *Server, blocks []*block.Block, speed time.Duration, served *servedBlocks) *harnessPeer {
+	p := &harnessPeer{
+		localPeer: newLocalPeer(nil, s),
+		blocks:    blocks,
+		perBlock:  harnessPerBlock * speed,
+		requests:  make(chan *Message, 1024),
+		served:    served,
+	}
+	p.handshaked = 1
+	p.lastBlockIndex = uint32(len(blocks) - 1)
+	p.messageHandler = func(_ *testing.T, msg *Message) {
+		p.requests <- msg
+	}
+	return p
+}
+
+// serve answers requests until done is closed. Answers are handled by the
+// server in this goroutine just like they're handled by a peer reader.
+func (p *harnessPeer) serve(t testing.TB, done <-chan struct{}) {
+	for {
+		var msg *Message
+		select {
+		case <-done:
+			return
+		case msg = <-p.requests:
+		}
+		var (
+			req      = msg.Payload.(*payload.GetBlockByIndex)
+			deadline = time.Now().Add(harnessRTT)
+		)
+		switch msg.Command {
+		case CMDGetHeaders:
+			var hdrs = new(payload.Headers)
+			for i := req.IndexStart; i < uint32(len(p.blocks)) && len(hdrs.Hdrs) < payload.MaxHeadersAllowed; i++ {
+				hdrs.Hdrs = append(hdrs.Hdrs, &p.blocks[i].Header)
+			}
+			time.Sleep(time.Until(deadline.Add(time.Duration(len(hdrs.Hdrs)) * harnessPerHeader)))
+			if len(hdrs.Hdrs) != 0 && !p.handle(t, done, NewMessage(CMDHeaders, hdrs)) {
+				return
+			}
+		case CMDGetBlockByIndex:
+			var count = uint32(req.Count)
+			if req.Count < 0 {
+				count = payload.MaxHashesCount
+			}
+			for i := req.IndexStart; i < req.IndexStart+count && i < uint32(len(p.blocks)); i++ {
+				deadline = deadline.Add(p.perBlock)
+				time.Sleep(time.Until(deadline))
+				p.served.add(i)
+				if !p.handle(t, done, NewMessage(CMDBlock, p.blocks[i])) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (p *harnessPeer) handle(t testing.TB, done <-chan struct{}, msg *Message) bool {
+	select {
+	case <-done:
+		return false
+	default:
+	}
+	err := p.server.handleMessage(p, msg)
+	if err != nil {
+		t.Errorf("failed to handle %s: %s", msg.Command, err)
+		return false
+	}
+	return true
+}
+
+// syncWithHarness synchronizes a new node with several peers having n blocks
+// and returns the time it took, the resulting chain and the set of blocks
+// sent by peers.
+func syncWithHarness(t testing.TB, n int, headersFirst bool) (time.Duration, *syncChain, map[uint32]bool) {
+	var (
+		chain = &syncChain{FakeChain: fakechain.NewFakeChain()}
+		cfg   = ServerConfig{
+			UserAgent:        "/test/",
+			HeadersFirstSync: headersFirst,
+			TimePerBlock:     time.Second,
+			Addresses:        []config.AnnounceableAddress{{Address: ":0"}},
+		}
+	)
+	s, err := newServerFromConstructors(cfg, chain, new(fakechain.FakeStateSync), zap.NewNop(),
+		newFakeTransp, newTestDiscovery)
+	require.NoError(t, err)
+
+	blocks := make([]*block.Block, n+1)
+	for i := range blocks {
+		blocks[i] = newDummyBlock(uint32(i), 0)
+	}
+	chain.PutBlock(blocks[0])
+
+	var (
+		done   = make(chan struct{})
+		wg     sync.WaitGroup
+		peers  = make([]*harnessPeer, len(harnessPeerSpeeds))
+		served = &servedBlocks{indexes: make(map[uint32]bool)}
+	)
+	for i, speed := range harnessPeerSpeeds {
+		peers[i] = newHarnessPeer(t, s, blocks, speed, served)
+	}
+	go s.bQueue.Run()
+	defer s.bQueue.Discard()
+
+	start := time.Now()
+	for _, p := range peers {
+		wg.Add(2)
+		go func(p *harnessPeer) {
+			defer wg.Done()
+			p.serve(t, done)
+		}(p)
+		// Simulate TCPPeer.StartProtocol.
+		go func(p *harnessPeer) {
+			defer wg.Done()
+			var ticker = time.NewTicker(harnessTick)
+			defer ticker.Stop()
+			for {
+				if err := s.requestBlocksOrHeaders(p); err != nil {
+					t.Errorf("failed to request blocks: %s", err)
+					return
+				}
+				select {
+				case <-done:
+					return
+				case <-ticker.C:
+				}
+			}
+		}(p)
+	}
+	for chain.BlockHeight() < uint32(n) {
+		time.Sleep(time.Millisecond)
+	}
+	took := time.Since(start)
+	close(done)
+	wg.Wait()
+	return took, chain, served.indexes
+}
+
+// TestSyncWithHarness checks that both regular block queue based and
+// headers-first synchronization get all blocks from the same set of peers.
+func TestSyncWithHarness(t *testing.T) {
+	const n = 1000
+	for _, headersFirst := range []bool{false, true} {
+		_, chain, served := syncWithHarness(t, n, headersFirst)
+		require.Equal(t, uint32(n), chain.BlockHeight())
+		for i := uint32(1); i <= n; i++ {
+			require.True(t, served[i], "block %d wasn't requested (headers-first: %t)", i, headersFirst)
+		}
+		require.False(t, served[0])
+	}
+}
+
+// BenchmarkSync compares regular block queue based synchronization with
+// headers-first one using the same set of peers, headers-first is expected
+// to be faster.
+func BenchmarkSync(b *testing.B) {
+	const n = 10000
+	for _, tc := range []struct {
+		name         string
+		headersFirst bool
+	}{
+		{"sequential", false},
+		{"headers-first", true},
+	} {
+		b.Run(tc.name, func(b *testing.B) {
+			var total time.Duration
+			for i := 0; i < b.N; i++ {
+				took, _, _ := syncWithHarness(b, n, tc.headersFirst)
+				total += took
+			}
+			b.ReportMetric(float64(n*b.N)/total.Seconds(), "blocks/s")
+		})
+	}
+}
@@ -147,6 +147,9 @@ func (p *TCPPeer) writeMsg(msg *Message) error {
 	}
 
 	_, err = p.conn.Write(b)
+	if err == nil {
+		p.server.capture(p, CaptureOut, b)
+	}
 
 	return err
 }
@@ -163,11 +166,23 @@ func (p *TCPPeer) handleConn() {
 	// When a new peer is connected, we send out our version immediately.
 	err = p.SendVersion()
 	if err == nil {
-		r := io.NewBinReaderFromIO(p.conn)
+		var (
+			r   *io.BinReader
+			buf *captureBuffer
+		)
+		if p.server.captureW != nil {
+			buf = &captureBuffer{r: p.conn}
+			r = io.NewBinReaderFromIO(buf)
+		} else {
+			r = io.NewBinReaderFromIO(p.conn)
+		}
 	loop:
 		for {
 			msg := &Message{StateRootInHeader: p.server.config.StateRootInHeader}
 			err = msg.Decode(r)
+			if buf != nil {
+				p.server.capture(p, CaptureIn, buf.take())
+			}
 
 			if errors.Is(err, payload.ErrTooManyHeaders) {
 				p.server.log.Warn("not all headers were processed")
@@ -252,6 +267,7 @@ func (p *TCPPeer) handleQueues() {
 		if err != nil {
 			break
 		}
+		p.server.capture(p, CaptureOut, msg)
 		p2pSkipCounter++
 	}
 	p.Disconnect(err)
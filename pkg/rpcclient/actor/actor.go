@@ -97,6 +97,10 @@ type Options struct {
 	// before it's signed (other methods that perform test invocations
 	// use CheckerModifier). MakeUnsigned* methods do not run it.
 	Modifier TransactionModifier
+	// FeeStrategy is used by all methods creating transactions (including
+	// MakeUnsigned* ones) to adjust the network fee. If it's nil, the
+	// minimal fee required is used.
+	FeeStrategy FeeStrategy
 }
 
 // New creates an Actor instance using the specified RPC interface and the set of
@@ -174,6 +178,7 @@ func NewTuned(ra RPCActor, signers []SignerAccount, opts Options) (*Actor, error
 	if opts.Modifier != nil {
 		a.opts.Modifier = opts.Modifier
 	}
+	a.opts.FeeStrategy = opts.FeeStrategy
 	return a, err
 }
 
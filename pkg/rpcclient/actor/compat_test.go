@@ -11,3 +11,8 @@ func TestRPCActorRPCClientCompat(t *testing.T) {
 	_ = actor.RPCActor(&rpcclient.WSClient{})
 	_ = actor.RPCActor(&rpcclient.Client{})
 }
+
+func TestFeeEstimatorRPCClientCompat(t *testing.T) {
+	_ = actor.FeeEstimator(&rpcclient.WSClient{})
+	_ = actor.FeeEstimator(&rpcclient.Client{})
+}
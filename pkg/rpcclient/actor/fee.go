@@ -0,0 +1,98 @@
+package actor
+
+import (
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/fee"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+)
+
+// FeeStrategy is a callback that returns the desired fee-per-byte value (network
+// fee divided by transaction size, the value used to order transactions in the
+// memory pool) for new transactions. It's used by Actor if set in Options, the
+// network fee of every transaction created is increased to match this value if
+// the minimal fee required is not enough for it. Notice that it's called for
+// every transaction created.
+type FeeStrategy func() (int64, error)
+
+// FeeEstimator is an RPC client that can estimate fees, rpcclient.Client and
+// rpcclient.WSClient implement it.
+type FeeEstimator interface {
+	GetFeeEstimate(blocks int) (*result.FeeEstimate, error)
+}
+
+// FeePriority is a transaction priority level used by EstimatedFeeStrategy.
+type FeePriority byte
+
+// Fee priorities, see result.FeeEstimate for details.
+const (
+	LowFeePriority FeePriority = iota
+	MediumFeePriority
+	HighFeePriority
+)
+
+// EstimatedFeeStrategy returns a FeeStrategy that uses getfeeestimate RPC call
+// (which is a NeoGo extension) to get the fee-per-byte value for the given
+// priority.
+func EstimatedFeeStrategy(e FeeEstimator, p FeePriority) FeeStrategy {
+	return func() (int64, error) {
+		est, err := e.GetFeeEstimate(0)
+		if err != nil {
+			return 0, fmt.Errorf("failed to estimate fee: %w", err)
+		}
+		switch p {
+		case LowFeePriority:
+			return est.Low, nil
+		case MediumFeePriority:
+			return est.Medium, nil
+		case HighFeePriority:
+			return est.High, nil
+		default:
+			return 0, fmt.Errorf("unknown fee priority %d", p)
+		}
+	}
+}
+
+// applyFeeStrategy increases the network fee of the given transaction (with the
+// minimal network fee already calculated) if needed.
+func (a *Actor) applyFeeStrategy(tx *transaction.Transaction) error {
+	if a.opts.FeeStrategy == nil {
+		return nil
+	}
+	feePerByte, err := a.opts.FeeStrategy()
+	if err != nil {
+		return err
+	}
+	size, err := estimateSignedSize(tx)
+	if err != nil {
+		return err
+	}
+	if netFee := feePerByte * int64(size); netFee > tx.NetworkFee {
+		tx.NetworkFee = netFee
+	}
+	return nil
+}
+
+// estimateSignedSize returns the size of the transaction after it's signed.
+// Standard signature and multisignature witnesses are expected to be
+// unfilled, other witnesses are counted as is. It doesn't call Size or Hash,
+// so it's safe wrt transaction internal caching.
+func estimateSignedSize(tx *transaction.Transaction) (int, error) {
+	hashable, err := tx.EncodeHashableFields()
+	if err != nil {
+		return 0, fmt.Errorf("failed to compute tx size: %w", err)
+	}
+	size := len(hashable) + io.GetVarSize(len(tx.Scripts))
+	for _, w := range tx.Scripts {
+		if len(w.InvocationScript) == 0 {
+			if _, sz := fee.Calculate(0, w.VerificationScript); sz != 0 {
+				size += sz
+				continue
+			}
+		}
+		size += io.GetVarSize(w.InvocationScript) + io.GetVarSize(w.VerificationScript)
+	}
+	return size, nil
+}
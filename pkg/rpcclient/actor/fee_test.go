@@ -0,0 +1,75 @@
+package actor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/stretchr/testify/require"
+)
+
+type testEstimator struct {
+	est *result.FeeEstimate
+	err error
+}
+
+func (e *testEstimator) GetFeeEstimate(blocks int) (*result.FeeEstimate, error) {
+	return e.est, e.err
+}
+
+func TestEstimatedFeeStrategy(t *testing.T) {
+	e := &testEstimator{est: &result.FeeEstimate{Low: 1, Medium: 2, High: 3}}
+	for p, exp := range map[FeePriority]int64{
+		LowFeePriority:    1,
+		MediumFeePriority: 2,
+		HighFeePriority:   3,
+	} {
+		fpb, err := EstimatedFeeStrategy(e, p)()
+		require.NoError(t, err)
+		require.Equal(t, exp, fpb)
+	}
+	_, err := EstimatedFeeStrategy(e, 42)()
+	require.Error(t, err)
+
+	e.err = errors.New("")
+	_, err = EstimatedFeeStrategy(e, LowFeePriority)()
+	require.Error(t, err)
+}
+
+func TestFeeStrategy(t *testing.T) {
+	client, acc := testRPCAndAccount(t)
+	client.netFee = 1000
+	script := []byte{1, 2, 3}
+
+	var (
+		fpb    int64
+		fpbErr error
+	)
+	simple, err := NewSimple(client, acc)
+	require.NoError(t, err)
+	a, err := NewTuned(client, simple.signers, Options{
+		FeeStrategy: func() (int64, error) { return fpb, fpbErr },
+	})
+	require.NoError(t, err)
+
+	// Minimal fee is enough.
+	tx, err := a.MakeUncheckedRun(script, 0, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), tx.NetworkFee)
+
+	// Fee is raised to match the strategy, signed transaction size is
+	// predicted precisely.
+	fpb = 100
+	tx, err = a.MakeUncheckedRun(script, 0, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, fpb*int64(tx.Size()), tx.NetworkFee)
+
+	// Unsigned transactions are affected too.
+	tx, err = a.MakeUnsignedUncheckedRun(script, 0, nil)
+	require.NoError(t, err)
+	require.Less(t, int64(1000), tx.NetworkFee)
+
+	fpbErr = errors.New("")
+	_, err = a.MakeUncheckedRun(script, 0, nil, nil)
+	require.Error(t, err)
+}
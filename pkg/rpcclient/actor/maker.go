@@ -207,6 +207,10 @@ func (a *Actor) MakeUnsignedUncheckedRun(script []byte, sysFee int64, attrs []tr
 	if err != nil {
 		return nil, fmt.Errorf("calculating network fee: %w", err)
 	}
+	err = a.applyFeeStrategy(tx)
+	if err != nil {
+		return nil, fmt.Errorf("applying fee strategy: %w", err)
+	}
 
 	return tx, nil
 }
@@ -0,0 +1,414 @@
+package rpcclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/invoker"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// Batch is a set of calls sent to the server as a single JSON-RPC batch
+// request. Calls are added with Batch methods (that mirror the respective
+// Client methods) or AddBatchCall, each of them returns a BatchCall that
+// holds the call result after Send. Batch is not thread-safe and can only be
+// sent once.
+//
+// HTTP client sends all calls in a single HTTP request, WSClient (that can't
+// send batches) sends calls concurrently without waiting for responses to
+// previous ones.
+type Batch struct {
+	c     *Client
+	calls []batchCall
+	sent  bool
+}
+
+// batchCall is an untyped part of BatchCall.
+type batchCall struct {
+	req *neorpc.Request
+	// set processes the response, raw is nil if err is not nil.
+	set func(raw json.RawMessage, err error)
+}
+
+// BatchCall is a single call of the Batch, its result is available after
+// Batch.Send.
+type BatchCall[T any] struct {
+	res  T
+	err  error
+	done bool
+}
+
+// errBatchNotSent is returned from BatchCall.Result if the batch is not sent yet.
+var errBatchNotSent = errors.New("batch is not sent")
+
+// NewBatch creates a new empty Batch for the client.
+func (c *Client) NewBatch() *Batch {
+	return &Batch{c: c}
+}
+
+// Len returns the number of calls in the batch.
+func (b *Batch) Len() int {
+	return len(b.calls)
+}
+
+// Result returns the call result (or an error if the call has failed).
+func (bc *BatchCall[T]) Result() (T, error) {
+	if !bc.done {
+		var res T
+		return res, errBatchNotSent
+	}
+	return bc.res, bc.err
+}
+
+// AddBatchCall adds a call of the given method with the given parameters to
+// the batch, the result is decoded from JSON into T. It can be used for
+// methods that don't have a specific Batch wrapper.
+func AddBatchCall[T any](b *Batch, method string, params []any) *BatchCall[T] {
+	return addCall(b, method, params, func(raw json.RawMessage) (T, error) {
+		var res T
+		err := json.Unmarshal(raw, &res)
+		return res, err
+	})
+}
+
+// addCall adds a call to the batch using the given decoder for its result.
+func addCall[T any](b *Batch, method string, params []any, decode func(json.RawMessage) (T, error)) *BatchCall[T] {
+	var bc = new(BatchCall[T])
+	if params == nil {
+		params = []any{} // neo-project/neo-modules#742
+	}
+	b.calls = append(b.calls, batchCall{
+		req: &neorpc.Request{
+			JSONRPC: neorpc.JSONRPCVersion,
+			Method:  method,
+			Params:  params,
+		},
+		set: func(raw json.RawMessage, err error) {
+			if err == nil {
+				bc.res, err = decode(raw)
+			}
+			bc.err = err
+			bc.done = true
+		},
+	})
+	return bc
+}
+
+// failedCall returns a BatchCall that is not sent to the server and always
+// returns the given error.
+func failedCall[T any](err error) *BatchCall[T] {
+	return &BatchCall[T]{err: err, done: true}
+}
+
+// Send sends all calls to the server. It returns an error if the batch can't
+// be sent or the response can't be received, errors of particular calls are
+// returned from their Result methods. If the client has middlewares, calls
+// are passed through them one by one and all errors are returned from Result
+// methods.
+func (b *Batch) Send() error {
+	if b.sent {
+		return errors.New("batch is already sent")
+	}
+	b.sent = true
+	if len(b.calls) == 0 {
+		return nil
+	}
+	var reqs = make([]*neorpc.Request, len(b.calls))
+	for i := range b.calls {
+		b.calls[i].req.ID = b.c.getNextRequestID()
+		reqs[i] = b.calls[i].req
+	}
+
+	if b.c.batchF == nil {
+		b.sendConcurrently(reqs, func(int) func(*neorpc.Request) (*neorpc.Response, error) {
+			return b.c.requestF
+		})
+		return nil
+	}
+	if len(b.c.opts.Middlewares) != 0 {
+		b.sendWithMiddlewares(reqs)
+		return nil
+	}
+	resps, err := b.c.batchF(reqs)
+	if err != nil {
+		for i := range b.calls {
+			b.calls[i].set(nil, err)
+		}
+		return err
+	}
+	var byID = make(map[string]*neorpc.Response, len(resps))
+	for _, r := range resps {
+		if r != nil {
+			byID[string(r.ID)] = r
+		}
+	}
+	for i := range b.calls {
+		var call = b.calls[i]
+		raw, ok := byID[strconv.FormatUint(call.req.ID, 10)]
+		if !ok {
+			call.set(nil, errors.New("no response returned"))
+			continue
+		}
+		call.setResponse(raw, nil)
+	}
+	return nil
+}
+
+// sendConcurrently sends requests one by one without waiting for responses
+// using the function returned by getF for every request, it's used for
+// transports that don't support batches.
+func (b *Batch) sendConcurrently(reqs []*neorpc.Request, getF func(i int) func(*neorpc.Request) (*neorpc.Response, error)) {
+	var wg sync.WaitGroup
+	for i := range reqs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			b.calls[i].setResponse(getF(i)(reqs[i]))
+		}(i)
+	}
+	wg.Wait()
+}
+
+// sendWithMiddlewares passes requests through the client middlewares
+// concurrently, requests that reach the transport are collected and sent as
+// a single batch once all requests have either reached it or have been
+// completed by middlewares. Repeated attempts to send the same request (made
+// by retrying middlewares) are sent as separate requests.
+func (b *Batch) sendWithMiddlewares(reqs []*neorpc.Request) {
+	var (
+		lock    sync.Mutex
+		pending = len(reqs)
+		joined  []*neorpc.Request
+		byID    = make(map[string]*neorpc.Response)
+		sendErr error
+		sent    = make(chan struct{})
+	)
+	// done marks the request as processed (r is nil if it hasn't reached
+	// the transport), the batch is sent after the last one.
+	done := func(r *neorpc.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		if r != nil {
+			joined = append(joined, r)
+		}
+		pending--
+		if pending != 0 {
+			return
+		}
+		if len(joined) != 0 {
+			var resps []*neorpc.Response
+			resps, sendErr = b.c.batchF(joined)
+			for _, r := range resps {
+				if r != nil {
+					byID[string(r.ID)] = r
+				}
+			}
+		}
+		close(sent)
+	}
+	b.sendConcurrently(reqs, func(int) func(*neorpc.Request) (*neorpc.Response, error) {
+		var (
+			batched bool
+			f       = chainMiddlewares(b.c.ctx, func(r *neorpc.Request) (*neorpc.Response, error) {
+				if batched {
+					return b.c.makeHTTPRequest(r)
+				}
+				batched = true
+				done(r)
+				<-sent
+				if sendErr != nil {
+					return nil, sendErr
+				}
+				resp, ok := byID[strconv.FormatUint(r.ID, 10)]
+				if !ok {
+					return nil, errors.New("no response returned")
+				}
+				return resp, nil
+			}, b.c.opts.Middlewares)
+		)
+		return func(r *neorpc.Request) (*neorpc.Response, error) {
+			resp, err := f(r)
+			if !batched {
+				done(nil)
+			}
+			return resp, err
+		}
+	})
+}
+
+// setResponse processes the response the same way Client.performRequest does.
+func (call batchCall) setResponse(raw *neorpc.Response, err error) {
+	switch {
+	case raw != nil && raw.Error != nil:
+		call.set(nil, raw.Error)
+	case err != nil:
+		call.set(nil, err)
+	case raw == nil || raw.Result == nil:
+		call.set(nil, errors.New("no result returned"))
+	default:
+		call.set(raw.Result, nil)
+	}
+}
+
+// GetApplicationLog adds getapplicationlog call to the batch, see
+// Client.GetApplicationLog.
+func (b *Batch) GetApplicationLog(hash util.Uint256, trig *trigger.Type) *BatchCall[*result.ApplicationLog] {
+	var params = []any{hash.StringLE()}
+	if trig != nil {
+		params = append(params, trig.String())
+	}
+	return AddBatchCall[*result.ApplicationLog](b, "getapplicationlog", params)
+}
+
+// GetBestBlockHash adds getbestblockhash call to the batch, see
+// Client.GetBestBlockHash.
+func (b *Batch) GetBestBlockHash() *BatchCall[util.Uint256] {
+	return AddBatchCall[util.Uint256](b, "getbestblockhash", nil)
+}
+
+// GetBlockCount adds getblockcount call to the batch, see Client.GetBlockCount.
+func (b *Batch) GetBlockCount() *BatchCall[uint32] {
+	return AddBatchCall[uint32](b, "getblockcount", nil)
+}
+
+// GetBlockByIndex adds getblock call to the batch, see Client.GetBlockByIndex.
+func (b *Batch) GetBlockByIndex(index uint32) *BatchCall[*block.Block] {
+	return b.getBlock(index)
+}
+
+// GetBlockByHash adds getblock call to the batch, see Client.GetBlockByHash.
+func (b *Batch) GetBlockByHash(hash util.Uint256) *BatchCall[*block.Block] {
+	return b.getBlock(hash.StringLE())
+}
+
+func (b *Batch) getBlock(param any) *BatchCall[*block.Block] {
+	return addCall(b, "getblock", []any{param}, func(raw json.RawMessage) (*block.Block, error) {
+		var data []byte
+		if err := json.Unmarshal(raw, &data); err != nil {
+			return nil, err
+		}
+		sr, err := b.c.stateRootInHeader()
+		if err != nil {
+			return nil, err
+		}
+		r := io.NewBinReaderFromBuf(data)
+		blk := block.New(sr)
+		blk.DecodeBinary(r)
+		if r.Err != nil {
+			return nil, r.Err
+		}
+		return blk, nil
+	})
+}
+
+// GetBlockHash adds getblockhash call to the batch, see Client.GetBlockHash.
+func (b *Batch) GetBlockHash(index uint32) *BatchCall[util.Uint256] {
+	return AddBatchCall[util.Uint256](b, "getblockhash", []any{index})
+}
+
+// GetContractStateByHash adds getcontractstate call to the batch, see
+// Client.GetContractStateByHash.
+func (b *Batch) GetContractStateByHash(hash util.Uint160) *BatchCall[*state.Contract] {
+	return AddBatchCall[*state.Contract](b, "getcontractstate", []any{hash.StringLE()})
+}
+
+// GetNEP11Balances adds getnep11balances call to the batch, see
+// Client.GetNEP11Balances.
+func (b *Batch) GetNEP11Balances(address util.Uint160) *BatchCall[*result.NEP11Balances] {
+	return AddBatchCall[*result.NEP11Balances](b, "getnep11balances", []any{address.StringLE()})
+}
+
+// GetNEP17Balances adds getnep17balances call to the batch, see
+// Client.GetNEP17Balances.
+func (b *Batch) GetNEP17Balances(address util.Uint160) *BatchCall[*result.NEP17Balances] {
+	return AddBatchCall[*result.NEP17Balances](b, "getnep17balances", []any{address.StringLE()})
+}
+
+// GetRawTransaction adds getrawtransaction call to the batch, see
+// Client.GetRawTransaction.
+func (b *Batch) GetRawTransaction(hash util.Uint256) *BatchCall[*transaction.Transaction] {
+	return addCall(b, "getrawtransaction", []any{hash.StringLE()}, func(raw json.RawMessage) (*transaction.Transaction, error) {
+		var data []byte
+		if err := json.Unmarshal(raw, &data); err != nil {
+			return nil, err
+		}
+		return transaction.NewTransactionFromBytes(data)
+	})
+}
+
+// GetTransactionHeight adds gettransactionheight call to the batch, see
+// Client.GetTransactionHeight.
+func (b *Batch) GetTransactionHeight(hash util.Uint256) *BatchCall[uint32] {
+	return AddBatchCall[uint32](b, "gettransactionheight", []any{hash.StringLE()})
+}
+
+// InvokeFunction adds invokefunction call to the batch, see
+// Client.InvokeFunction.
+func (b *Batch) InvokeFunction(contract util.Uint160, operation string, params []smartcontract.Parameter, signers []transaction.Signer) *BatchCall[*result.Invoke] {
+	return b.invoke("invokefunction", []any{contract.StringLE(), operation, params}, signers, nil)
+}
+
+// InvokeFunctionAtHeight adds invokefunctionhistoric call to the batch, see
+// Client.InvokeFunctionAtHeight.
+func (b *Batch) InvokeFunctionAtHeight(height uint32, contract util.Uint160, operation string, params []smartcontract.Parameter, signers []transaction.Signer) *BatchCall[*result.Invoke] {
+	return b.invoke("invokefunctionhistoric", []any{height, contract.StringLE(), operation, params}, signers, nil)
+}
+
+// InvokeScript adds invokescript call to the batch, see Client.InvokeScript.
+func (b *Batch) InvokeScript(script []byte, signers []transaction.Signer) *BatchCall[*result.Invoke] {
+	return b.invoke("invokescript", []any{script}, signers, nil)
+}
+
+// InvokeScriptAtHeight adds invokescripthistoric call to the batch, see
+// Client.InvokeScriptAtHeight.
+func (b *Batch) InvokeScriptAtHeight(height uint32, script []byte, signers []transaction.Signer) *BatchCall[*result.Invoke] {
+	return b.invoke("invokescripthistoric", []any{height, script}, signers, nil)
+}
+
+func (b *Batch) invoke(method string, p []any, signers []transaction.Signer, witnesses []transaction.Witness) *BatchCall[*result.Invoke] {
+	p, err := invokeParams(p, signers, witnesses)
+	if err != nil {
+		return failedCall[*result.Invoke](fmt.Errorf("%s: %w", method, err))
+	}
+	return AddBatchCall[*result.Invoke](b, method, p)
+}
+
+// InvokeFunctions performs a number of invokefunction calls with a single
+// batch request, see Client.InvokeFunction. It implements
+// invoker.RPCInvokeBatch interface.
+func (c *Client) InvokeFunctions(calls []invoker.FunctionCall, signers []transaction.Signer) ([]*result.Invoke, error) {
+	var (
+		b  = c.NewBatch()
+		bc = make([]*BatchCall[*result.Invoke], len(calls))
+	)
+	for i := range calls {
+		params := calls[i].Params
+		if params == nil {
+			params = []smartcontract.Parameter{}
+		}
+		bc[i] = b.InvokeFunction(calls[i].Contract, calls[i].Operation, params, signers)
+	}
+	if err := b.Send(); err != nil {
+		return nil, err
+	}
+	var res = make([]*result.Invoke, len(calls))
+	for i := range bc {
+		r, err := bc[i].Result()
+		if err != nil {
+			return nil, fmt.Errorf("call %d (%s): %w", i, calls[i].Operation, err)
+		}
+		res[i] = r
+	}
+	return res, nil
+}
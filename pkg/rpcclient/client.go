@@ -36,6 +36,9 @@ type Client struct {
 	ctxCancel func()
 	opts      Options
 	requestF  func(*neorpc.Request) (*neorpc.Response, error)
+	// batchF sends a set of requests at once, it's nil if the transport
+	// doesn't support batches.
+	batchF func([]*neorpc.Request) ([]*neorpc.Response, error)
 
 	// reader is an Invoker that has no signers and uses current state,
 	// it's used to implement various getters. It'll be removed eventually,
@@ -68,6 +71,11 @@ type Options struct {
 	RequestTimeout time.Duration
 	// Limit total number of connections per host. No limit by default.
 	MaxConnsPerHost int
+	// Middlewares are applied to every request sent by the client (the
+	// first one is the outermost), see Middleware. Requests of HTTP batches
+	// (see Batch) are passed through middlewares one by one and those that
+	// reach the transport are then sent as a single batch.
+	Middlewares []Middleware
 }
 
 // cache stores cache values for the RPC client methods.
@@ -129,7 +137,8 @@ func initClient(ctx context.Context, cl *Client, endpoint string, opts Options)
 	cl.latestReqID = atomic.Uint64{}
 	cl.getNextRequestID = (cl).getRequestID
 	cl.opts = opts
-	cl.requestF = cl.makeHTTPRequest
+	cl.requestF = chainMiddlewares(cl.ctx, cl.makeHTTPRequest, opts.Middlewares)
+	cl.batchF = cl.makeHTTPBatchRequest
 	cl.reader = invoker.New(cl, nil)
 	return nil
 }
@@ -230,6 +239,52 @@ func (c *Client) makeHTTPRequest(r *neorpc.Request) (*neorpc.Response, error) {
 	return raw, nil
 }
 
+func (c *Client) makeHTTPBatchRequest(reqs []*neorpc.Request) ([]*neorpc.Response, error) {
+	var (
+		buf = new(bytes.Buffer)
+		raw json.RawMessage
+	)
+
+	if err := json.NewEncoder(buf).Encode(reqs); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", c.endpoint.String(), buf)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.cli.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	err = json.NewDecoder(resp.Body).Decode(&raw)
+	if err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("HTTP %d/%s", resp.StatusCode, http.StatusText(resp.StatusCode))
+		}
+		return nil, fmt.Errorf("JSON decoding: %w", err)
+	}
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || raw[0] != '[' {
+		// The whole batch can be rejected with a single error response.
+		var single neorpc.Response
+		if err := json.Unmarshal(raw, &single); err != nil {
+			return nil, fmt.Errorf("JSON decoding: %w", err)
+		}
+		if single.Error != nil {
+			return nil, single.Error
+		}
+		return nil, errors.New("single response returned for batch request")
+	}
+	var res []*neorpc.Response
+	if err := json.Unmarshal(raw, &res); err != nil {
+		return nil, fmt.Errorf("JSON decoding: %w", err)
+	}
+	return res, nil
+}
+
 // Ping attempts to create a connection to the endpoint
 // and returns an error if there is any.
 func (c *Client) Ping() error {
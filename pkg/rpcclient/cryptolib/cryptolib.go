@@ -0,0 +1,163 @@
+/*
+Package cryptolib allows to work with the native CryptoLib contract via RPC.
+
+CryptoLib only has safe methods, they're all encapsulated into ContractReader
+structure. Method names follow the ones used by the interop/native/crypto
+package for contracts.
+
+BLS12-381 methods of the contract operate on points that are opaque interop
+interfaces and can't be passed to or returned from an RPC call, so this package
+works with serialized points only. Each Bls12381* method deserializes the
+points given, performs the operation and serializes the result (if needed)
+within a single script invocation.
+*/
+package cryptolib
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativehashes"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/unwrap"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+)
+
+// Invoker is used by ContractReader to call various methods.
+type Invoker interface {
+	Call(contract util.Uint160, operation string, params ...any) (*result.Invoke, error)
+	Run(script []byte) (*result.Invoke, error)
+}
+
+// Hash stores the hash of the native CryptoLib contract.
+var Hash = nativehashes.CryptoLib
+
+// NamedCurveHash represents a pair of named elliptic curve and hash function.
+type NamedCurveHash byte
+
+// Various pairs of named elliptic curves and hash functions.
+const (
+	Secp256k1Sha256    NamedCurveHash = 22
+	Secp256r1Sha256    NamedCurveHash = 23
+	Secp256k1Keccak256 NamedCurveHash = 122
+	Secp256r1Keccak256 NamedCurveHash = 123
+)
+
+// ContractReader provides an interface to call read-only CryptoLib contract's
+// methods.
+type ContractReader struct {
+	invoker Invoker
+}
+
+// NewReader creates an instance of ContractReader that can be used to read
+// data from the contract.
+func NewReader(invoker Invoker) *ContractReader {
+	return &ContractReader{invoker}
+}
+
+// Sha256 computes SHA256 hash of the given data.
+func (c *ContractReader) Sha256(data []byte) (util.Uint256, error) {
+	return unwrap.Uint256(c.invoker.Call(Hash, "sha256", data))
+}
+
+// Ripemd160 computes RIPEMD160 hash of the given data.
+func (c *ContractReader) Ripemd160(data []byte) (util.Uint160, error) {
+	return unwrap.Uint160(c.invoker.Call(Hash, "ripemd160", data))
+}
+
+// Keccak256 computes Keccak256 hash of the given data.
+func (c *ContractReader) Keccak256(data []byte) (util.Uint256, error) {
+	return unwrap.Uint256(c.invoker.Call(Hash, "keccak256", data))
+}
+
+// Murmur32 computes Murmur32 hash of the given data using the given seed.
+func (c *ContractReader) Murmur32(data []byte, seed uint32) (uint32, error) {
+	b, err := unwrap.Bytes(c.invoker.Call(Hash, "murmur32", data, seed))
+	if err != nil {
+		return 0, err
+	}
+	if len(b) != 4 {
+		return 0, fmt.Errorf("invalid hash length: %d", len(b))
+	}
+	return binary.LittleEndian.Uint32(b), nil
+}
+
+// VerifyWithECDsa checks that sig is a correct signature of msg for the given
+// public key using the given curve and hash function.
+func (c *ContractReader) VerifyWithECDsa(msg []byte, pub *keys.PublicKey, sig []byte, curveHash NamedCurveHash) (bool, error) {
+	if pub == nil {
+		return false, errors.New("nil public key")
+	}
+	return unwrap.Bool(c.invoker.Call(Hash, "verifyWithECDsa", msg, pub.Bytes(), sig, int64(curveHash)))
+}
+
+// Bls12381Equal checks whether two serialized BLS12-381 points are equal.
+func (c *ContractReader) Bls12381Equal(x, y []byte) (bool, error) {
+	script, err := blsScript("bls12381Equal", false, [][]byte{x, y})
+	if err != nil {
+		return false, err
+	}
+	return unwrap.Bool(c.invoker.Run(script))
+}
+
+// Bls12381Add adds two serialized BLS12-381 points and returns the serialized
+// result.
+func (c *ContractReader) Bls12381Add(x, y []byte) ([]byte, error) {
+	script, err := blsScript("bls12381Add", true, [][]byte{x, y})
+	if err != nil {
+		return nil, err
+	}
+	return unwrap.Bytes(c.invoker.Run(script))
+}
+
+// Bls12381Mul multiplies the serialized BLS12-381 point by the given scalar
+// (32-byte little-endian) and returns the serialized result. If neg is true,
+// the scalar is negated.
+func (c *ContractReader) Bls12381Mul(x []byte, mul []byte, neg bool) ([]byte, error) {
+	script, err := blsScript("bls12381Mul", true, [][]byte{x}, mul, neg)
+	if err != nil {
+		return nil, err
+	}
+	return unwrap.Bytes(c.invoker.Run(script))
+}
+
+// Bls12381Pairing computes the pairing of the serialized G1 and G2 points and
+// returns the serialized GT point.
+func (c *ContractReader) Bls12381Pairing(g1, g2 []byte) ([]byte, error) {
+	script, err := blsScript("bls12381Pairing", true, [][]byte{g1, g2})
+	if err != nil {
+		return nil, err
+	}
+	return unwrap.Bytes(c.invoker.Run(script))
+}
+
+// blsScript creates a script calling the given BLS12-381 method with the points
+// deserialized and other arguments following them. If serialize is true, the
+// result is serialized.
+func blsScript(method string, serialize bool, points [][]byte, args ...any) ([]byte, error) {
+	w := io.NewBufBinWriter()
+	for i := len(args) - 1; i >= 0; i-- {
+		emit.Any(w.BinWriter, args[i])
+	}
+	for i := len(points) - 1; i >= 0; i-- {
+		emit.AppCall(w.BinWriter, Hash, "bls12381Deserialize", callflag.NoneFlag, points[i])
+	}
+	emit.Int(w.BinWriter, int64(len(points)+len(args)))
+	emit.Opcodes(w.BinWriter, opcode.PACK)
+	emit.AppCallNoArgs(w.BinWriter, Hash, method, callflag.NoneFlag)
+	if serialize {
+		emit.Int(w.BinWriter, 1)
+		emit.Opcodes(w.BinWriter, opcode.PACK)
+		emit.AppCallNoArgs(w.BinWriter, Hash, "bls12381Serialize", callflag.NoneFlag)
+	}
+	if w.Err != nil {
+		return nil, w.Err
+	}
+	return w.Bytes(), nil
+}
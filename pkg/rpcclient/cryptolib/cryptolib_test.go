@@ -0,0 +1,116 @@
+package cryptolib
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+type testInv struct {
+	err    error
+	res    *result.Invoke
+	method string
+	params []any
+	script []byte
+}
+
+func (t *testInv) Call(contract util.Uint160, operation string, params ...any) (*result.Invoke, error) {
+	t.method, t.params = operation, params
+	return t.res, t.err
+}
+
+func (t *testInv) Run(script []byte) (*result.Invoke, error) {
+	t.script = script
+	return t.res, t.err
+}
+
+func TestReader(t *testing.T) {
+	ti := new(testInv)
+	cl := NewReader(ti)
+	pk, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+
+	ti.err = errors.New("")
+	_, err = cl.Sha256([]byte{1})
+	require.Error(t, err)
+	_, err = cl.Murmur32([]byte{1}, 0)
+	require.Error(t, err)
+	_, err = cl.VerifyWithECDsa([]byte{1}, pk.PublicKey(), []byte{2}, Secp256r1Sha256)
+	require.Error(t, err)
+	_, err = cl.Bls12381Equal([]byte{1}, []byte{2})
+	require.Error(t, err)
+	_, err = cl.Bls12381Add([]byte{1}, []byte{2})
+	require.Error(t, err)
+
+	ti.err = nil
+	h := hash.Sha256([]byte{1})
+	ti.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{stackitem.Make(h.BytesBE())},
+	}
+	res, err := cl.Sha256([]byte{1})
+	require.NoError(t, err)
+	require.Equal(t, h, res)
+	_, err = cl.Keccak256([]byte{1})
+	require.NoError(t, err)
+	require.Equal(t, "keccak256", ti.method)
+	_, err = cl.Ripemd160([]byte{1})
+	require.Error(t, err)
+	_, err = cl.Murmur32([]byte{1}, 0)
+	require.Error(t, err)
+
+	ti.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{stackitem.Make(util.Uint160{1, 2, 3}.BytesBE())},
+	}
+	u, err := cl.Ripemd160([]byte{1})
+	require.NoError(t, err)
+	require.Equal(t, util.Uint160{1, 2, 3}, u)
+
+	ti.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{stackitem.Make([]byte{1, 2, 3, 4})},
+	}
+	m, err := cl.Murmur32([]byte{1}, 42)
+	require.NoError(t, err)
+	require.Equal(t, uint32(0x04030201), m)
+	require.Equal(t, []any{[]byte{1}, uint32(42)}, ti.params)
+
+	ti.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{stackitem.Make(true)},
+	}
+	ok, err := cl.VerifyWithECDsa([]byte{1}, pk.PublicKey(), []byte{2}, Secp256k1Keccak256)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []any{[]byte{1}, pk.PublicKey().Bytes(), []byte{2}, int64(122)}, ti.params)
+	_, err = cl.VerifyWithECDsa([]byte{1}, nil, []byte{2}, Secp256k1Keccak256)
+	require.Error(t, err)
+	ok, err = cl.Bls12381Equal([]byte{1}, []byte{2})
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ti.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{stackitem.Make([]byte{1, 2, 3})},
+	}
+	for _, f := range []func() ([]byte, error){
+		func() ([]byte, error) { return cl.Bls12381Add([]byte{1}, []byte{2}) },
+		func() ([]byte, error) { return cl.Bls12381Mul([]byte{1}, []byte{2}, true) },
+		func() ([]byte, error) { return cl.Bls12381Pairing([]byte{1}, []byte{2}) },
+	} {
+		ti.script = nil
+		b, err := f()
+		require.NoError(t, err)
+		require.Equal(t, []byte{1, 2, 3}, b)
+		require.NotNil(t, ti.script)
+		require.NoError(t, vm.IsScriptCorrect(ti.script, nil))
+	}
+}
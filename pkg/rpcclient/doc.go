@@ -33,9 +33,10 @@ These layers are:
     transactions.
 
   - Contract-specific wrappers for native contracts that include management, gas,
-    neo, oracle, policy and rolemgmt packages for the respective native contracts.
-    Complete contract functionality is exposed (reusing nep17 package for gas and
-    neo).
+    neo, oracle, policy, rolemgmt, ledger, stdlib and cryptolib packages for the
+    respective native contracts. Complete contract functionality is exposed
+    (reusing nep17 package for gas and neo), except for methods that can only be
+    used by contracts.
 
   - Notary actor and contract, a bit special since it's a NeoGo protocol
     extension, but notary package provides both the notary native contract wrapper
@@ -47,11 +48,38 @@ These layers are:
     example of how contract-specific wrappers can be built for other dApps
     (reusing invoker/actor layers it's pretty easy).
 
+  - State root light client provided by lightclient package, it allows to get
+    contract storage data from untrusted RPC nodes verifying state root
+    signatures and MPT proofs for it.
+
+  - Multi-node client provided by pool package, it distributes calls between
+    several RPC nodes and can be used instead of a regular client for invoker
+    and actor.
+
+  - Transaction lifecycle tracker provided by tracker package, it builds on
+    actor and waiter to follow transactions until they're accepted or expire,
+    re-broadcasting them and replacing stuck ones with higher network fee.
+
 # Client
 
 After creating a client instance with or without a ClientConfig
 you can interact with the NEO blockchain by its exposed methods.
 
+Several calls can be sent to the server at once with a Batch (see
+Client.NewBatch), HTTP client sends them as a single JSON-RPC batch request
+which saves a lot of round trips when many blocks or logs are to be fetched.
+Client also implements invoker.RPCInvokeBatch, so invoker.Invoker.CallBatch
+(used by nep17.TokenReader.BalancesOf, for example) sends all calls at once.
+
+Requests can be processed by a chain of Middleware functions (see
+Options.Middlewares) that are called before sending the request and after
+getting the response, both for Client and WSClient. Every call of a Batch is
+passed through middlewares as well, but calls that reach the transport are
+still sent as a single HTTP batch. This package provides middlewares for
+retries (that never repeat non-idempotent requests like sendrawtransaction),
+rate limiting, logging and tracing, but custom ones can be used as well. All
+of them stop waiting when the client is closed.
+
 Supported methods
 
 	calculatenetworkfee
@@ -96,6 +124,9 @@ Supported methods
 Extensions:
 
 	getblocksysfee
+	getfeeestimate
+	getnotaryrequest
+	getnotaryrequests
 	getrawnotarypool
 	getrawnotarytransaction
 	submitnotaryrequest
@@ -6,6 +6,11 @@ test function invocations and script runs. It also makes historic calls (NeoGo
 extension) transparent, allowing to use the same API as for regular calls.
 Results of these calls can be interpreted by upper layer packages like actor
 (to create transactions) or unwrap (to retrieve data from return values).
+
+Iterator type provides a lazy way to walk through the values returned by
+contract methods returning iterators. It handles sessions, iterator expansion
+and session expiration transparently, so the same code works with any RPC
+server.
 */
 package invoker
 
@@ -44,6 +49,21 @@ type RPCInvoke interface {
 	InvokeScript(script []byte, signers []transaction.Signer) (*result.Invoke, error)
 }
 
+// RPCInvokeBatch is an optional extension of RPCInvoke that allows to
+// perform a number of InvokeFunction calls with a single request to the
+// server. If the client implements it, Invoker.CallBatch uses it.
+type RPCInvokeBatch interface {
+	InvokeFunctions(calls []FunctionCall, signers []transaction.Signer) ([]*result.Invoke, error)
+}
+
+// FunctionCall is a single contract method call of the batch, see
+// Invoker.CallBatch.
+type FunctionCall struct {
+	Contract  util.Uint160
+	Operation string
+	Params    []smartcontract.Parameter
+}
+
 // RPCInvokeHistoric is a set of RPC methods needed to execute things at some
 // fixed point in blockchain's life.
 type RPCInvokeHistoric interface {
@@ -147,6 +167,26 @@ func (v *Invoker) Call(contract util.Uint160, operation string, params ...any) (
 	return v.client.InvokeFunction(contract, operation, ps, v.signers)
 }
 
+// CallBatch invokes a number of contract methods (with Invoker-specific list of
+// signers) and returns their results in the same order. If the RPC client
+// implements RPCInvokeBatch all calls are performed with a single request,
+// otherwise they're done one by one. Historic invokers always perform calls
+// one by one.
+func (v *Invoker) CallBatch(calls []FunctionCall) ([]*result.Invoke, error) {
+	if bc, ok := v.client.(RPCInvokeBatch); ok {
+		return bc.InvokeFunctions(calls, v.signers)
+	}
+	var res = make([]*result.Invoke, len(calls))
+	for i, c := range calls {
+		r, err := v.client.InvokeFunction(c.Contract, c.Operation, c.Params, v.signers)
+		if err != nil {
+			return nil, fmt.Errorf("call %d (%s): %w", i, c.Operation, err)
+		}
+		res[i] = r
+	}
+	return res, nil
+}
+
 // CallAndExpandIterator creates a script containing a call of the specified method
 // of a contract with given parameters (similar to how Call operates). But then this
 // script contains additional code that expects that the result of the first call is
@@ -54,6 +54,21 @@ func (r *rpcInv) TraverseIterator(sessionID, iteratorID uuid.UUID, maxItemsCount
 	return r.resItm, r.err
 }
 
+type rpcInvBatch struct {
+	rpcInv
+
+	calls []FunctionCall
+}
+
+func (r *rpcInvBatch) InvokeFunctions(calls []FunctionCall, signers []transaction.Signer) ([]*result.Invoke, error) {
+	r.calls = calls
+	var res = make([]*result.Invoke, len(calls))
+	for i := range res {
+		res[i] = r.resInv
+	}
+	return res, r.err
+}
+
 func TestInvoker(t *testing.T) {
 	resExp := &result.Invoke{State: "HALT"}
 	ri := &rpcInv{resExp, true, nil, nil}
@@ -95,6 +110,10 @@ func TestInvoker(t *testing.T) {
 
 		_, err = inv.CallAndExpandIterator(util.Uint160{}, "method", 10, make(map[int]int))
 		require.Error(t, err)
+
+		ress, err := inv.CallBatch([]FunctionCall{{Operation: "method"}, {Operation: "other"}})
+		require.NoError(t, err)
+		require.Equal(t, []*result.Invoke{resExp, resExp}, ress)
 	}
 	t.Run("standard", func(t *testing.T) {
 		testInv(t, New(ri, nil))
@@ -105,6 +124,19 @@ func TestInvoker(t *testing.T) {
 	t.Run("historic, state", func(t *testing.T) {
 		testInv(t, NewHistoricWithState(util.Uint256{}, ri, nil))
 	})
+	t.Run("batch", func(t *testing.T) {
+		rb := &rpcInvBatch{rpcInv: *ri}
+		testInv(t, New(rb, nil))
+		require.Equal(t, []FunctionCall{{Operation: "method"}, {Operation: "other"}}, rb.calls)
+
+		rb.err = errors.New("")
+		_, err := New(rb, nil).CallBatch([]FunctionCall{{Operation: "method"}})
+		require.Error(t, err)
+	})
+	t.Run("sequential batch error", func(t *testing.T) {
+		_, err := New(&rpcInv{err: errors.New("")}, nil).CallBatch([]FunctionCall{{Operation: "method"}})
+		require.Error(t, err)
+	})
 	t.Run("broken historic", func(t *testing.T) {
 		inv := New(&historicConverter{client: ri}, nil) // It's not possible to do this from outside.
 		require.Panics(t, func() { _, _ = inv.Call(util.Uint160{}, "method") })
@@ -0,0 +1,276 @@
+package invoker
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/unwrap"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// IteratorInvoker is a set of methods needed by Iterator to retrieve data. It's
+// implemented by Invoker (and thus by actor.Actor).
+type IteratorInvoker interface {
+	Call(contract util.Uint160, operation string, params ...any) (*result.Invoke, error)
+	CallAndExpandIterator(contract util.Uint160, method string, maxItems int, params ...any) (*result.Invoke, error)
+	TerminateSession(sessionID uuid.UUID) error
+	TraverseIterator(sessionID uuid.UUID, iterator *result.Iterator, num int) ([]stackitem.Item, error)
+}
+
+// Iterator is a lazy iterator over the values returned by some contract method
+// (that returns an iterator). It hides the differences between RPC servers:
+//   - if the server supports sessions, the iterator is traversed page by page
+//     using TraverseIterator;
+//   - if the server expands iterators in place, expanded values are used and
+//     if they're truncated the rest is fetched with CallAndExpandIterator;
+//   - if the server has sessions disabled and doesn't expand iterators,
+//     CallAndExpandIterator is used.
+//
+// CallAndExpandIterator can't continue from some position, so every request
+// returns all of the items from the beginning. To keep the number of requests
+// (and the amount of data transferred) linear, the number of items requested
+// is doubled every time and those not yet needed are buffered. Still, the
+// whole result of this call is limited by the VM (see vm.MaxStackSize), so
+// this mode is only suitable for iterators with a small number of elements,
+// larger ones require server sessions to be enabled.
+//
+// If the session expires while traversing the iterator, the method is invoked
+// again and the items that were already returned are skipped. This makes
+// traversal resilient to session timeouts, but the data can change between
+// invocations (new blocks can be added), so it's not guaranteed to be a
+// consistent snapshot in this case. Expansion mode has the same property
+// since every page is a separate invocation.
+//
+// Nothing is requested from the server until the first Next call (or the
+// first iteration over All). Iterator is not thread-safe.
+type Iterator[T any] struct {
+	inv      IteratorInvoker
+	convert  func(stackitem.Item) (T, error)
+	contract util.Uint160
+	method   string
+	params   []any
+
+	started  bool
+	expand   bool
+	done     bool
+	session  uuid.UUID
+	iterator result.Iterator
+	// consumed is the number of items already returned to the caller.
+	consumed int
+	// buffered are the items fetched with CallAndExpandIterator that are
+	// not yet returned to the caller, expanded is set when there are no
+	// more items to fetch.
+	buffered []stackitem.Item
+	expanded bool
+	err      error
+}
+
+// NewIterator creates an Iterator for the given contract method using the
+// given converter to transform stack items into values of the required type.
+// The method is invoked lazily with the parameters provided.
+func NewIterator[T any](inv IteratorInvoker, convert func(stackitem.Item) (T, error), contract util.Uint160, method string, params ...any) *Iterator[T] {
+	return &Iterator[T]{
+		inv:      inv,
+		convert:  convert,
+		contract: contract,
+		method:   method,
+		params:   params,
+	}
+}
+
+// Next returns the next set of elements from the iterator (up to num of them,
+// DefaultIteratorResultItems if num <= 0). It returns an empty slice when the
+// iterator has no more elements. Any error is also remembered and returned
+// by Err.
+func (i *Iterator[T]) Next(num int) ([]T, error) {
+	if i.err != nil {
+		return nil, i.err
+	}
+	if num <= 0 {
+		num = DefaultIteratorResultItems
+	}
+	items, err := i.next(num)
+	if err != nil {
+		i.err = err
+		return nil, err
+	}
+	res := make([]T, len(items))
+	for j := range items {
+		res[j], err = i.convert(items[j])
+		if err != nil {
+			i.err = fmt.Errorf("item #%d: %w", i.consumed+j, err)
+			return nil, i.err
+		}
+	}
+	i.consumed += len(items)
+	return res, nil
+}
+
+// All returns a function that can be used to walk through all of the
+// iterator elements. It's compatible with iter.Seq, so it can be used in
+// range-over-func loops with Go 1.23+, but can also be called directly with
+// a yield function on older versions. Iteration stops on the first error,
+// it can be checked with Err afterwards. The session (if any) is terminated
+// when iteration ends.
+func (i *Iterator[T]) All() func(yield func(T) bool) {
+	return func(yield func(T) bool) {
+		defer func() { _ = i.Terminate() }()
+		for {
+			vals, err := i.Next(DefaultIteratorResultItems)
+			if err != nil || len(vals) == 0 {
+				return
+			}
+			for _, v := range vals {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Err returns the first error encountered by the iterator (if any).
+func (i *Iterator[T]) Err() error {
+	return i.err
+}
+
+// Terminate closes the iterator session (if there is any). Iterator can't be
+// used after this call. It's done automatically when the iterator is
+// exhausted.
+func (i *Iterator[T]) Terminate() error {
+	i.done = true
+	if !i.started || i.expand || i.iterator.ID == nil {
+		return nil
+	}
+	i.iterator.ID = nil
+	return i.inv.TerminateSession(i.session)
+}
+
+// next retrieves up to num raw items from wherever they're available.
+func (i *Iterator[T]) next(num int) ([]stackitem.Item, error) {
+	if i.done {
+		return nil, nil
+	}
+	if !i.started {
+		if err := i.start(); err != nil {
+			return nil, err
+		}
+	}
+	if i.expand {
+		return i.nextExpanded(num)
+	}
+	if i.iterator.ID == nil {
+		items, _ := iterateNext(nil, i.session, &i.iterator, num)
+		if len(items) == 0 && i.iterator.Truncated {
+			// Server-side expansion limit reached, get the rest via
+			// in-VM expansion.
+			i.expand = true
+			return i.nextExpanded(num)
+		}
+		if len(items) == 0 {
+			i.done = true
+		}
+		return items, nil
+	}
+	items, err := i.inv.TraverseIterator(i.session, &i.iterator, num)
+	if err != nil {
+		if !errors.Is(err, neorpc.ErrUnknownSession) && !errors.Is(err, neorpc.ErrUnknownIterator) {
+			return nil, err
+		}
+		// Session has expired, start again skipping known items.
+		if err = i.restart(); err != nil {
+			return nil, err
+		}
+		if i.expand {
+			return i.nextExpanded(num)
+		}
+		items, err = i.inv.TraverseIterator(i.session, &i.iterator, num)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(items) == 0 {
+		// Session is kept by the server until it expires.
+		return nil, i.Terminate()
+	}
+	return items, nil
+}
+
+// start invokes the method and determines the mode of operation.
+func (i *Iterator[T]) start() error {
+	sess, iter, err := unwrap.SessionIterator(i.inv.Call(i.contract, i.method, i.params...))
+	i.started = true
+	if err != nil {
+		if errors.Is(err, unwrap.ErrNoSessionID) {
+			i.expand = true
+			return nil
+		}
+		return err
+	}
+	i.session, i.iterator = sess, iter
+	return nil
+}
+
+// restart invokes the method again and skips the items already consumed.
+func (i *Iterator[T]) restart() error {
+	i.started = false
+	i.iterator = result.Iterator{}
+	if err := i.start(); err != nil {
+		return err
+	}
+	if i.iterator.ID == nil {
+		// No session this time, expansion skips consumed items itself.
+		i.expand = true
+		return nil
+	}
+	for skip := i.consumed; skip > 0; {
+		n := skip
+		if n > DefaultIteratorResultItems {
+			n = DefaultIteratorResultItems
+		}
+		items, err := i.inv.TraverseIterator(i.session, &i.iterator, n)
+		if err != nil {
+			return fmt.Errorf("skipping consumed items: %w", err)
+		}
+		if len(items) == 0 {
+			break
+		}
+		skip -= len(items)
+	}
+	return nil
+}
+
+// nextExpanded gets the next page via CallAndExpandIterator. It requests
+// at least twice as many items as consumed (skipping the consumed ones) and
+// buffers the ones not needed yet, so that the number of calls is
+// logarithmic.
+func (i *Iterator[T]) nextExpanded(num int) ([]stackitem.Item, error) {
+	if len(i.buffered) == 0 && !i.expanded {
+		want := i.consumed + num
+		if want < 2*i.consumed {
+			want = 2 * i.consumed
+		}
+		arr, err := unwrap.Array(i.inv.CallAndExpandIterator(i.contract, i.method, want, i.params...))
+		if err != nil {
+			return nil, err
+		}
+		i.expanded = len(arr) < want
+		if len(arr) > i.consumed {
+			i.buffered = arr[i.consumed:]
+		}
+	}
+	if len(i.buffered) == 0 {
+		i.done = true
+		return nil, nil
+	}
+	if num > len(i.buffered) {
+		num = len(i.buffered)
+	}
+	items := i.buffered[:num]
+	i.buffered = i.buffered[num:]
+	return items, nil
+}
@@ -0,0 +1,233 @@
+package invoker
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+// iterInv emulates a contract returning an iterator over data.
+type iterInv struct {
+	data      []stackitem.Item
+	sessions  bool
+	expand    int // Server-side expansion limit, 0 for none.
+	calls     int
+	expCalls  int
+	expire    bool // Expire the session on the next traversal.
+	sess      uuid.UUID
+	pos       int
+	terminate int
+	err       error
+}
+
+func (r *iterInv) Call(contract util.Uint160, operation string, params ...any) (*result.Invoke, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	r.calls++
+	r.pos = 0
+	var res = &result.Invoke{State: "HALT"}
+	switch {
+	case r.sessions:
+		r.sess = uuid.New()
+		iid := uuid.New()
+		res.Session = r.sess
+		res.Stack = []stackitem.Item{stackitem.NewInterop(result.Iterator{ID: &iid})}
+	case r.expand != 0:
+		n := r.expand
+		if n > len(r.data) {
+			n = len(r.data)
+		}
+		res.Stack = []stackitem.Item{stackitem.NewInterop(result.Iterator{
+			Values:    r.data[:n],
+			Truncated: n < len(r.data),
+		})}
+	default:
+		iid := uuid.New()
+		res.Stack = []stackitem.Item{stackitem.NewInterop(result.Iterator{ID: &iid})}
+	}
+	return res, nil
+}
+
+func (r *iterInv) CallAndExpandIterator(contract util.Uint160, method string, maxItems int, params ...any) (*result.Invoke, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	r.expCalls++
+	if maxItems > len(r.data) {
+		maxItems = len(r.data)
+	}
+	return &result.Invoke{State: "HALT", Stack: []stackitem.Item{stackitem.Make(r.data[:maxItems])}}, nil
+}
+
+func (r *iterInv) TerminateSession(sessionID uuid.UUID) error {
+	r.terminate++
+	return nil
+}
+
+func (r *iterInv) TraverseIterator(sessionID uuid.UUID, iterator *result.Iterator, num int) ([]stackitem.Item, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	if r.expire {
+		r.expire = false
+		return nil, neorpc.ErrUnknownSession
+	}
+	if sessionID != r.sess {
+		return nil, neorpc.ErrUnknownSession
+	}
+	end := r.pos + num
+	if end > len(r.data) {
+		end = len(r.data)
+	}
+	res := r.data[r.pos:end]
+	r.pos = end
+	return res, nil
+}
+
+func TestIterator(t *testing.T) {
+	var data []stackitem.Item
+	var expected []int64
+	for i := 0; i < 250; i++ {
+		data = append(data, stackitem.Make(i))
+		expected = append(expected, int64(i))
+	}
+	toInt := func(itm stackitem.Item) (int64, error) {
+		i, err := itm.TryInteger()
+		if err != nil {
+			return 0, err
+		}
+		return i.Int64(), nil
+	}
+	collect := func(t *testing.T, it *Iterator[int64]) []int64 {
+		var res []int64
+		it.All()(func(v int64) bool {
+			res = append(res, v)
+			return true
+		})
+		require.NoError(t, it.Err())
+		return res
+	}
+
+	t.Run("lazy", func(t *testing.T) {
+		ri := &iterInv{data: data, sessions: true}
+		it := NewIterator(ri, toInt, util.Uint160{}, "method")
+		require.Equal(t, 0, ri.calls)
+		require.NoError(t, it.Terminate())
+		require.Equal(t, 0, ri.terminate)
+	})
+	t.Run("sessions", func(t *testing.T) {
+		ri := &iterInv{data: data, sessions: true}
+		it := NewIterator(ri, toInt, util.Uint160{}, "method")
+		require.Equal(t, expected, collect(t, it))
+		require.Equal(t, 1, ri.calls)
+		require.Equal(t, 0, ri.expCalls)
+		require.Equal(t, 1, ri.terminate)
+
+		// Exhausted iterator terminates the session.
+		it = NewIterator(ri, toInt, util.Uint160{}, "method")
+		vals, err := it.Next(1000)
+		require.NoError(t, err)
+		require.Equal(t, expected, vals)
+		require.Equal(t, 1, ri.terminate)
+		vals, err = it.Next(1000)
+		require.NoError(t, err)
+		require.Equal(t, 0, len(vals))
+		require.Equal(t, 2, ri.terminate)
+		require.NoError(t, it.Terminate())
+		require.Equal(t, 2, ri.terminate)
+	})
+	t.Run("session expired", func(t *testing.T) {
+		ri := &iterInv{data: data, sessions: true}
+		it := NewIterator(ri, toInt, util.Uint160{}, "method")
+		vals, err := it.Next(10)
+		require.NoError(t, err)
+		require.Equal(t, expected[:10], vals)
+
+		ri.expire = true
+		vals, err = it.Next(10)
+		require.NoError(t, err)
+		require.Equal(t, expected[10:20], vals)
+		require.Equal(t, 2, ri.calls)
+
+		require.NoError(t, it.Terminate())
+		require.Equal(t, 1, ri.terminate)
+		vals, err = it.Next(10)
+		require.NoError(t, err)
+		require.Equal(t, 0, len(vals))
+	})
+	t.Run("server expansion", func(t *testing.T) {
+		ri := &iterInv{data: data, expand: 100}
+		it := NewIterator(ri, toInt, util.Uint160{}, "method")
+		require.Equal(t, expected, collect(t, it))
+		require.Equal(t, 1, ri.calls)
+		require.Equal(t, 2, ri.expCalls)
+		require.Equal(t, 0, ri.terminate)
+	})
+	t.Run("server expansion, not truncated", func(t *testing.T) {
+		ri := &iterInv{data: data, expand: 1000}
+		it := NewIterator(ri, toInt, util.Uint160{}, "method")
+		require.Equal(t, expected, collect(t, it))
+		require.Equal(t, 0, ri.expCalls)
+	})
+	t.Run("no sessions", func(t *testing.T) {
+		ri := &iterInv{data: data}
+		it := NewIterator(ri, toInt, util.Uint160{}, "method")
+		vals, err := it.Next(200)
+		require.NoError(t, err)
+		require.Equal(t, expected[:200], vals)
+		require.Equal(t, expected[200:], collect(t, it))
+		require.Equal(t, 1, ri.calls)
+		require.Equal(t, 2, ri.expCalls)
+	})
+	t.Run("no sessions, small pages", func(t *testing.T) {
+		ri := &iterInv{data: data}
+		it := NewIterator(ri, toInt, util.Uint160{}, "method")
+		var res []int64
+		for {
+			vals, err := it.Next(10)
+			require.NoError(t, err)
+			if len(vals) == 0 {
+				break
+			}
+			require.LessOrEqual(t, len(vals), 10)
+			res = append(res, vals...)
+		}
+		require.Equal(t, expected, res)
+		require.Equal(t, 6, ri.expCalls) // 10, 20, 40, 80, 160, 320.
+	})
+	t.Run("early stop", func(t *testing.T) {
+		ri := &iterInv{data: data, sessions: true}
+		it := NewIterator(ri, toInt, util.Uint160{}, "method")
+		var n int
+		it.All()(func(v int64) bool {
+			n++
+			return n < 5
+		})
+		require.Equal(t, 5, n)
+		require.Equal(t, 1, ri.terminate)
+	})
+	t.Run("errors", func(t *testing.T) {
+		ri := &iterInv{data: data, sessions: true, err: errors.New("")}
+		it := NewIterator(ri, toInt, util.Uint160{}, "method")
+		_, err := it.Next(10)
+		require.Error(t, err)
+		require.Error(t, it.Err())
+
+		ri.err = nil
+		_, err = it.Next(10)
+		require.Error(t, err) // Sticky.
+
+		its := NewIterator(ri, func(itm stackitem.Item) (string, error) {
+			return "", errors.New("bad item")
+		}, util.Uint160{}, "method")
+		_, err = its.Next(10)
+		require.ErrorContains(t, err, "item #0")
+	})
+}
@@ -0,0 +1,294 @@
+/*
+Package ledger allows to work with the native LedgerContract contract via RPC.
+
+LedgerContract only has safe methods, they're all encapsulated into
+ContractReader structure. Most of the data provided by this contract can also
+be retrieved via regular RPC calls (getblock, getrawtransaction and so on) in a
+more efficient way, so this package is mostly useful for historic invocations
+and for testing contract behavior.
+*/
+package ledger
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativehashes"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/unwrap"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+)
+
+// Invoker is used by ContractReader to call various methods.
+type Invoker interface {
+	Call(contract util.Uint160, operation string, params ...any) (*result.Invoke, error)
+}
+
+// Hash stores the hash of the native LedgerContract contract.
+var Hash = nativehashes.LedgerContract
+
+// ContractReader provides an interface to call read-only LedgerContract
+// contract's methods.
+type ContractReader struct {
+	invoker Invoker
+}
+
+// Block is a block representation returned by the LedgerContract. It only
+// contains header data and the number of transactions in the block.
+type Block struct {
+	Hash               util.Uint256
+	Version            uint32
+	PrevHash           util.Uint256
+	MerkleRoot         util.Uint256
+	Timestamp          uint64
+	Nonce              uint64
+	Index              uint32
+	NextConsensus      util.Uint160
+	TransactionsLength uint32
+	// PrevStateRoot is only set for networks with StateRootInHeader
+	// extension enabled.
+	PrevStateRoot *util.Uint256
+}
+
+// Transaction is a transaction representation returned by the
+// LedgerContract. It doesn't contain attributes, signers (they can be
+// retrieved separately with GetTransactionSigners) and witnesses.
+type Transaction struct {
+	Hash            util.Uint256
+	Version         uint8
+	Nonce           uint32
+	Sender          util.Uint160
+	SystemFee       int64
+	NetworkFee      int64
+	ValidUntilBlock uint32
+	Script          []byte
+}
+
+// NewReader creates an instance of ContractReader that can be used to read
+// data from the contract.
+func NewReader(invoker Invoker) *ContractReader {
+	return &ContractReader{invoker}
+}
+
+// CurrentHash returns the hash of the latest block.
+func (c *ContractReader) CurrentHash() (util.Uint256, error) {
+	return unwrap.Uint256(c.invoker.Call(Hash, "currentHash"))
+}
+
+// CurrentIndex returns the index of the latest block.
+func (c *ContractReader) CurrentIndex() (uint32, error) {
+	r, err := c.invoker.Call(Hash, "currentIndex")
+	i, err := unwrap.LimitedInt64(r, err, 0, math.MaxUint32)
+	return uint32(i), err
+}
+
+// GetBlock returns the block with the given index. Nil (with no error) is
+// returned if the block is not available (it's too old to be traced).
+func (c *ContractReader) GetBlock(index uint32) (*Block, error) {
+	return itemToBlock(unwrap.Item(c.invoker.Call(Hash, "getBlock", index)))
+}
+
+// GetBlockByHash returns the block with the given hash. Nil (with no error)
+// is returned if there is no such block or it can't be traced.
+func (c *ContractReader) GetBlockByHash(hash util.Uint256) (*Block, error) {
+	return itemToBlock(unwrap.Item(c.invoker.Call(Hash, "getBlock", hash)))
+}
+
+// GetTransaction returns the transaction with the given hash. Nil (with no
+// error) is returned if there is no such transaction or it can't be traced.
+func (c *ContractReader) GetTransaction(hash util.Uint256) (*Transaction, error) {
+	return itemToTransaction(unwrap.Item(c.invoker.Call(Hash, "getTransaction", hash)))
+}
+
+// GetTransactionFromBlock returns the transaction with the given index from
+// the block with the given index. Nil (with no error) is returned if the block
+// can't be traced, an invalid transaction index leads to an error.
+func (c *ContractReader) GetTransactionFromBlock(blockIndex uint32, txIndex uint32) (*Transaction, error) {
+	return itemToTransaction(unwrap.Item(c.invoker.Call(Hash, "getTransactionFromBlock", blockIndex, txIndex)))
+}
+
+// GetTransactionFromBlockByHash is the same as GetTransactionFromBlock, but
+// the block is specified by its hash.
+func (c *ContractReader) GetTransactionFromBlockByHash(blockHash util.Uint256, txIndex uint32) (*Transaction, error) {
+	return itemToTransaction(unwrap.Item(c.invoker.Call(Hash, "getTransactionFromBlock", blockHash, txIndex)))
+}
+
+// GetTransactionHeight returns the index of the block containing the given
+// transaction, -1 is returned if there is no such transaction or it can't be
+// traced.
+func (c *ContractReader) GetTransactionHeight(hash util.Uint256) (int64, error) {
+	r, err := c.invoker.Call(Hash, "getTransactionHeight", hash)
+	return unwrap.LimitedInt64(r, err, -1, math.MaxUint32)
+}
+
+// GetTransactionSigners returns the list of signers of the given transaction.
+// Nil (with no error) is returned if there is no such transaction or it can't
+// be traced.
+func (c *ContractReader) GetTransactionSigners(hash util.Uint256) ([]transaction.Signer, error) {
+	itm, err := unwrap.Item(c.invoker.Call(Hash, "getTransactionSigners", hash))
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := itm.(stackitem.Null); ok {
+		return nil, nil
+	}
+	return transaction.SignersFromStackItem(itm)
+}
+
+// GetTransactionVMState returns the VM state of the given transaction
+// execution, vmstate.None is returned if there is no such transaction or it
+// can't be traced.
+func (c *ContractReader) GetTransactionVMState(hash util.Uint256) (vmstate.State, error) {
+	r, err := c.invoker.Call(Hash, "getTransactionVMState", hash)
+	s, err := unwrap.LimitedInt64(r, err, 0, math.MaxUint8)
+	return vmstate.State(s), err
+}
+
+func itemToBlock(itm stackitem.Item, err error) (*Block, error) {
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := itm.(stackitem.Null); ok {
+		return nil, nil
+	}
+	arr, ok := itm.Value().([]stackitem.Item)
+	if !ok {
+		return nil, errors.New("not a structure")
+	}
+	if len(arr) != 9 && len(arr) != 10 {
+		return nil, fmt.Errorf("wrong number of elements: %d", len(arr))
+	}
+	var (
+		res = new(Block)
+		i   int64
+	)
+	if res.Hash, err = itemToUint256(arr[0]); err != nil {
+		return nil, fmt.Errorf("invalid hash: %w", err)
+	}
+	if i, err = itemToInt(arr[1], 0, math.MaxUint32); err != nil {
+		return nil, fmt.Errorf("invalid version: %w", err)
+	}
+	res.Version = uint32(i)
+	if res.PrevHash, err = itemToUint256(arr[2]); err != nil {
+		return nil, fmt.Errorf("invalid previous hash: %w", err)
+	}
+	if res.MerkleRoot, err = itemToUint256(arr[3]); err != nil {
+		return nil, fmt.Errorf("invalid merkle root: %w", err)
+	}
+	if res.Timestamp, err = itemToUint64(arr[4]); err != nil {
+		return nil, fmt.Errorf("invalid timestamp: %w", err)
+	}
+	if res.Nonce, err = itemToUint64(arr[5]); err != nil {
+		return nil, fmt.Errorf("invalid nonce: %w", err)
+	}
+	if i, err = itemToInt(arr[6], 0, math.MaxUint32); err != nil {
+		return nil, fmt.Errorf("invalid index: %w", err)
+	}
+	res.Index = uint32(i)
+	b, err := arr[7].TryBytes()
+	if err == nil {
+		res.NextConsensus, err = util.Uint160DecodeBytesBE(b)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid next consensus: %w", err)
+	}
+	if i, err = itemToInt(arr[8], 0, math.MaxUint32); err != nil {
+		return nil, fmt.Errorf("invalid transactions length: %w", err)
+	}
+	res.TransactionsLength = uint32(i)
+	if len(arr) == 10 {
+		h, err := itemToUint256(arr[9])
+		if err != nil {
+			return nil, fmt.Errorf("invalid previous state root: %w", err)
+		}
+		res.PrevStateRoot = &h
+	}
+	return res, nil
+}
+
+func itemToTransaction(itm stackitem.Item, err error) (*Transaction, error) {
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := itm.(stackitem.Null); ok {
+		return nil, nil
+	}
+	arr, ok := itm.Value().([]stackitem.Item)
+	if !ok {
+		return nil, errors.New("not a structure")
+	}
+	if len(arr) != 8 {
+		return nil, fmt.Errorf("wrong number of elements: %d", len(arr))
+	}
+	var (
+		res = new(Transaction)
+		i   int64
+	)
+	if res.Hash, err = itemToUint256(arr[0]); err != nil {
+		return nil, fmt.Errorf("invalid hash: %w", err)
+	}
+	if i, err = itemToInt(arr[1], 0, math.MaxUint8); err != nil {
+		return nil, fmt.Errorf("invalid version: %w", err)
+	}
+	res.Version = uint8(i)
+	if i, err = itemToInt(arr[2], 0, math.MaxUint32); err != nil {
+		return nil, fmt.Errorf("invalid nonce: %w", err)
+	}
+	res.Nonce = uint32(i)
+	b, err := arr[3].TryBytes()
+	if err == nil {
+		res.Sender, err = util.Uint160DecodeBytesBE(b)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender: %w", err)
+	}
+	if res.SystemFee, err = itemToInt(arr[4], 0, math.MaxInt64); err != nil {
+		return nil, fmt.Errorf("invalid system fee: %w", err)
+	}
+	if res.NetworkFee, err = itemToInt(arr[5], 0, math.MaxInt64); err != nil {
+		return nil, fmt.Errorf("invalid network fee: %w", err)
+	}
+	if i, err = itemToInt(arr[6], 0, math.MaxUint32); err != nil {
+		return nil, fmt.Errorf("invalid valid until block: %w", err)
+	}
+	res.ValidUntilBlock = uint32(i)
+	if res.Script, err = arr[7].TryBytes(); err != nil {
+		return nil, fmt.Errorf("invalid script: %w", err)
+	}
+	return res, nil
+}
+
+func itemToUint256(itm stackitem.Item) (util.Uint256, error) {
+	b, err := itm.TryBytes()
+	if err != nil {
+		return util.Uint256{}, err
+	}
+	return util.Uint256DecodeBytesBE(b)
+}
+
+func itemToInt(itm stackitem.Item, min int64, max int64) (int64, error) {
+	bi, err := itm.TryInteger()
+	if err != nil {
+		return 0, err
+	}
+	if !bi.IsInt64() || bi.Int64() < min || bi.Int64() > max {
+		return 0, errors.New("value out of range")
+	}
+	return bi.Int64(), nil
+}
+
+func itemToUint64(itm stackitem.Item) (uint64, error) {
+	bi, err := itm.TryInteger()
+	if err != nil {
+		return 0, err
+	}
+	if bi.Sign() < 0 || bi.Cmp(new(big.Int).SetUint64(math.MaxUint64)) > 0 {
+		return 0, errors.New("value out of range")
+	}
+	return bi.Uint64(), nil
+}
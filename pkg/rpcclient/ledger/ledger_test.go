@@ -0,0 +1,207 @@
+package ledger
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+	"github.com/stretchr/testify/require"
+)
+
+type testInv struct {
+	err error
+	res *result.Invoke
+}
+
+func (t *testInv) Call(contract util.Uint160, operation string, params ...any) (*result.Invoke, error) {
+	return t.res, t.err
+}
+
+func TestReader(t *testing.T) {
+	ti := new(testInv)
+	lc := NewReader(ti)
+
+	ti.err = errors.New("")
+	_, err := lc.CurrentHash()
+	require.Error(t, err)
+	_, err = lc.CurrentIndex()
+	require.Error(t, err)
+	_, err = lc.GetBlock(1)
+	require.Error(t, err)
+	_, err = lc.GetBlockByHash(util.Uint256{})
+	require.Error(t, err)
+	_, err = lc.GetTransaction(util.Uint256{})
+	require.Error(t, err)
+	_, err = lc.GetTransactionFromBlock(1, 0)
+	require.Error(t, err)
+	_, err = lc.GetTransactionFromBlockByHash(util.Uint256{}, 0)
+	require.Error(t, err)
+	_, err = lc.GetTransactionHeight(util.Uint256{})
+	require.Error(t, err)
+	_, err = lc.GetTransactionSigners(util.Uint256{})
+	require.Error(t, err)
+	_, err = lc.GetTransactionVMState(util.Uint256{})
+	require.Error(t, err)
+
+	ti.err = nil
+	ti.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{stackitem.Make(util.Uint256{1, 2, 3}.BytesBE())},
+	}
+	h, err := lc.CurrentHash()
+	require.NoError(t, err)
+	require.Equal(t, util.Uint256{1, 2, 3}, h)
+
+	ti.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{stackitem.Make(42)},
+	}
+	i, err := lc.CurrentIndex()
+	require.NoError(t, err)
+	require.Equal(t, uint32(42), i)
+	height, err := lc.GetTransactionHeight(util.Uint256{})
+	require.NoError(t, err)
+	require.Equal(t, int64(42), height)
+
+	ti.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{stackitem.Make(-1)},
+	}
+	height, err = lc.GetTransactionHeight(util.Uint256{})
+	require.NoError(t, err)
+	require.Equal(t, int64(-1), height)
+	_, err = lc.CurrentIndex()
+	require.Error(t, err)
+
+	ti.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{stackitem.Make(vmstate.Fault)},
+	}
+	st, err := lc.GetTransactionVMState(util.Uint256{})
+	require.NoError(t, err)
+	require.Equal(t, vmstate.Fault, st)
+
+	ti.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{stackitem.Null{}},
+	}
+	b, err := lc.GetBlock(1)
+	require.NoError(t, err)
+	require.Nil(t, b)
+	tx, err := lc.GetTransaction(util.Uint256{})
+	require.NoError(t, err)
+	require.Nil(t, tx)
+	signers, err := lc.GetTransactionSigners(util.Uint256{})
+	require.NoError(t, err)
+	require.Nil(t, signers)
+
+	ti.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{stackitem.Make(42)},
+	}
+	_, err = lc.GetBlock(1)
+	require.Error(t, err)
+	_, err = lc.GetTransaction(util.Uint256{})
+	require.Error(t, err)
+	_, err = lc.GetTransactionSigners(util.Uint256{})
+	require.Error(t, err)
+
+	ti.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{stackitem.Make([]stackitem.Item{})},
+	}
+	_, err = lc.GetBlock(1)
+	require.Error(t, err)
+	_, err = lc.GetTransaction(util.Uint256{})
+	require.Error(t, err)
+}
+
+func TestGetBlock(t *testing.T) {
+	ti := new(testInv)
+	lc := NewReader(ti)
+
+	for _, sr := range []bool{false, true} {
+		blk := &block.Block{
+			Header: block.Header{
+				Version:          0,
+				PrevHash:         util.Uint256{1, 2, 3},
+				MerkleRoot:       util.Uint256{4, 5, 6},
+				Timestamp:        123456,
+				Nonce:            0xffffffffffffffff,
+				Index:            42,
+				NextConsensus:    util.Uint160{7, 8, 9},
+				StateRootEnabled: sr,
+				PrevStateRoot:    util.Uint256{10, 11},
+			},
+			Transactions: []*transaction.Transaction{transaction.New([]byte{1}, 1)},
+		}
+		ti.res = &result.Invoke{
+			State: "HALT",
+			Stack: []stackitem.Item{blk.ToStackItem()},
+		}
+		b, err := lc.GetBlockByHash(blk.Hash())
+		require.NoError(t, err)
+		require.Equal(t, blk.Hash(), b.Hash)
+		require.Equal(t, blk.PrevHash, b.PrevHash)
+		require.Equal(t, blk.MerkleRoot, b.MerkleRoot)
+		require.Equal(t, blk.Timestamp, b.Timestamp)
+		require.Equal(t, blk.Nonce, b.Nonce)
+		require.Equal(t, blk.Index, b.Index)
+		require.Equal(t, blk.NextConsensus, b.NextConsensus)
+		require.Equal(t, uint32(1), b.TransactionsLength)
+		if sr {
+			require.Equal(t, &blk.PrevStateRoot, b.PrevStateRoot)
+		} else {
+			require.Nil(t, b.PrevStateRoot)
+		}
+	}
+}
+
+func TestGetTransaction(t *testing.T) {
+	ti := new(testInv)
+	lc := NewReader(ti)
+
+	tx := transaction.New([]byte{1, 2, 3}, 100)
+	tx.Nonce = 42
+	tx.NetworkFee = 200
+	tx.ValidUntilBlock = 1000
+	tx.Signers = []transaction.Signer{{
+		Account: util.Uint160{1, 2, 3},
+		Scopes:  transaction.CalledByEntry,
+	}}
+	ti.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{tx.ToStackItem()},
+	}
+	for _, get := range []func() (*Transaction, error){
+		func() (*Transaction, error) { return lc.GetTransaction(tx.Hash()) },
+		func() (*Transaction, error) { return lc.GetTransactionFromBlock(1, 0) },
+		func() (*Transaction, error) { return lc.GetTransactionFromBlockByHash(util.Uint256{}, 0) },
+	} {
+		res, err := get()
+		require.NoError(t, err)
+		require.Equal(t, &Transaction{
+			Hash:            tx.Hash(),
+			Version:         tx.Version,
+			Nonce:           tx.Nonce,
+			Sender:          tx.Sender(),
+			SystemFee:       tx.SystemFee,
+			NetworkFee:      tx.NetworkFee,
+			ValidUntilBlock: tx.ValidUntilBlock,
+			Script:          tx.Script,
+		}, res)
+	}
+
+	ti.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{transaction.SignersToStackItem(tx.Signers)},
+	}
+	signers, err := lc.GetTransactionSigners(tx.Hash())
+	require.NoError(t, err)
+	require.Equal(t, tx.Signers, signers)
+}
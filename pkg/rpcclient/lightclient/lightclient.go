@@ -0,0 +1,459 @@
+/*
+Package lightclient provides a way to verify contract storage data obtained
+from untrusted RPC nodes.
+
+It tracks state roots signed by the StateValidator nodes (designated via the
+RoleManagement native contract) and checks MPT proofs returned by the
+getproof RPC against them. Client starts with a trusted set of state
+validators and follows subsequent StateValidator designations only if they're
+proven against a state root signed by the previous set, so the RPC node can't
+make the client use any keys it wants. Notice that storage item absence can't
+be proven with the currently available RPC API, so VerifiedGetStorage returns
+an RPC error in this case.
+*/
+package lightclient
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativehashes"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// RPC is a set of RPC client methods needed for Client, it's implemented by
+// rpcclient.Client.
+type RPC interface {
+	GetVersion() (*result.Version, error)
+	GetStateHeight() (*result.StateHeight, error)
+	GetStateRootByHeight(height uint32) (*state.MPTRoot, error)
+	GetProof(stateroot util.Uint256, historicalContractHash util.Uint160, historicalKey []byte) (*result.ProofWithKey, error)
+	FindStates(stateroot util.Uint256, historicalContractHash util.Uint160, historicalPrefix []byte, start []byte, maxCount *int) (result.FindStates, error)
+}
+
+// Client is a state root light client, it verifies state roots and storage
+// data obtained from an untrusted RPC node. It's safe for concurrent use.
+type Client struct {
+	rpc   RPC
+	magic uint32
+
+	lock   sync.Mutex
+	sets   []validatorSet
+	latest *state.MPTRoot
+}
+
+// validatorSet is a set of state validators valid starting from the given
+// height.
+type validatorSet struct {
+	height uint32
+	keys   keys.PublicKeys
+	script []byte
+	m      int
+}
+
+var (
+	// ErrInvalidStateRoot is returned when the state root can't be verified
+	// with any known state validator set.
+	ErrInvalidStateRoot = errors.New("invalid state root")
+	// ErrInvalidProof is returned when the proof doesn't match the state root
+	// or the requested key.
+	ErrInvalidProof = errors.New("invalid proof")
+)
+
+// Storage-related constants of native contracts, they're a part of the
+// protocol and can't change.
+const (
+	managementContractID = -1
+	designateContractID  = -8
+
+	prefixContract = 8
+)
+
+// nativeIDs contains IDs of native contracts, their states are not stored in
+// the ContractManagement storage.
+var nativeIDs = map[util.Uint160]int32{
+	nativehashes.ContractManagement: managementContractID,
+	nativehashes.StdLib:             -2,
+	nativehashes.CryptoLib:          -3,
+	nativehashes.LedgerContract:     -4,
+	nativehashes.NeoToken:           -5,
+	nativehashes.GasToken:           -6,
+	nativehashes.PolicyContract:     -7,
+	nativehashes.RoleManagement:     designateContractID,
+	nativehashes.OracleContract:     -9,
+	nativehashes.Notary:             -10,
+}
+
+// New creates a new light client using the given RPC client and the trusted
+// set of state validators designated at the given height (state roots
+// starting from this height must be signed by them). It's usually the set
+// designated in the genesis block (height 0) or some set you've obtained
+// from a trusted source.
+func New(rpc RPC, height uint32, trusted keys.PublicKeys) (*Client, error) {
+	set, err := newValidatorSet(height, trusted)
+	if err != nil {
+		return nil, err
+	}
+	v, err := rpc.GetVersion()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get version: %w", err)
+	}
+	return &Client{
+		rpc:   rpc,
+		magic: uint32(v.Protocol.Network),
+		sets:  []validatorSet{set},
+	}, nil
+}
+
+func newValidatorSet(height uint32, pubs keys.PublicKeys) (validatorSet, error) {
+	if len(pubs) == 0 {
+		return validatorSet{}, errors.New("empty state validator set")
+	}
+	// Keys are sorted by CreateDefaultMultiSigRedeemScript, so it's done on a copy.
+	pubs = pubs.Copy()
+	script, err := smartcontract.CreateDefaultMultiSigRedeemScript(pubs)
+	if err != nil {
+		return validatorSet{}, fmt.Errorf("bad state validator set: %w", err)
+	}
+	set := validatorSet{
+		height: height,
+		keys:   pubs,
+		script: script,
+		m:      smartcontract.GetDefaultHonestNodeCount(len(pubs)),
+	}
+	return set, nil
+}
+
+// StateValidators returns the set of state validators used to verify state
+// root with the given index. It can only return sets known to the client,
+// the trusted one and ones learned while verifying state roots.
+func (c *Client) StateValidators(index uint32) keys.PublicKeys {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.setFor(index).keys.Copy()
+}
+
+// LatestVerifiedStateRoot returns the latest state root verified by the client
+// (nil if there are none).
+func (c *Client) LatestVerifiedStateRoot() *state.MPTRoot {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.latest
+}
+
+// GetStateRoot returns the state root with the given index verified to be
+// signed by the state validators designated for this height.
+func (c *Client) GetStateRoot(index uint32) (*state.MPTRoot, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.getStateRoot(index)
+}
+
+// GetLatestStateRoot returns the latest state root validated by the RPC node
+// after checking its signature.
+func (c *Client) GetLatestStateRoot() (*state.MPTRoot, error) {
+	h, err := c.rpc.GetStateHeight()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get state height: %w", err)
+	}
+	return c.GetStateRoot(h.Validated)
+}
+
+// VerifiedGetStorage returns the value of the storage item with the given key
+// of the given contract from the latest state validated by the RPC node. The
+// state root signature and the proof of the value are checked, contract ID is
+// proven as well.
+func (c *Client) VerifiedGetStorage(contract util.Uint160, key []byte) ([]byte, error) {
+	root, err := c.GetLatestStateRoot()
+	if err != nil {
+		return nil, err
+	}
+	return c.VerifiedGetStorageAt(root, contract, key)
+}
+
+// VerifiedGetStorageAt is similar to VerifiedGetStorage, but uses the given
+// state root which must be verified in advance (see GetStateRoot).
+func (c *Client) VerifiedGetStorageAt(root *state.MPTRoot, contract util.Uint160, key []byte) ([]byte, error) {
+	id, err := c.getContractID(root, contract)
+	if err != nil {
+		return nil, err
+	}
+	return c.getProvenValue(root, contract, id, key)
+}
+
+func (c *Client) getContractID(root *state.MPTRoot, contract util.Uint160) (int32, error) {
+	if id, ok := nativeIDs[contract]; ok {
+		return id, nil
+	}
+	key := make([]byte, 1+util.Uint160Size)
+	key[0] = prefixContract
+	copy(key[1:], contract.BytesBE())
+	val, err := c.getProvenValue(root, nativehashes.ContractManagement, managementContractID, key)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get contract state: %w", err)
+	}
+	var cs state.Contract
+	err = stackitem.DeserializeConvertible(val, &cs)
+	if err != nil {
+		return 0, fmt.Errorf("failed to decode contract state: %w", err)
+	}
+	if !cs.Hash.Equals(contract) {
+		return 0, fmt.Errorf("%w: contract hash mismatch", ErrInvalidProof)
+	}
+	return cs.ID, nil
+}
+
+func (c *Client) getProvenValue(root *state.MPTRoot, contract util.Uint160, id int32, key []byte) ([]byte, error) {
+	proof, err := c.rpc.GetProof(root.Root, contract, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get proof: %w", err)
+	}
+	return verifyProof(root, id, key, proof)
+}
+
+// verifyProof checks that the proof is given for the key of the contract with
+// the given ID and matches the state root. It returns the proven value.
+func verifyProof(root *state.MPTRoot, id int32, key []byte, proof *result.ProofWithKey) ([]byte, error) {
+	if !bytes.Equal(proof.Key, makeStorageKey(id, key)) {
+		return nil, fmt.Errorf("%w: key mismatch", ErrInvalidProof)
+	}
+	val, ok := mpt.VerifyProof(root.Root, proof.Key, proof.Proof)
+	if !ok {
+		return nil, fmt.Errorf("%w: doesn't match state root %d", ErrInvalidProof, root.Index)
+	}
+	return val, nil
+}
+
+// verifyLastProof is similar to verifyProof, but it also checks that there
+// are no keys with the given prefix following the proven one.
+func verifyLastProof(root *state.MPTRoot, id int32, prefix []byte, key []byte, proof *result.ProofWithKey) ([]byte, error) {
+	if !bytes.Equal(proof.Key, makeStorageKey(id, key)) {
+		return nil, fmt.Errorf("%w: key mismatch", ErrInvalidProof)
+	}
+	val, ok := mpt.VerifyLastProof(root.Root, makeStorageKey(id, prefix), proof.Key, proof.Proof)
+	if !ok {
+		return nil, fmt.Errorf("%w: not the last key in state root %d", ErrInvalidProof, root.Index)
+	}
+	return val, nil
+}
+
+func makeStorageKey(id int32, key []byte) []byte {
+	skey := make([]byte, 4+len(key))
+	binary.LittleEndian.PutUint32(skey, uint32(id))
+	copy(skey[4:], key)
+	return skey
+}
+
+func (c *Client) setFor(index uint32) validatorSet {
+	for i := len(c.sets) - 1; i > 0; i-- {
+		if c.sets[i].height <= index {
+			return c.sets[i]
+		}
+	}
+	return c.sets[0]
+}
+
+func (c *Client) getStateRoot(index uint32) (*state.MPTRoot, error) {
+	if index < c.sets[0].height {
+		return nil, fmt.Errorf("%w: %d is lower than the trusted height %d", ErrInvalidStateRoot, index, c.sets[0].height)
+	}
+	if c.latest != nil && c.latest.Index == index {
+		return c.latest, nil
+	}
+	root, err := c.rpc.GetStateRootByHeight(index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get state root %d: %w", index, err)
+	}
+	if root.Index != index {
+		return nil, fmt.Errorf("%w: index mismatch (%d vs %d)", ErrInvalidStateRoot, root.Index, index)
+	}
+	for {
+		if c.verifyWitness(root, c.setFor(index)) {
+			c.track(root)
+			return root, nil
+		}
+		if c.setFor(index).height != c.sets[len(c.sets)-1].height {
+			// Known sets are proven, there can't be newer ones for this height.
+			return nil, fmt.Errorf("%w: bad witness for %d", ErrInvalidStateRoot, index)
+		}
+		updated, err := c.updateValidators(index)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update state validators: %w", err)
+		}
+		if !updated {
+			return nil, fmt.Errorf("%w: bad witness for %d", ErrInvalidStateRoot, index)
+		}
+	}
+}
+
+func (c *Client) track(root *state.MPTRoot) {
+	if c.latest == nil || c.latest.Index < root.Index {
+		c.latest = root
+	}
+}
+
+// verifyWitness checks that the state root is signed by the majority of the
+// given state validators.
+func (c *Client) verifyWitness(root *state.MPTRoot, set validatorSet) bool {
+	if len(root.Witness) != 1 || !bytes.Equal(root.Witness[0].VerificationScript, set.script) {
+		return false
+	}
+	var (
+		inv  = root.Witness[0].InvocationScript
+		sigs [][]byte
+	)
+	for len(inv) != 0 {
+		if len(inv) < 2+keys.SignatureLen || inv[0] != byte(opcode.PUSHDATA1) || inv[1] != keys.SignatureLen {
+			return false
+		}
+		sigs = append(sigs, inv[2:2+keys.SignatureLen])
+		inv = inv[2+keys.SignatureLen:]
+	}
+	if len(sigs) != set.m {
+		return false
+	}
+	// Same logic as CHECKMULTISIG uses, signatures are in the order of keys.
+	var k int
+	for _, sig := range sigs {
+		for k < len(set.keys) && !set.keys[k].VerifyHashable(sig, c.magic, root) {
+			k++
+		}
+		if k == len(set.keys) {
+			return false
+		}
+		k++
+	}
+	return true
+}
+
+// updateValidators tries to find a StateValidator designation that happened
+// after the latest known one and not later than the target height. To do
+// that it finds the latest state root before the target that can be verified
+// with the latest known set (designation is stored in the state of the block
+// preceding the new set height) and gets the proven designation from it.
+func (c *Client) updateValidators(target uint32) (bool, error) {
+	var (
+		set = c.sets[len(c.sets)-1]
+		lo  *state.MPTRoot
+	)
+	if c.latest != nil && c.setFor(c.latest.Index).height == set.height {
+		lo = c.latest
+	} else {
+		root, err := c.rpc.GetStateRootByHeight(set.height)
+		if err != nil {
+			return false, fmt.Errorf("failed to get state root %d: %w", set.height, err)
+		}
+		if root.Index != set.height || !c.verifyWitness(root, set) {
+			return false, nil
+		}
+		c.track(root)
+		lo = root
+	}
+	if lo.Index >= target {
+		return false, nil
+	}
+	hi := target
+	for hi-lo.Index > 1 {
+		mid := lo.Index + (hi-lo.Index)/2
+		root, err := c.rpc.GetStateRootByHeight(mid)
+		if err != nil {
+			return false, fmt.Errorf("failed to get state root %d: %w", mid, err)
+		}
+		if root.Index == mid && c.verifyWitness(root, set) {
+			c.track(root)
+			lo = root
+		} else {
+			hi = mid
+		}
+	}
+	pubs, height, err := c.getDesignation(lo, set.height)
+	if err != nil || pubs == nil {
+		return false, err
+	}
+	if height <= set.height || height > target {
+		return false, fmt.Errorf("%w: unexpected designation height %d", ErrInvalidProof, height)
+	}
+	newSet, err := newValidatorSet(height, pubs)
+	if err != nil {
+		return false, err
+	}
+	c.sets = append(c.sets, newSet)
+	return true, nil
+}
+
+// getDesignation returns the latest StateValidator designation made after
+// the given height from the given state root. The designation is proven to
+// be the last one, so the node can't hide newer designations.
+func (c *Client) getDesignation(root *state.MPTRoot, after uint32) (keys.PublicKeys, uint32, error) {
+	var (
+		prefix = []byte{byte(noderoles.StateValidator)}
+		start  = make([]byte, 5)
+		last   *result.KeyValue
+		proof  *result.ProofWithKey
+	)
+	start[0] = prefix[0]
+	binary.BigEndian.PutUint32(start[1:], after)
+	for {
+		res, err := c.rpc.FindStates(root.Root, nativehashes.RoleManagement, prefix, start, nil)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to find designations: %w", err)
+		}
+		if len(res.Results) == 0 {
+			break
+		}
+		last = &res.Results[len(res.Results)-1]
+		proof = res.LastProof
+		if len(res.Results) == 1 {
+			proof = res.FirstProof
+		}
+		if !res.Truncated {
+			break
+		}
+		start = last.Key
+	}
+	if last == nil {
+		return nil, 0, nil
+	}
+	if proof == nil || len(last.Key) != 5 {
+		return nil, 0, fmt.Errorf("%w: bad designation", ErrInvalidProof)
+	}
+	val, err := verifyLastProof(root, designateContractID, prefix, last.Key, proof)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !bytes.Equal(val, last.Value) {
+		return nil, 0, fmt.Errorf("%w: designation value mismatch", ErrInvalidProof)
+	}
+	item, err := stackitem.Deserialize(val)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to decode designation: %w", err)
+	}
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return nil, 0, errors.New("failed to decode designation: not an array")
+	}
+	pubs := make(keys.PublicKeys, 0, len(arr))
+	for i := range arr {
+		b, err := arr[i].TryBytes()
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to decode designation: %w", err)
+		}
+		pub, err := keys.NewPublicKeyFromBytes(b, nil)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to decode designation: %w", err)
+		}
+		pubs = append(pubs, pub)
+	}
+	return pubs, binary.BigEndian.Uint32(last.Key[1:]), nil
+}
@@ -0,0 +1,346 @@
+package lightclient
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativehashes"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+const testMagic = netmode.UnitTestNet
+
+var _ = RPC(&rpcclient.Client{})
+
+// testChain is a fake RPC node serving state roots and proofs.
+type testChain struct {
+	store  *storage.MemCachedStore
+	tr     *mpt.Trie
+	roots  []*state.MPTRoot
+	ids    map[util.Uint160]int32
+	states []map[string][]byte
+	kv     map[string][]byte
+
+	badProofKey bool
+	// hideLast makes FindStates omit the last matching key.
+	hideLast bool
+}
+
+func newTestChain() *testChain {
+	store := storage.NewMemCachedStore(storage.NewMemoryStore())
+	c := &testChain{
+		store: store,
+		tr:    mpt.NewTrie(nil, mpt.ModeAll, store),
+		ids:   make(map[util.Uint160]int32),
+		kv:    make(map[string][]byte),
+	}
+	for h, id := range nativeIDs {
+		c.ids[h] = id
+	}
+	return c
+}
+
+func (c *testChain) put(id int32, key, value []byte) {
+	skey := makeStorageKey(id, key)
+	c.kv[string(skey)] = value
+	if err := c.tr.Put(skey, value); err != nil {
+		panic(err)
+	}
+}
+
+// persist creates a new state root signed by the given keys.
+func (c *testChain) persist(signers []*keys.PrivateKey) {
+	index := uint32(len(c.roots))
+	c.tr.Flush(index)
+	root := &state.MPTRoot{Index: index, Root: c.tr.StateRoot()}
+	signRoot(root, signers)
+	c.roots = append(c.roots, root)
+	snapshot := make(map[string][]byte, len(c.kv))
+	for k, v := range c.kv {
+		snapshot[k] = v
+	}
+	c.states = append(c.states, snapshot)
+}
+
+func signRoot(root *state.MPTRoot, signers []*keys.PrivateKey) {
+	pubs := make(keys.PublicKeys, len(signers))
+	for i := range signers {
+		pubs[i] = signers[i].PublicKey()
+	}
+	script, err := smartcontract.CreateDefaultMultiSigRedeemScript(pubs)
+	if err != nil {
+		panic(err)
+	}
+	sorted := make([]*keys.PrivateKey, len(signers))
+	copy(sorted, signers)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].PublicKey().Cmp(sorted[j].PublicKey()) < 0 })
+	var inv []byte
+	for _, k := range sorted[:smartcontract.GetDefaultHonestNodeCount(len(sorted))] {
+		inv = append(inv, byte(opcode.PUSHDATA1), keys.SignatureLen)
+		inv = append(inv, k.SignHashable(uint32(testMagic), root)...)
+	}
+	root.Witness = []transaction.Witness{{InvocationScript: inv, VerificationScript: script}}
+}
+
+func (c *testChain) rootIndex(root util.Uint256) int {
+	for i := range c.roots {
+		if c.roots[i].Root == root {
+			return i
+		}
+	}
+	panic("unknown root")
+}
+
+func (c *testChain) GetVersion() (*result.Version, error) {
+	return &result.Version{Protocol: result.Protocol{Network: testMagic}}, nil
+}
+
+func (c *testChain) GetStateHeight() (*result.StateHeight, error) {
+	return &result.StateHeight{Local: uint32(len(c.roots) - 1), Validated: uint32(len(c.roots) - 1)}, nil
+}
+
+func (c *testChain) GetStateRootByHeight(height uint32) (*state.MPTRoot, error) {
+	if int(height) >= len(c.roots) {
+		return nil, errors.New("unknown root")
+	}
+	return c.roots[height], nil
+}
+
+func (c *testChain) proof(root util.Uint256, skey []byte) (*result.ProofWithKey, error) {
+	tr := mpt.NewTrie(mpt.NewHashNode(root), mpt.ModeAll, c.store)
+	proof, err := tr.GetProof(skey)
+	if err != nil {
+		return nil, err
+	}
+	return &result.ProofWithKey{Key: skey, Proof: proof}, nil
+}
+
+func (c *testChain) GetProof(root util.Uint256, contract util.Uint160, key []byte) (*result.ProofWithKey, error) {
+	id, ok := c.ids[contract]
+	if !ok {
+		return nil, errors.New("unknown contract")
+	}
+	if c.badProofKey && id != managementContractID {
+		id = -6
+	}
+	return c.proof(root, makeStorageKey(id, key))
+}
+
+func (c *testChain) FindStates(root util.Uint256, contract util.Uint160, prefix []byte, start []byte, maxCount *int) (result.FindStates, error) {
+	var (
+		res     result.FindStates
+		idBytes = makeStorageKey(c.ids[contract], nil)
+		skeys   []string
+	)
+	for k := range c.states[c.rootIndex(root)] {
+		key := []byte(k)
+		if bytes.HasPrefix(key, idBytes) && bytes.HasPrefix(key[4:], prefix) && bytes.Compare(key[4:], start) > 0 {
+			skeys = append(skeys, k)
+		}
+	}
+	sort.Strings(skeys)
+	if c.hideLast && len(skeys) > 1 {
+		skeys = skeys[:len(skeys)-1]
+	}
+	for i, k := range skeys {
+		res.Results = append(res.Results, result.KeyValue{Key: []byte(k)[4:], Value: c.states[c.rootIndex(root)][k]})
+		p, err := c.proof(root, []byte(k))
+		if err != nil {
+			return res, err
+		}
+		if i == 0 {
+			res.FirstProof = p
+		}
+		if i > 0 && i == len(skeys)-1 {
+			res.LastProof = p
+		}
+	}
+	return res, nil
+}
+
+func newKeys(t *testing.T, n int) ([]*keys.PrivateKey, keys.PublicKeys) {
+	var (
+		privs []*keys.PrivateKey
+		pubs  keys.PublicKeys
+	)
+	for i := 0; i < n; i++ {
+		k, err := keys.NewPrivateKey()
+		require.NoError(t, err)
+		privs = append(privs, k)
+		pubs = append(pubs, k.PublicKey())
+	}
+	return privs, pubs
+}
+
+func designate(c *testChain, height uint32, pubs keys.PublicKeys) {
+	key := make([]byte, 5)
+	key[0] = byte(noderoles.StateValidator)
+	binary.BigEndian.PutUint32(key[1:], height)
+	items := make([]stackitem.Item, len(pubs))
+	for i := range pubs {
+		items[i] = stackitem.NewByteArray(pubs[i].Bytes())
+	}
+	val, err := stackitem.Serialize(stackitem.NewArray(items))
+	if err != nil {
+		panic(err)
+	}
+	c.put(designateContractID, key, val)
+}
+
+func TestClient(t *testing.T) {
+	var (
+		c        = newTestChain()
+		contract = util.Uint160{1, 2, 3}
+		key      = []byte("key")
+
+		privsA, pubsA = newKeys(t, 4)
+		privsB, pubsB = newKeys(t, 1)
+	)
+	c.ids[contract] = 1
+	ne, err := nef.NewFile([]byte{byte(opcode.RET)})
+	require.NoError(t, err)
+	cs := &state.Contract{ContractBase: state.ContractBase{
+		ID:       1,
+		Hash:     contract,
+		NEF:      *ne,
+		Manifest: *manifest.DefaultManifest("test"),
+	}}
+	csBytes, err := stackitem.SerializeConvertible(cs)
+	require.NoError(t, err)
+	c.put(managementContractID, append([]byte{prefixContract}, contract.BytesBE()...), csBytes)
+	c.put(1, key, []byte("value1"))
+	c.put(-6, key, []byte("gas"))
+	for i := 0; i < 3; i++ {
+		c.persist(privsA)
+	}
+	c.put(1, key, []byte("value2"))
+	c.persist(privsA) // 3
+	designate(c, 5, pubsB)
+	c.persist(privsA) // 4
+	for i := 0; i < 5; i++ {
+		c.persist(privsB) // 5-9
+	}
+
+	lc, err := New(c, 0, pubsA)
+	require.NoError(t, err)
+	require.Nil(t, lc.LatestVerifiedStateRoot())
+
+	root, err := lc.GetStateRoot(2)
+	require.NoError(t, err)
+	require.Equal(t, c.roots[2], root)
+	val, err := lc.VerifiedGetStorageAt(root, contract, key)
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), val)
+	val, err = lc.VerifiedGetStorageAt(root, nativehashes.GasToken, key)
+	require.NoError(t, err)
+	require.Equal(t, []byte("gas"), val)
+
+	val, err = lc.VerifiedGetStorage(contract, key)
+	require.NoError(t, err)
+	require.Equal(t, []byte("value2"), val)
+	require.Equal(t, c.roots[9], lc.LatestVerifiedStateRoot())
+	require.ElementsMatch(t, pubsB, lc.StateValidators(9))
+	require.ElementsMatch(t, pubsA, lc.StateValidators(4))
+
+	t.Run("unknown contract", func(t *testing.T) {
+		_, err := lc.VerifiedGetStorage(util.Uint160{3, 2, 1}, key)
+		require.Error(t, err)
+	})
+
+	t.Run("bad proof", func(t *testing.T) {
+		c.badProofKey = true
+		defer func() { c.badProofKey = false }()
+		_, err := lc.VerifiedGetStorage(contract, key)
+		require.ErrorIs(t, err, ErrInvalidProof)
+	})
+
+	t.Run("bad signature", func(t *testing.T) {
+		lc, err := New(c, 0, pubsA)
+		require.NoError(t, err)
+		good := c.roots[1]
+		bad := *good
+		signRoot(&bad, privsB)
+		c.roots[1] = &bad
+		defer func() { c.roots[1] = good }()
+		_, err = lc.GetStateRoot(1)
+		require.ErrorIs(t, err, ErrInvalidStateRoot)
+	})
+
+	t.Run("wrong trusted set", func(t *testing.T) {
+		lc, err := New(c, 0, pubsB)
+		require.NoError(t, err)
+		_, err = lc.GetStateRoot(1)
+		require.ErrorIs(t, err, ErrInvalidStateRoot)
+	})
+
+	t.Run("undesignated signers", func(t *testing.T) {
+		lc, err := New(c, 0, pubsA)
+		require.NoError(t, err)
+		privsC, _ := newKeys(t, 1)
+		good := c.roots[7]
+		bad := *good
+		signRoot(&bad, privsC)
+		c.roots[7] = &bad
+		defer func() { c.roots[7] = good }()
+		_, err = lc.GetStateRoot(7)
+		require.ErrorIs(t, err, ErrInvalidStateRoot)
+		// Designation is learned anyway.
+		require.ElementsMatch(t, pubsB, lc.StateValidators(7))
+	})
+
+	t.Run("below trusted height", func(t *testing.T) {
+		lc, err := New(c, 5, pubsB)
+		require.NoError(t, err)
+		_, err = lc.GetStateRoot(4)
+		require.ErrorIs(t, err, ErrInvalidStateRoot)
+		_, err = lc.GetStateRoot(8)
+		require.NoError(t, err)
+	})
+}
+
+func TestClientHiddenDesignation(t *testing.T) {
+	var (
+		c             = newTestChain()
+		privsA, pubsA = newKeys(t, 4)
+		privsB, pubsB = newKeys(t, 1)
+		privsC, pubsC = newKeys(t, 1)
+	)
+	c.persist(privsA) // 0
+	c.persist(privsA) // 1
+	designate(c, 3, pubsB)
+	designate(c, 4, pubsC)
+	c.persist(privsA) // 2
+	c.persist(privsB) // 3
+	c.persist(privsC) // 4
+
+	// B can't be accepted instead of C.
+	c.hideLast = true
+	lc, err := New(c, 0, pubsA)
+	require.NoError(t, err)
+	_, err = lc.GetStateRoot(4)
+	require.ErrorIs(t, err, ErrInvalidProof)
+
+	c.hideLast = false
+	root, err := lc.GetStateRoot(4)
+	require.NoError(t, err)
+	require.Equal(t, c.roots[4], root)
+	require.ElementsMatch(t, pubsC, lc.StateValidators(4))
+}
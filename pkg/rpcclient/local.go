@@ -45,6 +45,7 @@ func NewInternal(ctx context.Context, register InternalHook) (*Internal, error)
 	go c.eventLoop()
 	// c.ctx is inherited from ctx in fact (see initClient).
 	c.requestF = register(c.ctx, c.events) //nolint:contextcheck // Non-inherited new context, use function like `context.WithXXX` instead
+	c.batchF = nil
 	return c, nil
 }
 
@@ -0,0 +1,226 @@
+package rpcclient
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultRetryAttempts = 3
+	defaultRetryDelay    = 100 * time.Millisecond
+)
+
+// RequestFunc is a function performing an RPC request, it returns either
+// a response (that can contain an RPC error) or a transport error. ctx is the
+// client context, it's canceled when the client is closed.
+type RequestFunc func(ctx context.Context, r *neorpc.Request) (*neorpc.Response, error)
+
+// Middleware wraps RequestFunc adding some functionality to it. It can
+// inspect or modify the request before passing it to the next function,
+// inspect or modify the result, skip calling the next function or call it
+// multiple times (sequentially). Middlewares can be used concurrently, so
+// they must be thread-safe. Long waits should be interrupted when ctx is
+// done.
+type Middleware func(next RequestFunc) RequestFunc
+
+// Tracer starts spans for RPC requests, it's a minimal interface that can be
+// easily implemented on top of OpenTelemetry or any other tracing library.
+type Tracer interface {
+	// StartSpan starts a span for the given request. It's called right
+	// before the request is sent.
+	StartSpan(r *neorpc.Request) Span
+}
+
+// Span is a single traced RPC request.
+type Span interface {
+	// End finishes the span. err is either a transport error or an RPC
+	// error returned by the server (nil on success).
+	End(err error)
+}
+
+// RetryOptions are the options for NewRetryMiddleware.
+type RetryOptions struct {
+	// Attempts is the maximum number of attempts to make (including the
+	// first one), 3 by default.
+	Attempts int
+	// Delay is the delay before the second attempt, it's doubled after
+	// every failed attempt. 100ms by default.
+	Delay time.Duration
+	// Idempotent decides whether the request can be safely repeated,
+	// IsIdempotent is used by default.
+	Idempotent func(*neorpc.Request) bool
+}
+
+// nonIdempotentMethods contains methods that change something on the server
+// side, so they can't be safely repeated if the result of the first attempt
+// is not known.
+var nonIdempotentMethods = map[string]bool{
+	"sendrawtransaction":   true,
+	"submitblock":          true,
+	"submitnotaryrequest":  true,
+	"submitoracleresponse": true,
+	"subscribe":            true,
+	"unsubscribe":          true,
+	"terminatesession":     true,
+	"traverseiterator":     true,
+}
+
+// IsIdempotent returns true if the given request can be safely repeated in
+// case it has failed with a transport error (its result and side-effects are
+// unknown). Requests that submit something to the network (like
+// sendrawtransaction) or change session/subscription state are not
+// idempotent.
+func IsIdempotent(r *neorpc.Request) bool {
+	return !nonIdempotentMethods[r.Method]
+}
+
+// NewRetryMiddleware returns a Middleware that repeats idempotent requests
+// failed with a transport error (like connection loss or timeout). Errors
+// returned by the server are never retried, as well as context cancellation
+// and explicit WSClient closing. Waiting for the next attempt is interrupted
+// (returning the last error) if the client is closed.
+func NewRetryMiddleware(opts RetryOptions) Middleware {
+	if opts.Attempts <= 0 {
+		opts.Attempts = defaultRetryAttempts
+	}
+	if opts.Delay <= 0 {
+		opts.Delay = defaultRetryDelay
+	}
+	if opts.Idempotent == nil {
+		opts.Idempotent = IsIdempotent
+	}
+	return func(next RequestFunc) RequestFunc {
+		return func(ctx context.Context, r *neorpc.Request) (*neorpc.Response, error) {
+			var delay = opts.Delay
+			for i := 1; ; i++ {
+				resp, err := next(ctx, r)
+				if err == nil || (resp != nil && resp.Error != nil) ||
+					i >= opts.Attempts || !opts.Idempotent(r) ||
+					errors.Is(err, context.Canceled) || errors.Is(err, errConnClosedByUser) {
+					return resp, err
+				}
+				if !sleep(ctx, delay) {
+					return resp, err
+				}
+				delay *= 2
+			}
+		}
+	}
+}
+
+// NewRateLimitMiddleware returns a Middleware that limits the rate of
+// requests to the given number per period. Up to limit requests can be sent
+// at once, subsequent ones are delayed. Delayed requests are blocked until
+// they can be sent (or the client is closed). The limit is shared by all
+// clients using the same Middleware.
+func NewRateLimitMiddleware(limit int, period time.Duration) Middleware {
+	if limit <= 0 {
+		limit = 1
+	}
+	var (
+		lock      sync.Mutex
+		interval  = period / time.Duration(limit)
+		tolerance = interval * time.Duration(limit-1)
+		// tat is the theoretical arrival time of the next request
+		// (GCRA algorithm).
+		tat time.Time
+	)
+	return func(next RequestFunc) RequestFunc {
+		return func(ctx context.Context, r *neorpc.Request) (*neorpc.Response, error) {
+			lock.Lock()
+			var now = time.Now()
+			if tat.Before(now) {
+				tat = now
+			}
+			var wait = tat.Sub(now) - tolerance
+			tat = tat.Add(interval)
+			lock.Unlock()
+
+			if wait > 0 && !sleep(ctx, wait) {
+				return nil, ctx.Err()
+			}
+			return next(ctx, r)
+		}
+	}
+}
+
+// NewLogMiddleware returns a Middleware that logs every request and its
+// result with the given logger at Debug level.
+func NewLogMiddleware(log *zap.Logger) Middleware {
+	return func(next RequestFunc) RequestFunc {
+		return func(ctx context.Context, r *neorpc.Request) (*neorpc.Response, error) {
+			log.Debug("RPC request",
+				zap.String("method", r.Method),
+				zap.Uint64("id", r.ID),
+				zap.Any("params", r.Params))
+			var start = time.Now()
+			resp, err := next(ctx, r)
+			var fields = []zap.Field{
+				zap.String("method", r.Method),
+				zap.Uint64("id", r.ID),
+				zap.Duration("duration", time.Since(start)),
+			}
+			if rErr := responseError(resp, err); rErr != nil {
+				fields = append(fields, zap.Error(rErr))
+			}
+			log.Debug("RPC response", fields...)
+			return resp, err
+		}
+	}
+}
+
+// NewTracingMiddleware returns a Middleware that wraps every request into a
+// span created by the given Tracer.
+func NewTracingMiddleware(t Tracer) Middleware {
+	return func(next RequestFunc) RequestFunc {
+		return func(ctx context.Context, r *neorpc.Request) (*neorpc.Response, error) {
+			var span = t.StartSpan(r)
+			resp, err := next(ctx, r)
+			span.End(responseError(resp, err))
+			return resp, err
+		}
+	}
+}
+
+// chainMiddlewares wraps f with the given middlewares (the first one is the
+// outermost) passing ctx to them.
+func chainMiddlewares(ctx context.Context, f func(*neorpc.Request) (*neorpc.Response, error), mws []Middleware) func(*neorpc.Request) (*neorpc.Response, error) {
+	if len(mws) == 0 {
+		return f
+	}
+	var next RequestFunc = func(_ context.Context, r *neorpc.Request) (*neorpc.Response, error) {
+		return f(r)
+	}
+	for i := len(mws) - 1; i >= 0; i-- {
+		next = mws[i](next)
+	}
+	return func(r *neorpc.Request) (*neorpc.Response, error) {
+		return next(ctx, r)
+	}
+}
+
+// sleep waits for the given duration, it returns false if ctx is done
+// earlier.
+func sleep(ctx context.Context, d time.Duration) bool {
+	var t = time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
+
+// responseError returns an error to report for the given request results.
+func responseError(resp *neorpc.Response, err error) error {
+	if resp != nil && resp.Error != nil {
+		return resp.Error
+	}
+	return err
+}
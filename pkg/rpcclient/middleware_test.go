@@ -0,0 +1,279 @@
+package rpcclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// initFlakyServer returns a server that fails the first fails requests with
+// HTTP 502 and then answers with the given response.
+func initFlakyServer(t *testing.T, fails int32, resp string) (*httptest.Server, *atomic.Int32) {
+	var cnt = new(atomic.Int32)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if cnt.Add(1) <= fails {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(resp))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, cnt
+}
+
+func TestChainMiddlewares(t *testing.T) {
+	var (
+		order []string
+		mw    = func(name string) Middleware {
+			return func(next RequestFunc) RequestFunc {
+				return func(ctx context.Context, r *neorpc.Request) (*neorpc.Response, error) {
+					order = append(order, name)
+					return next(ctx, r)
+				}
+			}
+		}
+		f = chainMiddlewares(context.Background(), func(r *neorpc.Request) (*neorpc.Response, error) {
+			order = append(order, "request")
+			return nil, nil
+		}, []Middleware{mw("first"), mw("second")})
+	)
+	_, err := f(&neorpc.Request{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"first", "second", "request"}, order)
+}
+
+func TestRetryMiddleware(t *testing.T) {
+	const blockCountResp = `{"jsonrpc": "2.0", "id": 1, "result": 5}`
+
+	t.Run("idempotent", func(t *testing.T) {
+		srv, cnt := initFlakyServer(t, 2, blockCountResp)
+		c, err := New(context.TODO(), srv.URL, Options{Middlewares: []Middleware{
+			NewRetryMiddleware(RetryOptions{Delay: time.Millisecond}),
+		}})
+		require.NoError(t, err)
+		n, err := c.GetBlockCount()
+		require.NoError(t, err)
+		require.Equal(t, uint32(5), n)
+		require.Equal(t, int32(3), cnt.Load())
+	})
+	t.Run("attempts exhausted", func(t *testing.T) {
+		srv, cnt := initFlakyServer(t, 5, blockCountResp)
+		c, err := New(context.TODO(), srv.URL, Options{Middlewares: []Middleware{
+			NewRetryMiddleware(RetryOptions{Attempts: 2, Delay: time.Millisecond}),
+		}})
+		require.NoError(t, err)
+		_, err = c.GetBlockCount()
+		require.Error(t, err)
+		require.Equal(t, int32(2), cnt.Load())
+	})
+	t.Run("server error", func(t *testing.T) {
+		srv, cnt := initFlakyServer(t, 0, `{"jsonrpc": "2.0", "id": 1, "error":{"code":-32602,"message":"Invalid params"}}`)
+		c, err := New(context.TODO(), srv.URL, Options{Middlewares: []Middleware{
+			NewRetryMiddleware(RetryOptions{Delay: time.Millisecond}),
+		}})
+		require.NoError(t, err)
+		_, err = c.GetBlockCount()
+		require.ErrorIs(t, err, neorpc.ErrInvalidParams)
+		require.Equal(t, int32(1), cnt.Load())
+	})
+	t.Run("not idempotent", func(t *testing.T) {
+		srv, cnt := initFlakyServer(t, 2, `{"jsonrpc": "2.0", "id": 1, "result": {"hash": "0x0000000000000000000000000000000000000000000000000000000000000000"}}`)
+		c, err := New(context.TODO(), srv.URL, Options{Middlewares: []Middleware{
+			NewRetryMiddleware(RetryOptions{Delay: time.Millisecond}),
+		}})
+		require.NoError(t, err)
+		_, err = c.SendRawTransaction(transaction.New([]byte{byte(0x40)}, 0))
+		require.Error(t, err)
+		require.Equal(t, int32(1), cnt.Load())
+	})
+	t.Run("client closed", func(t *testing.T) {
+		srv, cnt := initFlakyServer(t, 5, blockCountResp)
+		c, err := New(context.TODO(), srv.URL, Options{Middlewares: []Middleware{
+			NewRetryMiddleware(RetryOptions{Delay: time.Hour}),
+		}})
+		require.NoError(t, err)
+		go func() {
+			for cnt.Load() == 0 {
+				time.Sleep(time.Millisecond)
+			}
+			c.Close()
+		}()
+		_, err = c.GetBlockCount()
+		require.Error(t, err)
+		require.Equal(t, int32(1), cnt.Load())
+	})
+	t.Run("batch", func(t *testing.T) {
+		srv, cnt := initFlakyServer(t, 1, blockCountResp)
+		c, err := New(context.TODO(), srv.URL, Options{Middlewares: []Middleware{
+			NewRetryMiddleware(RetryOptions{Delay: time.Millisecond}),
+		}})
+		require.NoError(t, err)
+		// The batch has failed, so requests are repeated separately.
+		b := c.NewBatch()
+		var calls = []*BatchCall[uint32]{b.GetBlockCount(), b.GetBlockCount()}
+		require.NoError(t, b.Send())
+		for _, call := range calls {
+			n, err := call.Result()
+			require.NoError(t, err)
+			require.Equal(t, uint32(5), n)
+		}
+		require.Equal(t, int32(3), cnt.Load())
+	})
+	t.Run("custom idempotency", func(t *testing.T) {
+		srv, cnt := initFlakyServer(t, 1, blockCountResp)
+		c, err := New(context.TODO(), srv.URL, Options{Middlewares: []Middleware{
+			NewRetryMiddleware(RetryOptions{
+				Delay:      time.Millisecond,
+				Idempotent: func(r *neorpc.Request) bool { return r.Method != "getblockcount" },
+			}),
+		}})
+		require.NoError(t, err)
+		_, err = c.GetBlockCount()
+		require.Error(t, err)
+		require.Equal(t, int32(1), cnt.Load())
+	})
+}
+
+func TestIsIdempotent(t *testing.T) {
+	require.True(t, IsIdempotent(&neorpc.Request{Method: "getblockcount"}))
+	require.True(t, IsIdempotent(&neorpc.Request{Method: "invokefunction"}))
+	require.False(t, IsIdempotent(&neorpc.Request{Method: "sendrawtransaction"}))
+	require.False(t, IsIdempotent(&neorpc.Request{Method: "subscribe"}))
+	require.False(t, IsIdempotent(&neorpc.Request{Method: "traverseiterator"}))
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	var (
+		cnt int
+		f   = NewRateLimitMiddleware(2, 100*time.Millisecond)(func(_ context.Context, r *neorpc.Request) (*neorpc.Response, error) {
+			cnt++
+			return nil, nil
+		})
+		start = time.Now()
+	)
+	// Burst.
+	for i := 0; i < 2; i++ {
+		_, _ = f(context.Background(), &neorpc.Request{})
+	}
+	// Delayed, 50ms per request.
+	for i := 0; i < 2; i++ {
+		_, _ = f(context.Background(), &neorpc.Request{})
+	}
+	require.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+	require.Equal(t, 4, cnt)
+
+	// Waiting is interrupted by the context.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := f(ctx, &neorpc.Request{})
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, 4, cnt)
+}
+
+func TestLogMiddleware(t *testing.T) {
+	var (
+		core, logs = observer.New(zap.DebugLevel)
+		srv        = initTestServer(t, `{"jsonrpc": "2.0", "id": 1, "result": 5}`)
+	)
+	c, err := New(context.TODO(), srv.URL, Options{Middlewares: []Middleware{
+		NewLogMiddleware(zap.New(core)),
+	}})
+	require.NoError(t, err)
+	_, err = c.GetBlockCount()
+	require.NoError(t, err)
+
+	entries := logs.AllUntimed()
+	require.Equal(t, 2, len(entries))
+	require.Equal(t, "RPC request", entries[0].Message)
+	require.Equal(t, "getblockcount", entries[0].ContextMap()["method"])
+	require.Equal(t, "RPC response", entries[1].Message)
+	require.Equal(t, "getblockcount", entries[1].ContextMap()["method"])
+	require.NotContains(t, entries[1].ContextMap(), "error")
+}
+
+type testTracer struct {
+	lock  sync.Mutex
+	spans []*testSpan
+}
+
+type testSpan struct {
+	method string
+	ended  bool
+	err    error
+}
+
+func (t *testTracer) StartSpan(r *neorpc.Request) Span {
+	var s = &testSpan{method: r.Method}
+	t.lock.Lock()
+	t.spans = append(t.spans, s)
+	t.lock.Unlock()
+	return s
+}
+
+func (s *testSpan) End(err error) {
+	s.ended, s.err = true, err
+}
+
+func TestTracingMiddleware(t *testing.T) {
+	var (
+		tr  = new(testTracer)
+		srv = initTestServer(t, `{"jsonrpc": "2.0", "id": 1, "error":{"code":-32602,"message":"Invalid params"}}`)
+	)
+	wsc, err := NewWS(context.TODO(), httpURLtoWS(srv.URL), WSOptions{Options: Options{
+		Middlewares: []Middleware{NewTracingMiddleware(tr)},
+	}})
+	require.NoError(t, err)
+	wsc.getNextRequestID = getTestRequestID
+	t.Cleanup(wsc.Close)
+
+	_, err = wsc.GetBlockCount()
+	require.Error(t, err)
+	require.Equal(t, 1, len(tr.spans))
+	require.Equal(t, "getblockcount", tr.spans[0].method)
+	require.True(t, tr.spans[0].ended)
+	require.ErrorIs(t, tr.spans[0].err, neorpc.ErrInvalidParams)
+}
+
+func TestBatchMiddlewares(t *testing.T) {
+	var (
+		core, logs = observer.New(zap.DebugLevel)
+		tr         = new(testTracer)
+		srv, cnt   = initFlakyServer(t, 0, `[{"jsonrpc": "2.0", "id": 1, "result": 5}, {"jsonrpc": "2.0", "id": 2, "result": "0x0000000000000000000000000000000000000000000000000000000000000000"}]`)
+	)
+	c, err := New(context.TODO(), srv.URL, Options{Middlewares: []Middleware{
+		NewLogMiddleware(zap.New(core)),
+		NewTracingMiddleware(tr),
+		NewRateLimitMiddleware(10, time.Second),
+	}})
+	require.NoError(t, err)
+
+	b := c.NewBatch()
+	count := b.GetBlockCount()
+	hash := b.GetBestBlockHash()
+	require.NoError(t, b.Send())
+	n, err := count.Result()
+	require.NoError(t, err)
+	require.Equal(t, uint32(5), n)
+	_, err = hash.Result()
+	require.NoError(t, err)
+
+	// Single HTTP request, but every call has passed through middlewares.
+	require.Equal(t, int32(1), cnt.Load())
+	require.Equal(t, 2, len(logs.FilterMessage("RPC request").All()))
+	require.Equal(t, 2, len(logs.FilterMessage("RPC response").All()))
+	require.Equal(t, 2, len(tr.spans))
+	for _, s := range tr.spans {
+		require.True(t, s.ended)
+		require.NoError(t, s.err)
+	}
+}
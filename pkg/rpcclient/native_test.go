@@ -0,0 +1,79 @@
+package rpcclient_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/native"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/cryptolib"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/ledger"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/management"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/neo"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/nep17"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/notary"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/oracle"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/policy"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/rolemgmt"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/stdlib"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNativeWrappers checks that every method of every native contract (as of
+// the latest hardfork) has a corresponding RPC wrapper.
+func TestNativeWrappers(t *testing.T) {
+	var (
+		wrappers = map[string]any{
+			nativenames.Management:  &management.Contract{},
+			nativenames.StdLib:      &stdlib.ContractReader{},
+			nativenames.CryptoLib:   &cryptolib.ContractReader{},
+			nativenames.Ledger:      &ledger.ContractReader{},
+			nativenames.Neo:         &neo.Contract{},
+			nativenames.Gas:         &nep17.Token{},
+			nativenames.Policy:      &policy.Contract{},
+			nativenames.Designation: &rolemgmt.Contract{},
+			nativenames.Oracle:      &oracle.Contract{},
+			nativenames.Notary:      &notary.Contract{},
+		}
+		// Wrapper names that are not just capitalized method names.
+		renames = map[string]map[string]string{
+			nativenames.Management: {
+				"getContractById": "GetContractByID",
+			},
+			nativenames.StdLib: {
+				"jsonSerialize":   "JSONSerialize",
+				"jsonDeserialize": "JSONDeserialize",
+			},
+		}
+		// Methods that can only be used by contracts or that can't be used
+		// directly via RPC.
+		skip = map[string]map[string]bool{
+			nativenames.Management: {"update": true, "destroy": true},
+			nativenames.Oracle:     {"finish": true, "request": true, "verify": true},
+			nativenames.CryptoLib:  {"bls12381Serialize": true, "bls12381Deserialize": true},
+			nativenames.Notary:     {"verify": true, "onNEP17Payment": true},
+		}
+		latestHF = config.LatestHardfork()
+		cs       = native.NewContracts(config.ProtocolConfiguration{P2PSigExtensions: true})
+	)
+	for _, c := range cs.Contracts {
+		name := c.Metadata().Name
+		w, ok := wrappers[name]
+		require.True(t, ok, "no wrapper for %s", name)
+		typ := reflect.TypeOf(w)
+		md := c.Metadata().HFSpecificContractMD(&latestHF)
+		for _, m := range md.Manifest.ABI.Methods {
+			if skip[name][m.Name] {
+				continue
+			}
+			goName, ok := renames[name][m.Name]
+			if !ok {
+				goName = strings.ToUpper(m.Name[:1]) + m.Name[1:]
+			}
+			_, ok = typ.MethodByName(goName)
+			require.True(t, ok, "no %s wrapper for %s.%s", goName, name, m.Name)
+		}
+	}
+}
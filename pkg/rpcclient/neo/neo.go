@@ -8,6 +8,7 @@ package neo
 
 import (
 	"crypto/elliptic"
+	"errors"
 	"fmt"
 	"math/big"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/invoker"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/nep17"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/unwrap"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
@@ -160,6 +162,14 @@ func (c *ContractReader) GetAllCandidatesExpanded(num int) ([]result.Validator,
 	return itemsToValidators(arr)
 }
 
+// IterateAllCandidates is similar to GetAllCandidates (uses the same NEO
+// method), but returns a lazy iterator that works with any RPC server. It uses
+// sessions if they're available and falls back to iterator expansion
+// otherwise, session expiration is also handled transparently.
+func (c *ContractReader) IterateAllCandidates() *invoker.Iterator[result.Validator] {
+	return invoker.NewIterator(c.invoker, itemToValidator, Hash, "getAllCandidates")
+}
+
 // Next returns the next set of elements from the iterator (up to num of them).
 // It can return less than num elements in case iterator doesn't have that many
 // or zero elements if the iterator has no more elements or the session is
@@ -198,34 +208,51 @@ func (c *ContractReader) GetCandidates() ([]result.Validator, error) {
 func itemsToValidators(arr []stackitem.Item) ([]result.Validator, error) {
 	res := make([]result.Validator, len(arr))
 	for i, itm := range arr {
-		str, ok := itm.Value().([]stackitem.Item)
-		if !ok {
-			return nil, fmt.Errorf("item #%d is not a structure", i)
-		}
-		if len(str) != 2 {
-			return nil, fmt.Errorf("item #%d has wrong length", i)
-		}
-		b, err := str[0].TryBytes()
-		if err != nil {
-			return nil, fmt.Errorf("item #%d has wrong key: %w", i, err)
-		}
-		k, err := keys.NewPublicKeyFromBytes(b, elliptic.P256())
+		v, err := itemToValidator(itm)
 		if err != nil {
-			return nil, fmt.Errorf("item #%d has wrong key: %w", i, err)
+			return nil, fmt.Errorf("item #%d: %w", i, err)
 		}
-		votes, err := str[1].TryInteger()
-		if err != nil {
-			return nil, fmt.Errorf("item #%d has wrong votes: %w", i, err)
-		}
-		if !votes.IsInt64() {
-			return nil, fmt.Errorf("item #%d has too big number of votes", i)
-		}
-		res[i].PublicKey = *k
-		res[i].Votes = votes.Int64()
+		res[i] = v
+	}
+	return res, nil
+}
+
+func itemToValidator(itm stackitem.Item) (result.Validator, error) {
+	var res result.Validator
+
+	str, ok := itm.Value().([]stackitem.Item)
+	if !ok {
+		return res, errors.New("not a structure")
+	}
+	if len(str) != 2 {
+		return res, errors.New("wrong length")
+	}
+	b, err := str[0].TryBytes()
+	if err != nil {
+		return res, fmt.Errorf("wrong key: %w", err)
+	}
+	k, err := keys.NewPublicKeyFromBytes(b, elliptic.P256())
+	if err != nil {
+		return res, fmt.Errorf("wrong key: %w", err)
+	}
+	votes, err := str[1].TryInteger()
+	if err != nil {
+		return res, fmt.Errorf("wrong votes: %w", err)
 	}
+	if !votes.IsInt64() {
+		return res, errors.New("too big number of votes")
+	}
+	res.PublicKey = *k
+	res.Votes = votes.Int64()
 	return res, nil
 }
 
+// GetCandidateVote returns the number of votes for the given candidate key,
+// -1 is returned if the key is not a registered candidate.
+func (c *ContractReader) GetCandidateVote(k *keys.PublicKey) (int64, error) {
+	return unwrap.Int64(c.invoker.Call(Hash, "getCandidateVote", k))
+}
+
 // GetCommittee returns the list of committee member public keys. This
 // method is mostly useful for historic invocations because the RPC protocol
 // provides direct getcommittee call that works faster.
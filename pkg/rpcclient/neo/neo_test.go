@@ -336,10 +336,15 @@ func TestGetKeys(t *testing.T) {
 func TestGetInts(t *testing.T) {
 	ta := &testAct{}
 	neo := NewReader(ta)
+	pk, err := keys.NewPrivateKey()
+	require.NoError(t, err)
 
 	meth := []func() (int64, error){
 		neo.GetGasPerBlock,
 		neo.GetRegisterPrice,
+		func() (int64, error) {
+			return neo.GetCandidateVote(pk.PublicKey())
+		},
 	}
 
 	ta.err = errors.New("")
@@ -18,6 +18,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/invoker"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/neptoken"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/unwrap"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
@@ -125,6 +126,14 @@ func (t *BaseReader) TokensExpanded(num int) ([][]byte, error) {
 	return unwrap.ArrayOfBytes(t.invoker.CallAndExpandIterator(t.hash, "tokens", num))
 }
 
+// IterateTokens is similar to Tokens (uses the same NEP-11 method), but
+// returns a lazy iterator that works with any RPC server. It uses sessions if
+// they're available and falls back to iterator expansion otherwise, session
+// expiration is also handled transparently.
+func (t *BaseReader) IterateTokens() *invoker.Iterator[[]byte] {
+	return invoker.NewIterator(t.invoker, stackitem.Item.TryBytes, t.hash, "tokens")
+}
+
 // TokensOf returns an iterator that allows to walk through all tokens owned by
 // the given account. It depends on the server to provide proper session-based
 // iterator, but can also work with expanded one.
@@ -145,6 +154,14 @@ func (t *BaseReader) TokensOfExpanded(account util.Uint160, num int) ([][]byte,
 	return unwrap.ArrayOfBytes(t.invoker.CallAndExpandIterator(t.hash, "tokensOf", num, account))
 }
 
+// IterateTokensOf is similar to TokensOf (uses the same NEP-11 method), but
+// returns a lazy iterator that works with any RPC server. It uses sessions if
+// they're available and falls back to iterator expansion otherwise, session
+// expiration is also handled transparently.
+func (t *BaseReader) IterateTokensOf(account util.Uint160) *invoker.Iterator[[]byte] {
+	return invoker.NewIterator(t.invoker, stackitem.Item.TryBytes, t.hash, "tokensOf", account)
+}
+
 // Transfer creates and sends a transaction that performs a `transfer` method
 // call using the given parameters and checks for this call result, failing the
 // transaction if it's not true. It works for divisible NFTs only when there is
@@ -7,8 +7,10 @@ import (
 	"github.com/google/uuid"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/invoker"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/unwrap"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 )
 
 // DivisibleReader is a reader interface for divisible NEP-11 contract.
@@ -68,6 +70,14 @@ func (t *DivisibleReader) OwnerOfExpanded(token []byte, num int) ([]util.Uint160
 	return unwrap.ArrayOfUint160(t.invoker.CallAndExpandIterator(t.hash, "ownerOf", num, token))
 }
 
+// IterateOwnersOf is similar to OwnerOf (uses the same NEP-11 method), but
+// returns a lazy iterator that works with any RPC server. It uses sessions if
+// they're available and falls back to iterator expansion otherwise, session
+// expiration is also handled transparently.
+func (t *DivisibleReader) IterateOwnersOf(token []byte) *invoker.Iterator[util.Uint160] {
+	return invoker.NewIterator(t.invoker, itemToOwner, t.hash, "ownerOf", token)
+}
+
 // BalanceOfD is a BalanceOf for divisible NFTs, it returns the amount of token
 // owned by a particular account.
 func (t *DivisibleReader) BalanceOfD(owner util.Uint160, token []byte) (*big.Int, error) {
@@ -145,3 +155,15 @@ func (v *OwnerIterator) Terminate() error {
 	}
 	return v.client.TerminateSession(v.session)
 }
+
+func itemToOwner(itm stackitem.Item) (util.Uint160, error) {
+	b, err := itm.TryBytes()
+	if err != nil {
+		return util.Uint160{}, fmt.Errorf("not a byte string: %w", err)
+	}
+	u, err := util.Uint160DecodeBytesBE(b)
+	if err != nil {
+		return util.Uint160{}, fmt.Errorf("not a uint160: %w", err)
+	}
+	return u, nil
+}
@@ -13,7 +13,9 @@ import (
 
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/invoker"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/neptoken"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/unwrap"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
@@ -24,6 +26,12 @@ type Invoker interface {
 	neptoken.Invoker
 }
 
+// batchInvoker is an optional Invoker extension used by TokenReader to
+// perform a number of calls at once, it's implemented by invoker.Invoker.
+type batchInvoker interface {
+	CallBatch(calls []invoker.FunctionCall) ([]*result.Invoke, error)
+}
+
 // Actor is used by Token to create and send transactions.
 type Actor interface {
 	Invoker
@@ -37,6 +45,9 @@ type Actor interface {
 // used to query various data.
 type TokenReader struct {
 	neptoken.Base
+
+	invoker Invoker
+	hash    util.Uint160
 }
 
 // TokenWriter contains NEP-17 token methods that change state. It's not meant
@@ -72,7 +83,7 @@ type TransferParameters struct {
 // NewReader creates an instance of TokenReader for contract with the given
 // hash using the given Invoker.
 func NewReader(invoker Invoker, hash util.Uint160) *TokenReader {
-	return &TokenReader{*neptoken.New(invoker, hash)}
+	return &TokenReader{*neptoken.New(invoker, hash), invoker, hash}
 }
 
 // New creates an instance of Token for contract with the given hash
@@ -81,6 +92,47 @@ func New(actor Actor, hash util.Uint160) *Token {
 	return &Token{*NewReader(actor, hash), TokenWriter{hash, actor}}
 }
 
+// BalancesOf returns token balances of the given accounts (see BalanceOf) in
+// the same order. If the Invoker is able to perform batch calls (like
+// invoker.Invoker does) all of them are requested at once.
+func (t *TokenReader) BalancesOf(accounts ...util.Uint160) ([]*big.Int, error) {
+	var res = make([]*big.Int, len(accounts))
+	bi, ok := t.invoker.(batchInvoker)
+	if !ok {
+		for i := range accounts {
+			bal, err := t.BalanceOf(accounts[i])
+			if err != nil {
+				return nil, fmt.Errorf("account %s: %w", accounts[i].StringLE(), err)
+			}
+			res[i] = bal
+		}
+		return res, nil
+	}
+	var calls = make([]invoker.FunctionCall, len(accounts))
+	for i := range accounts {
+		calls[i] = invoker.FunctionCall{
+			Contract:  t.hash,
+			Operation: "balanceOf",
+			Params: []smartcontract.Parameter{{
+				Type:  smartcontract.Hash160Type,
+				Value: accounts[i],
+			}},
+		}
+	}
+	invs, err := bi.CallBatch(calls)
+	if err != nil {
+		return nil, err
+	}
+	for i := range invs {
+		bal, err := unwrap.BigInt(invs[i], nil)
+		if err != nil {
+			return nil, fmt.Errorf("account %s: %w", accounts[i].StringLE(), err)
+		}
+		res[i] = bal
+	}
+	return res, nil
+}
+
 // Transfer creates and sends a transaction that performs a `transfer` method
 // call using the given parameters and checks for this call result, failing the
 // transaction if it's not true. The returned values are transaction hash, its
@@ -7,6 +7,7 @@ import (
 
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/invoker"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/stretchr/testify/require"
@@ -62,6 +63,65 @@ func TestReaderBalanceOf(t *testing.T) {
 	require.Error(t, err)
 }
 
+type testBatchAct struct {
+	testAct
+
+	calls []invoker.FunctionCall
+	ress  []*result.Invoke
+}
+
+func (t *testBatchAct) CallBatch(calls []invoker.FunctionCall) ([]*result.Invoke, error) {
+	t.calls = calls
+	return t.ress, t.err
+}
+
+func TestReaderBalancesOf(t *testing.T) {
+	var (
+		accs = []util.Uint160{{3, 2, 1}, {4, 5, 6}}
+		halt = func(items ...stackitem.Item) *result.Invoke {
+			return &result.Invoke{State: "HALT", Stack: items}
+		}
+	)
+	t.Run("sequential", func(t *testing.T) {
+		ta := new(testAct)
+		tr := NewReader(ta, util.Uint160{1, 2, 3})
+
+		ta.err = errors.New("")
+		_, err := tr.BalancesOf(accs...)
+		require.Error(t, err)
+
+		ta.err = nil
+		ta.res = halt(stackitem.Make(100500))
+		bals, err := tr.BalancesOf(accs...)
+		require.NoError(t, err)
+		require.Equal(t, []*big.Int{big.NewInt(100500), big.NewInt(100500)}, bals)
+	})
+	t.Run("batch", func(t *testing.T) {
+		ta := new(testBatchAct)
+		tr := NewReader(ta, util.Uint160{1, 2, 3})
+
+		ta.err = errors.New("")
+		_, err := tr.BalancesOf(accs...)
+		require.Error(t, err)
+
+		ta.err = nil
+		ta.ress = []*result.Invoke{halt(stackitem.Make(100500)), halt(stackitem.Make([]stackitem.Item{}))}
+		_, err = tr.BalancesOf(accs...)
+		require.ErrorContains(t, err, accs[1].StringLE())
+
+		ta.ress = []*result.Invoke{halt(stackitem.Make(100500)), halt(stackitem.Make(42))}
+		bals, err := tr.BalancesOf(accs...)
+		require.NoError(t, err)
+		require.Equal(t, []*big.Int{big.NewInt(100500), big.NewInt(42)}, bals)
+		require.Len(t, ta.calls, 2)
+		for i := range ta.calls {
+			require.Equal(t, util.Uint160{1, 2, 3}, ta.calls[i].Contract)
+			require.Equal(t, "balanceOf", ta.calls[i].Operation)
+			require.Equal(t, accs[i], ta.calls[i].Params[0].Value)
+		}
+	})
+}
+
 type tData struct {
 	someInt    int
 	someString string
@@ -5,6 +5,7 @@ import (
 
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/invoker"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/unwrap"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
@@ -18,7 +19,9 @@ type InfoClient interface {
 	GetContractStateByHash(hash util.Uint160) (*state.Contract, error)
 }
 
-// Info allows to get basic token info using RPC client.
+// Info allows to get basic token info using RPC client. If the client
+// implements invoker.RPCInvokeBatch, symbol and decimals are requested with
+// a single batch.
 func Info(c InfoClient, hash util.Uint160) (*wallet.Token, error) {
 	cs, err := c.GetContractStateByHash(hash)
 	if err != nil {
@@ -34,14 +37,20 @@ func Info(c InfoClient, hash util.Uint160) (*wallet.Token, error) {
 	if standard == "" {
 		return nil, fmt.Errorf("contract %s is not NEP-11/NEP17", hash.StringLE())
 	}
-	b := New(invoker.New(c, nil), hash)
-	symbol, err := b.Symbol()
+	res, err := invoker.New(c, nil).CallBatch([]invoker.FunctionCall{
+		{Contract: hash, Operation: "symbol"},
+		{Contract: hash, Operation: "decimals"},
+	})
 	if err != nil {
 		return nil, err
 	}
-	decimals, err := b.Decimals()
+	symbol, err := unwrap.PrintableASCIIString(res[0], nil)
 	if err != nil {
 		return nil, err
 	}
-	return wallet.NewToken(hash, cs.Manifest.Name, symbol, int64(decimals), standard), nil
+	decimals, err := unwrap.LimitedInt64(res[1], nil, 0, MaxValidDecimals)
+	if err != nil {
+		return nil, err
+	}
+	return wallet.NewToken(hash, cs.Manifest.Name, symbol, decimals, standard), nil
 }
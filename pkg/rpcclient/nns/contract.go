@@ -11,6 +11,7 @@ import (
 
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/invoker"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/nep11"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/unwrap"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
@@ -109,6 +110,14 @@ func (c *ContractReader) RootsExpanded(_numOfIteratorItems int) ([]string, error
 	return itemsToRoots(arr)
 }
 
+// IterateRoots is similar to Roots (uses the same contract method), but
+// returns a lazy iterator that works with any RPC server. It uses sessions if
+// they're available and falls back to iterator expansion otherwise, session
+// expiration is also handled transparently.
+func (c *ContractReader) IterateRoots() *invoker.Iterator[string] {
+	return invoker.NewIterator(c.invoker, itemToRoot, c.hash, "roots")
+}
+
 // GetPrice invokes `getPrice` method of contract.
 func (c *ContractReader) GetPrice(length uint8) (*big.Int, error) {
 	return unwrap.BigInt(c.invoker.Call(c.hash, "getPrice", length))
@@ -151,6 +160,14 @@ func (c *ContractReader) GetAllRecordsExpanded(name string, _numOfIteratorItems
 	return itemsToRecords(arr)
 }
 
+// IterateAllRecords is similar to GetAllRecords (uses the same contract
+// method), but returns a lazy iterator that works with any RPC server. It uses
+// sessions if they're available and falls back to iterator expansion
+// otherwise, session expiration is also handled transparently.
+func (c *ContractReader) IterateAllRecords(name string) *invoker.Iterator[RecordState] {
+	return invoker.NewIterator(c.invoker, itemToRecord, c.hash, "getAllRecords", name)
+}
+
 // Resolve invokes `resolve` method of contract.
 func (c *ContractReader) Resolve(name string, typev RecordType) (string, error) {
 	return unwrap.UTF8String(c.invoker.Call(c.hash, "resolve", name, int64(typev)))
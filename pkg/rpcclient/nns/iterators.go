@@ -34,19 +34,33 @@ func itemsToRecords(arr []stackitem.Item) ([]RecordState, error) {
 	return res, nil
 }
 
+func itemToRecord(itm stackitem.Item) (RecordState, error) {
+	var res RecordState
+	err := res.FromStackItem(itm)
+	return res, err
+}
+
 func itemsToRoots(arr []stackitem.Item) ([]string, error) {
 	res := make([]string, len(arr))
 	for i := range arr {
-		rs, ok := arr[i].Value().([]stackitem.Item)
-		if !ok {
-			return nil, errors.New("wrong number of elements")
+		root, err := itemToRoot(arr[i])
+		if err != nil {
+			return nil, err
 		}
-		myval, _ := rs[0].TryBytes()
-		res[i] = string(myval)
+		res[i] = root
 	}
 	return res, nil
 }
 
+func itemToRoot(itm stackitem.Item) (string, error) {
+	rs, ok := itm.Value().([]stackitem.Item)
+	if !ok {
+		return "", errors.New("wrong number of elements")
+	}
+	myval, _ := rs[0].TryBytes()
+	return string(myval), nil
+}
+
 // Next returns the next set of elements from the iterator (up to num of them).
 // It can return less than num elements in case iterator doesn't have that many
 // or zero elements if the iterator has no more elements or the session is
@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
 	"strings"
 
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativehashes"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
@@ -13,9 +15,11 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/actor"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/invoker"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
 )
 
@@ -43,6 +47,8 @@ type Actor struct {
 	reader   *ContractReader
 	sender   *wallet.Account
 	rpc      RPCActor
+	deposit  *DepositPolicy
+	depActor *actor.Actor
 }
 
 // ActorOptions are used to influence main and fallback actors as well as the
@@ -77,8 +83,43 @@ type ActorOptions struct {
 	// ValidUntilBlock transaction's field. Only override it if you know
 	// what you're doing.
 	MainModifier actor.TransactionModifier
+	// Deposit is an optional notary deposit management policy. If set, the
+	// deposit of the fallback transaction payer (FbSigner) is checked before
+	// sending every request with SendRequest (or Notarize) and topped up or
+	// extended when needed. It's nil (disabled) by default.
+	Deposit *DepositPolicy
 }
 
+// DepositPolicy is an automatic notary deposit management policy. Every
+// notary request needs some GAS deposited into the Notary contract by the
+// fallback transaction payer to cover fallback fees and this deposit must be
+// locked at least till the fallback transaction's ValidUntilBlock. If the
+// deposit doesn't satisfy these requirements (or the deposit balance is lower
+// than MinBalance) Actor creates a transaction (signed by FbSigner only, with
+// CalledByEntry scope) that transfers TopUpAmount GAS to the deposit and/or
+// extends its lock time, sends it and waits for it to be accepted before
+// sending a notary request. This requires the RPC client to support
+// transaction awaiting (see actor.Waiter).
+type DepositPolicy struct {
+	// MinBalance is the minimum deposit balance to be maintained, zero
+	// (or nil) means that the deposit is only topped up if it's not enough
+	// to pay for the fallback transaction.
+	MinBalance *big.Int
+	// TopUpAmount is the amount of GAS to be transferred to the deposit when
+	// it's topped up. It must be positive and it must not be less than the
+	// fallback transaction fee.
+	TopUpAmount *big.Int
+	// LockTill is the number of blocks (starting from the current chain's
+	// height) to lock the deposit for when it's topped up or its lock time
+	// is extended. DefaultDepositLockTill is used if it's zero. The deposit
+	// is always locked till (at least) the fallback's ValidUntilBlock.
+	LockTill uint32
+}
+
+// DefaultDepositLockTill is the default number of blocks the deposit is
+// locked for by DepositPolicy.
+const DefaultDepositLockTill = 5760
+
 // RPCActor is a set of methods required from RPC client to create Actor.
 type RPCActor interface {
 	actor.RPCActor
@@ -223,7 +264,32 @@ func newTunedActor(c RPCActor, signers []actor.SignerAccount, simpleAcc *wallet.
 	if err != nil {
 		return nil, err
 	}
-	return &Actor{*mainActor, *fbActor, opts.FbScript, reader, simpleAcc, c}, nil
+	var depActor *actor.Actor
+	if opts.Deposit != nil {
+		if opts.Deposit.TopUpAmount == nil || opts.Deposit.TopUpAmount.Sign() <= 0 {
+			return nil, errors.New("bad deposit policy: top up amount must be positive")
+		}
+		depActor, err = actor.New(c, []actor.SignerAccount{{
+			Signer: transaction.Signer{
+				Account: opts.FbSigner.Signer.Account,
+				Scopes:  transaction.CalledByEntry,
+			},
+			Account: opts.FbSigner.Account,
+		}})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &Actor{
+		Actor:    *mainActor,
+		FbActor:  *fbActor,
+		fbScript: opts.FbScript,
+		reader:   reader,
+		sender:   simpleAcc,
+		rpc:      c,
+		deposit:  opts.Deposit,
+		depActor: depActor,
+	}, nil
 }
 
 // Notarize is a simple wrapper for transaction-creating functions that allows to
@@ -253,8 +319,10 @@ func (a *Actor) Notarize(mainTx *transaction.Transaction, err error) (util.Uint2
 // transaction that will be adjusted in its NotValidBefore and Conflicts
 // attributes as well as ValidUntilBlock value. Conflicts is set to the main
 // transaction hash, while NotValidBefore is set to the middle of current mainTx
-// lifetime (between current block and ValidUntilBlock). The values returned are
-// main and fallback transaction hashes, ValidUntilBlock and error if any.
+// lifetime (between current block and ValidUntilBlock). If Actor has a
+// DepositPolicy set, the fallback payer deposit is checked (and adjusted if
+// needed, see TopUpDeposit) before sending the request. The values returned
+// are main and fallback transaction hashes, ValidUntilBlock and error if any.
 func (a *Actor) SendRequest(mainTx *transaction.Transaction, fbTx *transaction.Transaction) (util.Uint256, util.Uint256, uint32, error) {
 	var (
 		fbHash   util.Uint256
@@ -278,6 +346,12 @@ func (a *Actor) SendRequest(mainTx *transaction.Transaction, fbTx *transaction.T
 	fbTx.Attributes[1].Value = &transaction.NotValidBefore{Height: (height + vub) / 2}
 	fbTx.Attributes[2].Value = &transaction.Conflicts{Hash: mainHash}
 	fbTx.ValidUntilBlock = vub
+	if a.deposit != nil {
+		err = a.TopUpDeposit(fbTx.SystemFee+fbTx.NetworkFee, vub)
+		if err != nil {
+			return mainHash, fbHash, vub, err
+		}
+	}
 	err = a.FbActor.Sign(fbTx)
 	if err != nil {
 		return mainHash, fbHash, vub, err
@@ -317,6 +391,78 @@ func (a *Actor) SendRequestExactly(mainTx *transaction.Transaction, fbTx *transa
 	return mainHash, fbHash, vub, nil
 }
 
+// TopUpDeposit is a pre-flight deposit top-up, it checks the notary deposit of
+// the fallback transaction payer against the Actor DepositPolicy, it must have
+// at least fee GAS (and not less than DepositPolicy.MinBalance) and be locked
+// at least till the vub block. If it's not, a standalone top-up (or lock
+// extension) transaction is sent and awaited for, so it takes at least one
+// block. It can't be a part of the notary request itself, because the deposit
+// is checked when the request is submitted, way before the main transaction
+// is executed. It's called automatically by SendRequest, but can also be
+// used directly to prepare the deposit in advance (and avoid the delay on
+// request submission). An error is returned if the Actor has no
+// DepositPolicy.
+func (a *Actor) TopUpDeposit(fee int64, vub uint32) error {
+	if a.deposit == nil {
+		return errors.New("no deposit policy")
+	}
+	payer := a.depActor.Sender()
+	balance, err := a.reader.BalanceOf(payer)
+	if err != nil {
+		return fmt.Errorf("failed to get deposit balance: %w", err)
+	}
+	expiration, err := a.reader.ExpirationOf(payer)
+	if err != nil {
+		return fmt.Errorf("failed to get deposit expiration: %w", err)
+	}
+	height, err := a.GetBlockCount()
+	if err != nil {
+		return err
+	}
+	var (
+		minBalance = big.NewInt(fee)
+		lockTill   = a.deposit.LockTill
+	)
+	if a.deposit.MinBalance != nil && a.deposit.MinBalance.Cmp(minBalance) > 0 {
+		minBalance = a.deposit.MinBalance
+	}
+	if lockTill == 0 {
+		lockTill = DefaultDepositLockTill
+	}
+	var (
+		topUp = balance.Cmp(minBalance) < 0
+		lock  = expiration <= vub
+	)
+	if !topUp && !lock {
+		return nil
+	}
+	till := height + lockTill
+	if till <= vub {
+		till = vub + 1
+	}
+	if till < expiration {
+		till = expiration
+	}
+	var script []byte
+	if topUp {
+		script, err = smartcontract.CreateCallWithAssertScript(nativehashes.GasToken, "transfer",
+			payer, Hash, a.deposit.TopUpAmount, &OnNEP17PaymentData{Account: &payer, Till: till})
+		if err != nil {
+			return fmt.Errorf("failed to create deposit script: %w", err)
+		}
+	} else {
+		script = lockScript(payer, till)
+	}
+	res, err := a.depActor.Wait(a.depActor.SendRun(script))
+	if err != nil {
+		return fmt.Errorf("failed to update deposit: %w", err)
+	}
+	if res.VMState != vmstate.Halt {
+		return fmt.Errorf("deposit update transaction failed: %s", res.FaultException)
+	}
+	return nil
+}
+
 // Wait waits until main or fallback transaction will be accepted to the chain and returns
 // the resulting application execution result or actor.ErrTxNotAccepted if both transactions
 // failed to persist. Wait can be used if underlying Actor supports transaction awaiting,
@@ -3,6 +3,7 @@ package notary
 import (
 	"context"
 	"errors"
+	"math/big"
 	"testing"
 
 	"github.com/google/uuid"
@@ -36,6 +37,7 @@ type RPCClient struct {
 	nhash   util.Uint256
 	mirror  bool
 	applog  *result.ApplicationLog
+	sent    int
 }
 
 func (r *RPCClient) InvokeContractVerify(contract util.Uint160, params []smartcontract.Parameter, signers []transaction.Signer, witnesses ...transaction.Witness) (*result.Invoke, error) {
@@ -58,6 +60,7 @@ func (r *RPCClient) GetVersion() (*result.Version, error) {
 	return &verCopy, r.err
 }
 func (r *RPCClient) SendRawTransaction(tx *transaction.Transaction) (util.Uint256, error) {
+	r.sent++
 	return r.hash, r.err
 }
 func (r *RPCClient) SubmitP2PNotaryRequest(req *payload.P2PNotaryRequest) (util.Uint256, error) {
@@ -585,3 +588,85 @@ func TestWait(t *testing.T) {
 		Execution: ex,
 	}, res)
 }
+
+func TestTopUpDeposit(t *testing.T) {
+	rc := &RPCClient{
+		version: &result.Version{
+			Protocol: result.Protocol{
+				Network:              netmode.UnitTestNet,
+				MillisecondsPerBlock: 1,
+				ValidatorsCount:      7,
+			},
+		},
+		bCount: 42,
+	}
+
+	acc, err := wallet.NewAccount()
+	require.NoError(t, err)
+	signers := []actor.SignerAccount{{
+		Signer: transaction.Signer{
+			Account: acc.Contract.ScriptHash(),
+			Scopes:  transaction.None,
+		},
+		Account: acc,
+	}}
+
+	act, err := NewActor(rc, signers, acc)
+	require.NoError(t, err)
+	require.Error(t, act.TopUpDeposit(10, 50))
+
+	opts := NewDefaultActorOptions(NewReader(invoker.New(rc, nil)), acc)
+	opts.Deposit = &DepositPolicy{}
+	_, err = NewTunedActor(rc, signers, opts)
+	require.Error(t, err)
+
+	opts.Deposit = &DepositPolicy{
+		MinBalance:  big.NewInt(20),
+		TopUpAmount: big.NewInt(100),
+	}
+	act, err = NewTunedActor(rc, signers, opts)
+	require.NoError(t, err)
+
+	setDeposit := func(v int64) {
+		rc.invRes = &result.Invoke{
+			State:       "HALT",
+			GasConsumed: 3,
+			Script:      []byte{byte(opcode.RET)},
+			Stack:       []stackitem.Item{stackitem.Make(v)},
+		}
+	}
+	setApplog := func(st vmstate.State) {
+		rc.applog = &result.ApplicationLog{
+			IsTransaction: true,
+			Executions: []state.Execution{{
+				Trigger: trigger.Application,
+				VMState: st,
+			}},
+		}
+	}
+
+	// Good enough deposit, nothing to do.
+	setDeposit(100)
+	require.NoError(t, act.TopUpDeposit(10, 50))
+	require.Equal(t, 0, rc.sent)
+
+	// Deposit is locked for not long enough.
+	setApplog(vmstate.Halt)
+	require.NoError(t, act.TopUpDeposit(10, 100))
+	require.Equal(t, 1, rc.sent)
+
+	// Balance is lower than the fee.
+	require.NoError(t, act.TopUpDeposit(200, 50))
+	require.Equal(t, 2, rc.sent)
+
+	// Balance is lower than the minimum.
+	setDeposit(15)
+	setApplog(vmstate.Fault)
+	require.Error(t, act.TopUpDeposit(10, 30))
+	require.Equal(t, 3, rc.sent)
+
+	// Reader error.
+	rc.invRes.State = "FAULT"
+	require.Error(t, act.TopUpDeposit(10, 30))
+	require.Equal(t, 3, rc.sent)
+}
@@ -0,0 +1,70 @@
+package notary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// DefaultRequestPollInterval is the default interval between notary request
+// state checks used by WaitRequest.
+const DefaultRequestPollInterval = time.Second
+
+// ErrRequestRemoved is returned from WaitRequest if the notary request was
+// removed from the notary service before being completed, it happens when
+// main transaction is accepted to the chain (it may be completed by some
+// other notary node), when all fallbacks are sent or when all of them expire.
+var ErrRequestRemoved = errors.New("notary request was removed")
+
+// RequestReader is an RPC client interface needed to inspect notary requests
+// processed by the notary service of the RPC node (it's implemented by
+// rpcclient.Client).
+type RequestReader interface {
+	GetNotaryRequest(mainHash util.Uint256) (*result.NotaryRequest, error)
+}
+
+// WaitRequest waits until the notary request for the main transaction with the
+// given hash is completed by the notary service of the RPC node: either the
+// main transaction has all signatures collected and it's sent to the network
+// or some fallback transaction is sent. It polls the RPC node every
+// pollInterval (DefaultRequestPollInterval is used if it's not positive) and
+// returns the final request state. The request is waited for if it's not yet
+// known to the node, but if it's removed before being completed, the last
+// known state is returned with ErrRequestRemoved. Notice that completed
+// transactions still can fail to be accepted to the chain, use Actor.Wait to
+// get the execution result.
+func WaitRequest(ctx context.Context, c RequestReader, mainHash util.Uint256, pollInterval time.Duration) (*result.NotaryRequest, error) {
+	if pollInterval <= 0 {
+		pollInterval = DefaultRequestPollInterval
+	}
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	var last *result.NotaryRequest
+	for {
+		select {
+		case <-ctx.Done():
+			return last, ctx.Err()
+		case <-timer.C:
+		}
+		req, err := c.GetNotaryRequest(mainHash)
+		switch {
+		case errors.Is(err, neorpc.ErrUnknownTransaction):
+			if last != nil {
+				return last, ErrRequestRemoved
+			}
+		case err != nil:
+			return last, fmt.Errorf("failed to get notary request: %w", err)
+		case req.IsCompleted():
+			return req, nil
+		default:
+			last = req
+		}
+		timer.Reset(pollInterval)
+	}
+}
@@ -0,0 +1,90 @@
+package notary
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+type requestReader struct {
+	calls int
+	resps []*result.NotaryRequest
+	errs  []error
+}
+
+func (r *requestReader) GetNotaryRequest(mainHash util.Uint256) (*result.NotaryRequest, error) {
+	i := r.calls
+	if i >= len(r.resps) {
+		i = len(r.resps) - 1
+	}
+	r.calls++
+	return r.resps[i], r.errs[i]
+}
+
+func TestWaitRequest(t *testing.T) {
+	var (
+		h       = util.Uint256{1, 2, 3}
+		pending = &result.NotaryRequest{Hash: h, Valid: true, SignaturesLeft: 1}
+		sent    = &result.NotaryRequest{Hash: h, Valid: true, Sent: true}
+		fbSent  = &result.NotaryRequest{Hash: h, SentFallbacks: []result.NotaryFallback{{Hash: util.Uint256{4}}}}
+		unknown = neorpc.WrapErrorWithData(neorpc.ErrUnknownTransaction, "notary request is not found")
+	)
+
+	t.Run("main sent", func(t *testing.T) {
+		r := &requestReader{
+			resps: []*result.NotaryRequest{nil, pending, sent},
+			errs:  []error{unknown, nil, nil},
+		}
+		res, err := WaitRequest(context.Background(), r, h, time.Millisecond)
+		require.NoError(t, err)
+		require.Equal(t, sent, res)
+		require.Equal(t, 3, r.calls)
+	})
+
+	t.Run("fallback sent", func(t *testing.T) {
+		r := &requestReader{
+			resps: []*result.NotaryRequest{fbSent},
+			errs:  []error{nil},
+		}
+		res, err := WaitRequest(context.Background(), r, h, 0)
+		require.NoError(t, err)
+		require.Equal(t, fbSent, res)
+	})
+
+	t.Run("removed", func(t *testing.T) {
+		r := &requestReader{
+			resps: []*result.NotaryRequest{pending, nil},
+			errs:  []error{nil, unknown},
+		}
+		res, err := WaitRequest(context.Background(), r, h, time.Millisecond)
+		require.ErrorIs(t, err, ErrRequestRemoved)
+		require.Equal(t, pending, res)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		r := &requestReader{
+			resps: []*result.NotaryRequest{nil},
+			errs:  []error{neorpc.ErrNotaryDisabled},
+		}
+		_, err := WaitRequest(context.Background(), r, h, time.Millisecond)
+		require.ErrorIs(t, err, neorpc.ErrNotaryDisabled)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		r := &requestReader{
+			resps: []*result.NotaryRequest{pending},
+			errs:  []error{nil},
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		res, err := WaitRequest(ctx, r, h, time.Millisecond)
+		require.True(t, errors.Is(err, context.DeadlineExceeded))
+		require.Equal(t, pending, res)
+	})
+}
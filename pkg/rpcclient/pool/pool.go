@@ -0,0 +1,472 @@
+/*
+Package pool provides an RPC client working with a set of RPC nodes.
+
+Pool distributes calls between nodes in a round-robin fashion, skipping nodes
+that are not available or lag behind others (nodes are checked periodically
+and every transport-level failure makes the node unavailable until the next
+successful check). Failed calls are retried with other nodes, while errors
+returned by RPC servers are passed to the caller as is. Iterator sessions are
+node-specific, so TraverseIterator and TerminateSession calls are routed to
+the node that has created the session.
+
+Pool implements invoker.RPCInvoke, invoker.RPCInvokeHistoric and
+actor.RPCActor interfaces, so it can be used to create Invoker and Actor
+instances (and any contract wrappers based on them).
+*/
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+const (
+	// DefaultCheckInterval is the default Options.CheckInterval value.
+	DefaultCheckInterval = 5 * time.Second
+	// DefaultSessionTimeout is the default Options.SessionTimeout value.
+	DefaultSessionTimeout = time.Minute
+)
+
+// ErrNoHealthyNodes is returned when there are no nodes to make a call with.
+var ErrNoHealthyNodes = errors.New("no healthy nodes")
+
+// RPC is a set of RPC methods every pool node must implement, it's
+// implemented by rpcclient.Client and rpcclient.WSClient.
+type RPC interface {
+	CalculateNetworkFee(tx *transaction.Transaction) (int64, error)
+	Close()
+	GetApplicationLog(hash util.Uint256, trig *trigger.Type) (*result.ApplicationLog, error)
+	GetBlockCount() (uint32, error)
+	GetVersion() (*result.Version, error)
+	InvokeContractVerify(contract util.Uint160, params []smartcontract.Parameter, signers []transaction.Signer, witnesses ...transaction.Witness) (*result.Invoke, error)
+	InvokeContractVerifyAtHeight(height uint32, contract util.Uint160, params []smartcontract.Parameter, signers []transaction.Signer, witnesses ...transaction.Witness) (*result.Invoke, error)
+	InvokeContractVerifyWithState(stateroot util.Uint256, contract util.Uint160, params []smartcontract.Parameter, signers []transaction.Signer, witnesses ...transaction.Witness) (*result.Invoke, error)
+	InvokeFunction(contract util.Uint160, operation string, params []smartcontract.Parameter, signers []transaction.Signer) (*result.Invoke, error)
+	InvokeFunctionAtHeight(height uint32, contract util.Uint160, operation string, params []smartcontract.Parameter, signers []transaction.Signer) (*result.Invoke, error)
+	InvokeFunctionWithState(stateroot util.Uint256, contract util.Uint160, operation string, params []smartcontract.Parameter, signers []transaction.Signer) (*result.Invoke, error)
+	InvokeScript(script []byte, signers []transaction.Signer) (*result.Invoke, error)
+	InvokeScriptAtHeight(height uint32, script []byte, signers []transaction.Signer) (*result.Invoke, error)
+	InvokeScriptWithState(stateroot util.Uint256, script []byte, signers []transaction.Signer) (*result.Invoke, error)
+	SendRawTransaction(tx *transaction.Transaction) (util.Uint256, error)
+	TerminateSession(sessionID uuid.UUID) (bool, error)
+	TraverseIterator(sessionID, iteratorID uuid.UUID, maxItemsCount int) ([]stackitem.Item, error)
+}
+
+// Options contains Pool parameters.
+type Options struct {
+	// CheckInterval is the interval between node health checks,
+	// DefaultCheckInterval is used if not set.
+	CheckInterval time.Duration
+	// MaxLag is the number of blocks node can lag behind the highest one
+	// to still be used. Zero value means that only nodes with the highest
+	// block are used.
+	MaxLag uint32
+	// SessionTimeout is the time an iterator session is tracked for since its
+	// last use, it should be no less than SessionExpirationTime setting of
+	// the nodes. DefaultSessionTimeout is used if not set.
+	SessionTimeout time.Duration
+}
+
+// NodeStatus contains the node state known to the Pool.
+type NodeStatus struct {
+	// Index is the node index in the list passed to New.
+	Index int
+	// Healthy is false if the last check or call has failed.
+	Healthy bool
+	// Height is the node block height (block count minus one) obtained by
+	// the last successful check.
+	Height uint32
+	// Err is the last transport error, it's nil for healthy nodes.
+	Err error
+}
+
+// Pool is an RPC client using a set of nodes. It's safe for concurrent use.
+type Pool struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	opts   Options
+	nodes  []RPC
+	next   atomic.Uint32
+	done   chan struct{}
+
+	lock   sync.RWMutex
+	status []NodeStatus
+
+	sessionsLock sync.Mutex
+	sessions     map[uuid.UUID]session
+}
+
+// session is an iterator session bound to some node.
+type session struct {
+	node     int
+	lastUsed time.Time
+}
+
+// New creates a Pool using the given clients and starts node health checks.
+// Nodes are checked once before returning, an error is returned if none of
+// them is available.
+func New(ctx context.Context, nodes []RPC, opts Options) (*Pool, error) {
+	if len(nodes) == 0 {
+		return nil, errors.New("no nodes")
+	}
+	if opts.CheckInterval <= 0 {
+		opts.CheckInterval = DefaultCheckInterval
+	}
+	if opts.SessionTimeout <= 0 {
+		opts.SessionTimeout = DefaultSessionTimeout
+	}
+	p := &Pool{
+		opts:     opts,
+		nodes:    nodes,
+		done:     make(chan struct{}),
+		status:   make([]NodeStatus, len(nodes)),
+		sessions: make(map[uuid.UUID]session),
+	}
+	p.ctx, p.cancel = context.WithCancel(ctx)
+	for i := range p.status {
+		p.status[i].Index = i
+	}
+	p.check()
+	if len(p.candidates()) == 0 {
+		p.cancel()
+		return nil, fmt.Errorf("%w: %w", ErrNoHealthyNodes, p.lastErr())
+	}
+	go p.run()
+	return p, nil
+}
+
+// Dial creates rpcclient.Client for every endpoint and returns a Pool using
+// them. Endpoints that can't be used at the moment are still added to the
+// Pool (they're used when they become available).
+func Dial(ctx context.Context, endpoints []string, cliOpts rpcclient.Options, opts Options) (*Pool, error) {
+	nodes := make([]RPC, 0, len(endpoints))
+	for _, e := range endpoints {
+		c, err := rpcclient.New(ctx, e, cliOpts)
+		if err != nil {
+			for _, n := range nodes {
+				n.Close()
+			}
+			return nil, fmt.Errorf("endpoint %s: %w", e, err)
+		}
+		nodes = append(nodes, c)
+	}
+	p, err := New(ctx, nodes, opts)
+	if err != nil {
+		for _, n := range nodes {
+			n.Close()
+		}
+		return nil, err
+	}
+	return p, nil
+}
+
+// Close stops health checks and closes all node clients.
+func (p *Pool) Close() {
+	p.cancel()
+	<-p.done
+	for _, n := range p.nodes {
+		n.Close()
+	}
+}
+
+// Context returns the Pool context that is cancelled on Close, it's used by
+// Actor to wait for transactions.
+func (p *Pool) Context() context.Context {
+	return p.ctx
+}
+
+// Status returns the current state of all nodes.
+func (p *Pool) Status() []NodeStatus {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	res := make([]NodeStatus, len(p.status))
+	copy(res, p.status)
+	return res
+}
+
+func (p *Pool) run() {
+	defer close(p.done)
+	ticker := time.NewTicker(p.opts.CheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+			p.check()
+			p.pruneSessions()
+		}
+	}
+}
+
+// check updates the status of all nodes.
+func (p *Pool) check() {
+	var wg sync.WaitGroup
+	for i := range p.nodes {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			count, err := p.nodes[i].GetBlockCount()
+			p.lock.Lock()
+			defer p.lock.Unlock()
+			st := &p.status[i]
+			st.Healthy = err == nil
+			st.Err = err
+			if err == nil && count > 0 {
+				st.Height = count - 1
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func (p *Pool) lastErr() error {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	for _, st := range p.status {
+		if st.Err != nil {
+			return st.Err
+		}
+	}
+	return nil
+}
+
+// candidates returns indexes of nodes that can be used for a call, the
+// first one is picked in a round-robin fashion, others are the fallbacks.
+func (p *Pool) candidates() []int {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	var best uint32
+	for _, st := range p.status {
+		if st.Healthy && st.Height > best {
+			best = st.Height
+		}
+	}
+	var eligible = make([]int, 0, len(p.status))
+	for _, st := range p.status {
+		if st.Healthy && st.Height+p.opts.MaxLag >= best {
+			eligible = append(eligible, st.Index)
+		}
+	}
+	if len(eligible) == 0 {
+		return nil
+	}
+	var (
+		res   = make([]int, 0, len(eligible))
+		start = int(p.next.Add(1) % uint32(len(eligible)))
+	)
+	res = append(res, eligible[start:]...)
+	res = append(res, eligible[:start]...)
+	return res
+}
+
+// fail marks the node as unavailable until the next check.
+func (p *Pool) fail(i int, err error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.status[i].Healthy = false
+	p.status[i].Err = err
+}
+
+// isServerError checks whether the error is returned by the RPC server (so
+// it makes no sense to retry the call with other nodes).
+func isServerError(err error) bool {
+	var rpcErr *neorpc.Error
+	return errors.As(err, &rpcErr)
+}
+
+// call makes a call with the first available node trying others on failure.
+// It returns the result and the index of the node used.
+func call[T any](p *Pool, f func(RPC) (T, error)) (T, int, error) {
+	var (
+		res T
+		err = ErrNoHealthyNodes
+	)
+	for _, i := range p.candidates() {
+		res, err = f(p.nodes[i])
+		if err == nil || isServerError(err) {
+			return res, i, err
+		}
+		p.fail(i, err)
+	}
+	return res, -1, err
+}
+
+func (p *Pool) invoke(f func(RPC) (*result.Invoke, error)) (*result.Invoke, error) {
+	res, i, err := call(p, f)
+	if err == nil && res.Session != uuid.Nil {
+		p.sessionsLock.Lock()
+		p.sessions[res.Session] = session{node: i, lastUsed: time.Now()}
+		p.sessionsLock.Unlock()
+	}
+	return res, err
+}
+
+// sessionNode returns the node the session is bound to.
+func (p *Pool) sessionNode(id uuid.UUID) (RPC, bool) {
+	p.sessionsLock.Lock()
+	defer p.sessionsLock.Unlock()
+	s, ok := p.sessions[id]
+	if !ok {
+		return nil, false
+	}
+	s.lastUsed = time.Now()
+	p.sessions[id] = s
+	return p.nodes[s.node], true
+}
+
+func (p *Pool) pruneSessions() {
+	p.sessionsLock.Lock()
+	defer p.sessionsLock.Unlock()
+	for id, s := range p.sessions {
+		if time.Since(s.lastUsed) > p.opts.SessionTimeout {
+			delete(p.sessions, id)
+		}
+	}
+}
+
+// CalculateNetworkFee implements actor.RPCActor interface.
+func (p *Pool) CalculateNetworkFee(tx *transaction.Transaction) (int64, error) {
+	res, _, err := call(p, func(c RPC) (int64, error) {
+		return c.CalculateNetworkFee(tx)
+	})
+	return res, err
+}
+
+// GetApplicationLog returns a contract log based on the specified txid or
+// block hash, it's used by Actor to wait for transactions.
+func (p *Pool) GetApplicationLog(hash util.Uint256, trig *trigger.Type) (*result.ApplicationLog, error) {
+	res, _, err := call(p, func(c RPC) (*result.ApplicationLog, error) {
+		return c.GetApplicationLog(hash, trig)
+	})
+	return res, err
+}
+
+// GetBlockCount implements actor.RPCActor interface.
+func (p *Pool) GetBlockCount() (uint32, error) {
+	res, _, err := call(p, func(c RPC) (uint32, error) {
+		return c.GetBlockCount()
+	})
+	return res, err
+}
+
+// GetVersion implements actor.RPCActor interface.
+func (p *Pool) GetVersion() (*result.Version, error) {
+	res, _, err := call(p, func(c RPC) (*result.Version, error) {
+		return c.GetVersion()
+	})
+	return res, err
+}
+
+// SendRawTransaction implements actor.RPCActor interface. The transaction is
+// sent to the first available node, other nodes are tried only if it fails
+// at the transport level.
+func (p *Pool) SendRawTransaction(tx *transaction.Transaction) (util.Uint256, error) {
+	res, _, err := call(p, func(c RPC) (util.Uint256, error) {
+		return c.SendRawTransaction(tx)
+	})
+	return res, err
+}
+
+// InvokeContractVerify implements invoker.RPCInvoke interface.
+func (p *Pool) InvokeContractVerify(contract util.Uint160, params []smartcontract.Parameter, signers []transaction.Signer, witnesses ...transaction.Witness) (*result.Invoke, error) {
+	return p.invoke(func(c RPC) (*result.Invoke, error) {
+		return c.InvokeContractVerify(contract, params, signers, witnesses...)
+	})
+}
+
+// InvokeContractVerifyAtHeight implements invoker.RPCInvokeHistoric interface.
+func (p *Pool) InvokeContractVerifyAtHeight(height uint32, contract util.Uint160, params []smartcontract.Parameter, signers []transaction.Signer, witnesses ...transaction.Witness) (*result.Invoke, error) {
+	return p.invoke(func(c RPC) (*result.Invoke, error) {
+		return c.InvokeContractVerifyAtHeight(height, contract, params, signers, witnesses...)
+	})
+}
+
+// InvokeContractVerifyWithState implements invoker.RPCInvokeHistoric interface.
+func (p *Pool) InvokeContractVerifyWithState(stateroot util.Uint256, contract util.Uint160, params []smartcontract.Parameter, signers []transaction.Signer, witnesses ...transaction.Witness) (*result.Invoke, error) {
+	return p.invoke(func(c RPC) (*result.Invoke, error) {
+		return c.InvokeContractVerifyWithState(stateroot, contract, params, signers, witnesses...)
+	})
+}
+
+// InvokeFunction implements invoker.RPCInvoke interface.
+func (p *Pool) InvokeFunction(contract util.Uint160, operation string, params []smartcontract.Parameter, signers []transaction.Signer) (*result.Invoke, error) {
+	return p.invoke(func(c RPC) (*result.Invoke, error) {
+		return c.InvokeFunction(contract, operation, params, signers)
+	})
+}
+
+// InvokeFunctionAtHeight implements invoker.RPCInvokeHistoric interface.
+func (p *Pool) InvokeFunctionAtHeight(height uint32, contract util.Uint160, operation string, params []smartcontract.Parameter, signers []transaction.Signer) (*result.Invoke, error) {
+	return p.invoke(func(c RPC) (*result.Invoke, error) {
+		return c.InvokeFunctionAtHeight(height, contract, operation, params, signers)
+	})
+}
+
+// InvokeFunctionWithState implements invoker.RPCInvokeHistoric interface.
+func (p *Pool) InvokeFunctionWithState(stateroot util.Uint256, contract util.Uint160, operation string, params []smartcontract.Parameter, signers []transaction.Signer) (*result.Invoke, error) {
+	return p.invoke(func(c RPC) (*result.Invoke, error) {
+		return c.InvokeFunctionWithState(stateroot, contract, operation, params, signers)
+	})
+}
+
+// InvokeScript implements invoker.RPCInvoke interface.
+func (p *Pool) InvokeScript(script []byte, signers []transaction.Signer) (*result.Invoke, error) {
+	return p.invoke(func(c RPC) (*result.Invoke, error) {
+		return c.InvokeScript(script, signers)
+	})
+}
+
+// InvokeScriptAtHeight implements invoker.RPCInvokeHistoric interface.
+func (p *Pool) InvokeScriptAtHeight(height uint32, script []byte, signers []transaction.Signer) (*result.Invoke, error) {
+	return p.invoke(func(c RPC) (*result.Invoke, error) {
+		return c.InvokeScriptAtHeight(height, script, signers)
+	})
+}
+
+// InvokeScriptWithState implements invoker.RPCInvokeHistoric interface.
+func (p *Pool) InvokeScriptWithState(stateroot util.Uint256, script []byte, signers []transaction.Signer) (*result.Invoke, error) {
+	return p.invoke(func(c RPC) (*result.Invoke, error) {
+		return c.InvokeScriptWithState(stateroot, script, signers)
+	})
+}
+
+// TerminateSession implements invoker.RPCSessions interface. The call is
+// made with the node that has created the session.
+func (p *Pool) TerminateSession(sessionID uuid.UUID) (bool, error) {
+	c, ok := p.sessionNode(sessionID)
+	if !ok {
+		return false, fmt.Errorf("%w: unknown session %s", neorpc.ErrUnknownSession, sessionID)
+	}
+	res, err := c.TerminateSession(sessionID)
+	if err == nil || isServerError(err) {
+		p.sessionsLock.Lock()
+		delete(p.sessions, sessionID)
+		p.sessionsLock.Unlock()
+	}
+	return res, err
+}
+
+// TraverseIterator implements invoker.RPCSessions interface. The call is
+// made with the node that has created the session.
+func (p *Pool) TraverseIterator(sessionID, iteratorID uuid.UUID, maxItemsCount int) ([]stackitem.Item, error) {
+	c, ok := p.sessionNode(sessionID)
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown session %s", neorpc.ErrUnknownSession, sessionID)
+	}
+	return c.TraverseIterator(sessionID, iteratorID, maxItemsCount)
+}
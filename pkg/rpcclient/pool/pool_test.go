@@ -0,0 +1,261 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/actor"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/invoker"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ RPC                       = (*rpcclient.Client)(nil)
+	_ RPC                       = (*rpcclient.WSClient)(nil)
+	_ actor.RPCActor            = (*Pool)(nil)
+	_ invoker.RPCInvokeHistoric = (*Pool)(nil)
+)
+
+var errConn = errors.New("connection refused")
+
+type testNode struct {
+	RPC
+
+	lock     sync.Mutex
+	count    uint32
+	err      error
+	calls    int
+	sessions map[uuid.UUID]bool
+	closed   bool
+}
+
+func (n *testNode) call() error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.calls++
+	return n.err
+}
+
+func (n *testNode) set(count uint32, err error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.count = count
+	n.err = err
+}
+
+func (n *testNode) reset() int {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	c := n.calls
+	n.calls = 0
+	return c
+}
+
+func (n *testNode) Close() {
+	n.closed = true
+}
+
+func (n *testNode) GetBlockCount() (uint32, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.count, n.err
+}
+
+func (n *testNode) GetVersion() (*result.Version, error) {
+	return &result.Version{}, n.call()
+}
+
+func (n *testNode) SendRawTransaction(tx *transaction.Transaction) (util.Uint256, error) {
+	if err := n.call(); err != nil {
+		return util.Uint256{}, err
+	}
+	return util.Uint256{}, neorpc.ErrInsufficientFunds
+}
+
+func (n *testNode) InvokeScript(script []byte, signers []transaction.Signer) (*result.Invoke, error) {
+	if err := n.call(); err != nil {
+		return nil, err
+	}
+	res := &result.Invoke{State: "HALT"}
+	if len(script) > 0 {
+		res.Session = uuid.New()
+		n.lock.Lock()
+		n.sessions[res.Session] = true
+		n.lock.Unlock()
+	}
+	return res, nil
+}
+
+func (n *testNode) TraverseIterator(sessionID, iteratorID uuid.UUID, maxItemsCount int) ([]stackitem.Item, error) {
+	if err := n.call(); err != nil {
+		return nil, err
+	}
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if !n.sessions[sessionID] {
+		return nil, neorpc.ErrUnknownSession
+	}
+	return []stackitem.Item{stackitem.Make(1)}, nil
+}
+
+func (n *testNode) TerminateSession(sessionID uuid.UUID) (bool, error) {
+	if err := n.call(); err != nil {
+		return false, err
+	}
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	ok := n.sessions[sessionID]
+	delete(n.sessions, sessionID)
+	return ok, nil
+}
+
+func newTestPool(t *testing.T, opts Options, counts ...uint32) (*Pool, []*testNode) {
+	var (
+		nodes = make([]*testNode, len(counts))
+		rpcs  = make([]RPC, len(counts))
+	)
+	for i := range counts {
+		nodes[i] = &testNode{count: counts[i], sessions: make(map[uuid.UUID]bool)}
+		rpcs[i] = nodes[i]
+	}
+	p, err := New(context.Background(), rpcs, opts)
+	require.NoError(t, err)
+	t.Cleanup(p.Close)
+	return p, nodes
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(context.Background(), nil, Options{})
+	require.Error(t, err)
+
+	n := &testNode{err: errConn}
+	_, err = New(context.Background(), []RPC{n}, Options{})
+	require.ErrorIs(t, err, ErrNoHealthyNodes)
+	require.ErrorIs(t, err, errConn)
+
+	p, nodes := newTestPool(t, Options{}, 10, 10)
+	p.Close()
+	require.True(t, nodes[0].closed)
+	require.True(t, nodes[1].closed)
+	require.Error(t, p.Context().Err())
+}
+
+func TestPool_RoundRobin(t *testing.T) {
+	p, nodes := newTestPool(t, Options{MaxLag: 1}, 10, 11, 9, 11)
+	require.Equal(t, []NodeStatus{
+		{Index: 0, Healthy: true, Height: 9},
+		{Index: 1, Healthy: true, Height: 10},
+		{Index: 2, Healthy: true, Height: 8},
+		{Index: 3, Healthy: true, Height: 10},
+	}, p.Status())
+
+	for i := 0; i < 30; i++ {
+		_, err := p.GetVersion()
+		require.NoError(t, err)
+	}
+	require.Equal(t, 10, nodes[0].reset())
+	require.Equal(t, 10, nodes[1].reset())
+	require.Equal(t, 0, nodes[2].reset(), "lagging node is used")
+	require.Equal(t, 10, nodes[3].reset())
+}
+
+func TestPool_Failover(t *testing.T) {
+	p, nodes := newTestPool(t, Options{CheckInterval: 10 * time.Millisecond}, 10, 10)
+
+	nodes[0].set(10, errConn)
+	for i := 0; i < 4; i++ {
+		_, err := p.GetVersion()
+		require.NoError(t, err)
+	}
+	require.Equal(t, 1, nodes[0].reset(), "failed node is used after failure")
+	require.Equal(t, 4, nodes[1].reset())
+	st := p.Status()
+	require.False(t, st[0].Healthy)
+	require.ErrorIs(t, st[0].Err, errConn)
+
+	// Server errors are not retried.
+	_, err := p.SendRawTransaction(transaction.New([]byte{1}, 0))
+	require.ErrorIs(t, err, neorpc.ErrInsufficientFunds)
+	require.Equal(t, 1, nodes[1].reset())
+
+	// No nodes left.
+	nodes[1].set(10, errConn)
+	_, err = p.GetVersion()
+	require.ErrorIs(t, err, errConn)
+	_, err = p.GetVersion()
+	require.ErrorIs(t, err, ErrNoHealthyNodes)
+
+	// Nodes are back after the check.
+	nodes[0].set(11, nil)
+	nodes[1].set(11, nil)
+	require.Eventually(t, func() bool {
+		st := p.Status()
+		return st[0].Healthy && st[1].Healthy
+	}, time.Second, 10*time.Millisecond)
+	_, err = p.GetVersion()
+	require.NoError(t, err)
+}
+
+func TestPool_Sessions(t *testing.T) {
+	p, nodes := newTestPool(t, Options{}, 10, 10, 10)
+
+	var ids []uuid.UUID
+	for i := 0; i < 3; i++ {
+		res, err := p.InvokeScript([]byte{1}, nil)
+		require.NoError(t, err)
+		ids = append(ids, res.Session)
+	}
+	for i := 0; i < 3; i++ {
+		for _, id := range ids {
+			items, err := p.TraverseIterator(id, uuid.New(), 10)
+			require.NoError(t, err)
+			require.Len(t, items, 1)
+		}
+	}
+	for _, id := range ids {
+		ok, err := p.TerminateSession(id)
+		require.NoError(t, err)
+		require.True(t, ok)
+	}
+	for _, n := range nodes {
+		require.Equal(t, 1+3+1, n.reset())
+	}
+	_, err := p.TraverseIterator(ids[0], uuid.New(), 10)
+	require.ErrorIs(t, err, neorpc.ErrUnknownSession)
+	_, err = p.TerminateSession(ids[0])
+	require.ErrorIs(t, err, neorpc.ErrUnknownSession)
+
+	// Sessions are not failed over.
+	res, err := p.InvokeScript([]byte{1}, nil)
+	require.NoError(t, err)
+	for _, n := range nodes {
+		if n.reset() != 0 {
+			n.set(10, errConn)
+		}
+	}
+	_, err = p.TraverseIterator(res.Session, uuid.New(), 10)
+	require.ErrorIs(t, err, errConn)
+}
+
+func TestPool_SessionTimeout(t *testing.T) {
+	p, _ := newTestPool(t, Options{CheckInterval: 10 * time.Millisecond, SessionTimeout: 50 * time.Millisecond}, 10)
+
+	res, err := p.InvokeScript([]byte{1}, nil)
+	require.NoError(t, err)
+	res2, err := p.InvokeScript(nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, uuid.Nil, res2.Session)
+	time.Sleep(200 * time.Millisecond)
+	_, err = p.TraverseIterator(res.Session, uuid.New(), 10)
+	require.ErrorIs(t, err, neorpc.ErrUnknownSession)
+}
@@ -264,6 +264,24 @@ func (c *Client) getContractState(param any) (*state.Contract, error) {
 	return resp, nil
 }
 
+// GetFeeEstimate returns suggested fee-per-byte values for different
+// transaction priorities based on the current memory pool state and the
+// given number of recent blocks (server default is used if it's 0). It's a
+// NeoGo extension.
+func (c *Client) GetFeeEstimate(blocks int) (*result.FeeEstimate, error) {
+	var (
+		params []any
+		resp   = new(result.FeeEstimate)
+	)
+	if blocks != 0 {
+		params = []any{blocks}
+	}
+	if err := c.performRequest("getfeeestimate", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetNativeContracts queries information about native contracts.
 func (c *Client) GetNativeContracts() ([]state.Contract, error) {
 	var resp []state.Contract
@@ -780,22 +798,9 @@ func (c *Client) InvokeContractVerifyWithState(stateOrBlock util.Uint256, contra
 // invokeSomething is an inner wrapper for Invoke* functions.
 func (c *Client) invokeSomething(method string, p []any, signers []transaction.Signer, witnesses ...transaction.Witness) (*result.Invoke, error) {
 	var resp = new(result.Invoke)
-	if signers != nil {
-		if witnesses == nil {
-			p = append(p, signers)
-		} else {
-			if len(witnesses) != len(signers) {
-				return nil, fmt.Errorf("number of witnesses should match number of signers, got %d vs %d", len(witnesses), len(signers))
-			}
-			signersWithWitnesses := make([]neorpc.SignerWithWitness, len(signers))
-			for i := range signersWithWitnesses {
-				signersWithWitnesses[i] = neorpc.SignerWithWitness{
-					Signer:  signers[i],
-					Witness: witnesses[i],
-				}
-			}
-			p = append(p, signersWithWitnesses)
-		}
+	p, err := invokeParams(p, signers, witnesses)
+	if err != nil {
+		return nil, err
 	}
 	if err := c.performRequest(method, p, resp); err != nil {
 		return nil, err
@@ -803,6 +808,27 @@ func (c *Client) invokeSomething(method string, p []any, signers []transaction.S
 	return resp, nil
 }
 
+// invokeParams adds signers and witnesses to Invoke* parameters.
+func invokeParams(p []any, signers []transaction.Signer, witnesses []transaction.Witness) ([]any, error) {
+	if signers == nil {
+		return p, nil
+	}
+	if witnesses == nil {
+		return append(p, signers), nil
+	}
+	if len(witnesses) != len(signers) {
+		return nil, fmt.Errorf("number of witnesses should match number of signers, got %d vs %d", len(witnesses), len(signers))
+	}
+	signersWithWitnesses := make([]neorpc.SignerWithWitness, len(signers))
+	for i := range signersWithWitnesses {
+		signersWithWitnesses[i] = neorpc.SignerWithWitness{
+			Signer:  signers[i],
+			Witness: witnesses[i],
+		}
+	}
+	return append(p, signersWithWitnesses), nil
+}
+
 // SendRawTransaction broadcasts the given transaction to the Neo network.
 // It always returns transaction hash, when successful (no error) this is the
 // hash returned from server, when not it's a locally calculated rawTX hash.
@@ -960,6 +986,31 @@ func (c *Client) GetRawNotaryTransactionVerbose(hash util.Uint256) (*transaction
 	return resp, nil
 }
 
+// GetNotaryRequest returns the state of notary request processing for the
+// main transaction with the given hash. It requires the RPC node to have the
+// P2P Notary service running, neorpc.ErrUnknownTransaction is returned if the
+// request is not known to the service.
+func (c *Client) GetNotaryRequest(mainHash util.Uint256) (*result.NotaryRequest, error) {
+	var (
+		params = []any{mainHash.StringLE()}
+		resp   = new(result.NotaryRequest)
+	)
+	if err := c.performRequest("getnotaryrequest", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetNotaryRequests returns the state of all notary requests processed by the
+// P2P Notary service of the RPC node.
+func (c *Client) GetNotaryRequests() ([]result.NotaryRequest, error) {
+	var resp []result.NotaryRequest
+	if err := c.performRequest("getnotaryrequests", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetRawNotaryPool returns hashes of main P2PNotaryRequest transactions that
 // are currently in the RPC node's notary request pool with the corresponding
 // hashes of fallback transactions.
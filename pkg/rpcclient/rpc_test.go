@@ -299,6 +299,25 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"getfeeestimate": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (any, error) {
+				return c.GetFeeEstimate(5)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"feeperbyte":1000,"low":1500,"medium":2000,"high":3000,"mempoolsize":600,"blocks":5}}`,
+			result: func(c *Client) any {
+				return &result.FeeEstimate{
+					FeePerByte:  1000,
+					Low:         1500,
+					Medium:      2000,
+					High:        3000,
+					MempoolSize: 600,
+					Blocks:      5,
+				}
+			},
+		},
+	},
 	"getcommittee": {
 		{
 			name: "positive",
@@ -1295,6 +1314,60 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"getnotaryrequest": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (any, error) {
+				hash, err := util.Uint256DecodeStringLE("d86b5346e9bbe6dba845cc4192fa716535a3d05c4f2084431edc99dc3862a299")
+				if err != nil {
+					panic(err)
+				}
+				return c.GetNotaryRequest(hash)
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":{"hash":"0xd86b5346e9bbe6dba845cc4192fa716535a3d05c4f2084431edc99dc3862a299","valid":true,"sent":false,"notvalidbefore":20,"signaturesleft":1,"signers":[{"account":"0xb248508f4ef7088e10c48f14d04be3272ca29eee","type":"signature","signaturesleft":1,"keys":["03b209fd4f53a7170ea4444e0cb0a6bb6a53c2bd016926989cf85f9b0fba17a70c"],"signed":[]}],"fallbacks":[{"hash":"0xbb0b2f1d5539dd776637f00e5011d97921a1400d3a63c02977a38446180c6d7c","notvalidbefore":20,"validuntilblock":30}],"sentfallbacks":[]}}`,
+			result: func(c *Client) any {
+				return &result.NotaryRequest{}
+			},
+			check: func(t *testing.T, c *Client, uns any) {
+				res, ok := uns.(*result.NotaryRequest)
+				require.True(t, ok)
+				require.Equal(t, "d86b5346e9bbe6dba845cc4192fa716535a3d05c4f2084431edc99dc3862a299", res.Hash.StringLE())
+				require.True(t, res.Valid)
+				require.False(t, res.IsCompleted())
+				require.Equal(t, uint32(20), res.NotValidBefore)
+				require.Equal(t, 1, res.SignaturesLeft)
+				require.Equal(t, 1, len(res.Signers))
+				require.Equal(t, "signature", res.Signers[0].Type)
+				require.Equal(t, 1, len(res.Signers[0].Keys))
+				require.Equal(t, 0, len(res.Signers[0].Signed))
+				require.Equal(t, []result.NotaryFallback{{
+					Hash:            util.Uint256{0x7c, 0x6d, 0x0c, 0x18, 0x46, 0x84, 0xa3, 0x77, 0x29, 0xc0, 0x63, 0x3a, 0x0d, 0x40, 0xa1, 0x21, 0x79, 0xd9, 0x11, 0x50, 0x0e, 0xf0, 0x37, 0x66, 0x77, 0xdd, 0x39, 0x55, 0x1d, 0x2f, 0x0b, 0xbb},
+					NotValidBefore:  20,
+					ValidUntilBlock: 30,
+				}}, res.Fallbacks)
+			},
+		},
+	},
+	"getnotaryrequests": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (any, error) {
+				return c.GetNotaryRequests()
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":[{"hash":"0xd86b5346e9bbe6dba845cc4192fa716535a3d05c4f2084431edc99dc3862a299","valid":false,"sent":false,"notvalidbefore":20,"signaturesleft":0,"signers":[],"fallbacks":[],"sentfallbacks":[{"hash":"0xbb0b2f1d5539dd776637f00e5011d97921a1400d3a63c02977a38446180c6d7c","notvalidbefore":20,"validuntilblock":30}]}]}`,
+			result: func(c *Client) any {
+				return []result.NotaryRequest{}
+			},
+			check: func(t *testing.T, c *Client, uns any) {
+				res, ok := uns.([]result.NotaryRequest)
+				require.True(t, ok)
+				require.Equal(t, 1, len(res))
+				require.False(t, res[0].Valid)
+				require.True(t, res[0].IsCompleted())
+				require.Equal(t, 1, len(res[0].SentFallbacks))
+			},
+		},
+	},
 	"getrawnotarypool": {
 		{
 			name: "empty pool",
@@ -1521,6 +1594,12 @@ var rpcClientErrorCases = map[string][]rpcClientErrorCase{
 				return c.GetBlockSysFee(1)
 			},
 		},
+		{
+			name: "getfeeestimate_invalid_params_error",
+			invoke: func(c *Client) (any, error) {
+				return c.GetFeeEstimate(0)
+			},
+		},
 		{
 			name: "getconnectioncount_invalid_params_error",
 			invoke: func(c *Client) (any, error) {
@@ -1720,6 +1799,12 @@ var rpcClientErrorCases = map[string][]rpcClientErrorCase{
 				return c.GetBlockSysFee(1)
 			},
 		},
+		{
+			name: "getfeeestimate_unmarshalling_error",
+			invoke: func(c *Client) (any, error) {
+				return c.GetFeeEstimate(0)
+			},
+		},
 		{
 			name: "getcommittee_unmarshalling_error",
 			invoke: func(c *Client) (any, error) {
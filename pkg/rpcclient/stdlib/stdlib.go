@@ -0,0 +1,165 @@
+/*
+Package stdlib allows to work with the native StdLib contract via RPC.
+
+StdLib only has safe methods, they're all encapsulated into ContractReader
+structure. Method names follow the ones used by the interop/native/std package
+for contracts. Obviously, most of these functions have direct Go analogues that
+are much more efficient, but sometimes it's important to get exactly the same
+result as a contract does (like for serialization and number conversions).
+*/
+package stdlib
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativehashes"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/unwrap"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// Invoker is used by ContractReader to call various methods.
+type Invoker interface {
+	Call(contract util.Uint160, operation string, params ...any) (*result.Invoke, error)
+}
+
+// Hash stores the hash of the native StdLib contract.
+var Hash = nativehashes.StdLib
+
+// ContractReader provides an interface to call read-only StdLib contract's
+// methods.
+type ContractReader struct {
+	invoker Invoker
+}
+
+// NewReader creates an instance of ContractReader that can be used to read
+// data from the contract.
+func NewReader(invoker Invoker) *ContractReader {
+	return &ContractReader{invoker}
+}
+
+// Serialize serializes the given value into a byte slice using the binary
+// stack item serialization format. Item can be of any type supported by
+// smartcontract.NewParameterFromValue.
+func (c *ContractReader) Serialize(item any) ([]byte, error) {
+	return unwrap.Bytes(c.invoker.Call(Hash, "serialize", item))
+}
+
+// Deserialize deserializes the given byte slice into a stack item using the
+// binary stack item serialization format.
+func (c *ContractReader) Deserialize(data []byte) (stackitem.Item, error) {
+	return unwrap.Item(c.invoker.Call(Hash, "deserialize", data))
+}
+
+// JSONSerialize serializes the given value into JSON. Item can be of any type
+// supported by smartcontract.NewParameterFromValue.
+func (c *ContractReader) JSONSerialize(item any) ([]byte, error) {
+	return unwrap.Bytes(c.invoker.Call(Hash, "jsonSerialize", item))
+}
+
+// JSONDeserialize deserializes the given JSON into a stack item.
+func (c *ContractReader) JSONDeserialize(data []byte) (stackitem.Item, error) {
+	return unwrap.Item(c.invoker.Call(Hash, "jsonDeserialize", data))
+}
+
+// Base64Encode encodes the given byte slice into a base64 string.
+func (c *ContractReader) Base64Encode(data []byte) (string, error) {
+	return unwrap.UTF8String(c.invoker.Call(Hash, "base64Encode", data))
+}
+
+// Base64Decode decodes the given base64 string into a byte slice.
+func (c *ContractReader) Base64Decode(s string) ([]byte, error) {
+	return unwrap.Bytes(c.invoker.Call(Hash, "base64Decode", s))
+}
+
+// Base58Encode encodes the given byte slice into a base58 string.
+func (c *ContractReader) Base58Encode(data []byte) (string, error) {
+	return unwrap.UTF8String(c.invoker.Call(Hash, "base58Encode", data))
+}
+
+// Base58Decode decodes the given base58 string into a byte slice.
+func (c *ContractReader) Base58Decode(s string) ([]byte, error) {
+	return unwrap.Bytes(c.invoker.Call(Hash, "base58Decode", s))
+}
+
+// Base58CheckEncode encodes the given byte slice into a base58 string with
+// a checksum.
+func (c *ContractReader) Base58CheckEncode(data []byte) (string, error) {
+	return unwrap.UTF8String(c.invoker.Call(Hash, "base58CheckEncode", data))
+}
+
+// Base58CheckDecode decodes the given base58 string with a checksum into a
+// byte slice.
+func (c *ContractReader) Base58CheckDecode(s string) ([]byte, error) {
+	return unwrap.Bytes(c.invoker.Call(Hash, "base58CheckDecode", s))
+}
+
+// Itoa converts the given number to a string using the given base (10 or
+// 16).
+func (c *ContractReader) Itoa(num *big.Int, base int) (string, error) {
+	return unwrap.UTF8String(c.invoker.Call(Hash, "itoa", num, base))
+}
+
+// Itoa10 converts the given number to a decimal string.
+func (c *ContractReader) Itoa10(num *big.Int) (string, error) {
+	return unwrap.UTF8String(c.invoker.Call(Hash, "itoa", num))
+}
+
+// Atoi converts the given string to a number using the given base (10 or
+// 16).
+func (c *ContractReader) Atoi(s string, base int) (*big.Int, error) {
+	return unwrap.BigInt(c.invoker.Call(Hash, "atoi", s, base))
+}
+
+// Atoi10 converts the given decimal string to a number.
+func (c *ContractReader) Atoi10(s string) (*big.Int, error) {
+	return unwrap.BigInt(c.invoker.Call(Hash, "atoi", s))
+}
+
+// MemoryCompare compares two byte slices lexicographically, it returns -1,
+// 0 or 1 (like bytes.Compare does).
+func (c *ContractReader) MemoryCompare(s1, s2 []byte) (int64, error) {
+	r, err := c.invoker.Call(Hash, "memoryCompare", s1, s2)
+	return unwrap.LimitedInt64(r, err, -1, 1)
+}
+
+// MemorySearch returns the index of the first occurrence of pattern in mem,
+// -1 is returned if there is none.
+func (c *ContractReader) MemorySearch(mem, pattern []byte) (int64, error) {
+	r, err := c.invoker.Call(Hash, "memorySearch", mem, pattern)
+	return unwrap.LimitedInt64(r, err, -1, math.MaxInt32)
+}
+
+// MemorySearchIndex returns the index of the first occurrence of pattern in
+// mem starting from the given position, -1 is returned if there is none.
+func (c *ContractReader) MemorySearchIndex(mem, pattern []byte, start int) (int64, error) {
+	r, err := c.invoker.Call(Hash, "memorySearch", mem, pattern, start)
+	return unwrap.LimitedInt64(r, err, -1, math.MaxInt32)
+}
+
+// MemorySearchLastIndex returns the index of the last occurrence of pattern
+// in mem that ends before the given position, -1 is returned if there is
+// none.
+func (c *ContractReader) MemorySearchLastIndex(mem, pattern []byte, start int) (int64, error) {
+	r, err := c.invoker.Call(Hash, "memorySearch", mem, pattern, start, true)
+	return unwrap.LimitedInt64(r, err, -1, math.MaxInt32)
+}
+
+// StringSplit splits the given string using the given separator.
+func (c *ContractReader) StringSplit(s, sep string) ([]string, error) {
+	return unwrap.ArrayOfUTF8Strings(c.invoker.Call(Hash, "stringSplit", s, sep))
+}
+
+// StringSplitNonEmpty splits the given string using the given separator and
+// removes empty entries from the result.
+func (c *ContractReader) StringSplitNonEmpty(s, sep string) ([]string, error) {
+	return unwrap.ArrayOfUTF8Strings(c.invoker.Call(Hash, "stringSplit", s, sep, true))
+}
+
+// StrLen returns the number of UTF-8 characters in the given string.
+func (c *ContractReader) StrLen(s string) (int64, error) {
+	r, err := c.invoker.Call(Hash, "strLen", s)
+	return unwrap.LimitedInt64(r, err, 0, math.MaxInt32)
+}
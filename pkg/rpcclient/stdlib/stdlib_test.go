@@ -0,0 +1,133 @@
+package stdlib
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+type testInv struct {
+	err    error
+	res    *result.Invoke
+	method string
+	params []any
+}
+
+func (t *testInv) Call(contract util.Uint160, operation string, params ...any) (*result.Invoke, error) {
+	t.method, t.params = operation, params
+	return t.res, t.err
+}
+
+func TestReader(t *testing.T) {
+	ti := new(testInv)
+	sl := NewReader(ti)
+
+	ti.err = errors.New("")
+	_, err := sl.Serialize(42)
+	require.Error(t, err)
+	_, err = sl.Deserialize([]byte{1})
+	require.Error(t, err)
+	_, err = sl.Atoi10("42")
+	require.Error(t, err)
+	_, err = sl.StringSplit("a,b", ",")
+	require.Error(t, err)
+
+	ti.err = nil
+	ti.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{stackitem.Make([]byte{1, 2, 3})},
+	}
+	bytesMeth := map[string]func() ([]byte, error){
+		"serialize":         func() ([]byte, error) { return sl.Serialize(42) },
+		"jsonSerialize":     func() ([]byte, error) { return sl.JSONSerialize(42) },
+		"base64Decode":      func() ([]byte, error) { return sl.Base64Decode("AQID") },
+		"base58Decode":      func() ([]byte, error) { return sl.Base58Decode("Ldp") },
+		"base58CheckDecode": func() ([]byte, error) { return sl.Base58CheckDecode("3DUz7ncyT") },
+	}
+	for name, m := range bytesMeth {
+		val, err := m()
+		require.NoError(t, err)
+		require.Equal(t, []byte{1, 2, 3}, val)
+		require.Equal(t, name, ti.method)
+	}
+	itm, err := sl.Deserialize([]byte{1})
+	require.NoError(t, err)
+	require.Equal(t, stackitem.Make([]byte{1, 2, 3}), itm)
+	_, err = sl.JSONDeserialize([]byte("1"))
+	require.NoError(t, err)
+	require.Equal(t, "jsonDeserialize", ti.method)
+
+	ti.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{stackitem.Make("str")},
+	}
+	strMeth := map[string]func() (string, error){
+		"base64Encode":      func() (string, error) { return sl.Base64Encode([]byte{1}) },
+		"base58Encode":      func() (string, error) { return sl.Base58Encode([]byte{1}) },
+		"base58CheckEncode": func() (string, error) { return sl.Base58CheckEncode([]byte{1}) },
+		"itoa":              func() (string, error) { return sl.Itoa(big.NewInt(1), 16) },
+	}
+	for name, m := range strMeth {
+		val, err := m()
+		require.NoError(t, err)
+		require.Equal(t, "str", val)
+		require.Equal(t, name, ti.method)
+	}
+	_, err = sl.Itoa10(big.NewInt(1))
+	require.NoError(t, err)
+	require.Equal(t, 1, len(ti.params))
+
+	ti.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{stackitem.Make(1)},
+	}
+	bi, err := sl.Atoi("1", 16)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1), bi)
+	require.Equal(t, 2, len(ti.params))
+	bi, err = sl.Atoi10("1")
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1), bi)
+	require.Equal(t, 1, len(ti.params))
+
+	intMeth := []func() (int64, error){
+		func() (int64, error) { return sl.MemoryCompare([]byte{1}, []byte{0}) },
+		func() (int64, error) { return sl.MemorySearch([]byte{1}, []byte{1}) },
+		func() (int64, error) { return sl.MemorySearchIndex([]byte{1}, []byte{1}, 0) },
+		func() (int64, error) { return sl.MemorySearchLastIndex([]byte{1}, []byte{1}, 1) },
+		func() (int64, error) { return sl.StrLen("a") },
+	}
+	for _, m := range intMeth {
+		val, err := m()
+		require.NoError(t, err)
+		require.Equal(t, int64(1), val)
+	}
+	require.Equal(t, "strLen", ti.method)
+	_, _ = sl.MemorySearchLastIndex([]byte{1}, []byte{1}, 1)
+	require.Equal(t, []any{[]byte{1}, []byte{1}, 1, true}, ti.params)
+
+	ti.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{stackitem.Make(2)},
+	}
+	_, err = sl.MemoryCompare([]byte{1}, []byte{0})
+	require.Error(t, err)
+
+	ti.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{stackitem.Make([]stackitem.Item{stackitem.Make("a"), stackitem.Make("b")})},
+	}
+	strs, err := sl.StringSplit("a,b", ",")
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, strs)
+	require.Equal(t, 2, len(ti.params))
+	strs, err = sl.StringSplitNonEmpty("a,,b", ",")
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, strs)
+	require.Equal(t, []any{"a,,b", ",", true}, ti.params)
+}
@@ -0,0 +1,13 @@
+package tracker_test
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/tracker"
+)
+
+func TestRPCTrackerRPCClientCompat(t *testing.T) {
+	_ = tracker.RPCTracker(&rpcclient.WSClient{})
+	_ = tracker.RPCTracker(&rpcclient.Client{})
+}
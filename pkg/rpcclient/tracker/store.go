@@ -0,0 +1,176 @@
+package tracker
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// PendingTx is a transaction tracked by Tracker. It can have several versions
+// (the original one and replacements with higher network fee), any of them can
+// be accepted to the chain, but only one of them will be.
+type PendingTx struct {
+	// Tx is the latest version of the transaction.
+	Tx *transaction.Transaction `json:"tx"`
+	// Hashes contains hashes of all versions of the transaction, the first
+	// one is the original transaction hash used as an identifier.
+	Hashes []util.Uint256 `json:"hashes"`
+	// ValidUntilBlock is the maximum ValidUntilBlock value of all versions,
+	// no version can be accepted after this block.
+	ValidUntilBlock uint32 `json:"validuntilblock"`
+	// SentAt is the block count at the moment of the latest version creation.
+	SentAt uint32 `json:"sentat"`
+}
+
+// Store is a persistent storage for pending transactions, it allows Tracker
+// to continue tracking them after restart.
+type Store interface {
+	// Put adds a new or updates an existing transaction.
+	Put(p *PendingTx) error
+	// Delete removes the transaction with the given ID (original hash).
+	Delete(id util.Uint256) error
+	// List returns all stored transactions.
+	List() ([]*PendingTx, error)
+}
+
+// MemoryStore is a Store that keeps everything in memory, it's useful for
+// tests and applications that don't need persistence.
+type MemoryStore struct {
+	lock sync.Mutex
+	txs  map[util.Uint256]PendingTx
+}
+
+// FileStore is a Store that keeps pending transactions in a JSON file. The
+// whole file is rewritten on every change, which is OK for a reasonable number
+// of pending transactions.
+type FileStore struct {
+	MemoryStore
+
+	path string
+}
+
+// ID returns transaction identifier which is the original transaction hash.
+func (p *PendingTx) ID() util.Uint256 {
+	return p.Hashes[0]
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{txs: make(map[util.Uint256]PendingTx)}
+}
+
+// Put implements the Store interface.
+func (m *MemoryStore) Put(p *PendingTx) error {
+	if len(p.Hashes) == 0 {
+		return errors.New("no transaction hashes")
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.put(p)
+	return nil
+}
+
+func (m *MemoryStore) put(p *PendingTx) {
+	var cp = *p
+	cp.Hashes = append([]util.Uint256(nil), p.Hashes...)
+	m.txs[p.ID()] = cp
+}
+
+// Delete implements the Store interface.
+func (m *MemoryStore) Delete(id util.Uint256) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	delete(m.txs, id)
+	return nil
+}
+
+// List implements the Store interface. Transactions are sorted by their
+// SentAt value.
+func (m *MemoryStore) List() ([]*PendingTx, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.list(), nil
+}
+
+func (m *MemoryStore) list() []*PendingTx {
+	var res = make([]*PendingTx, 0, len(m.txs))
+	for _, p := range m.txs {
+		var cp = p
+		cp.Hashes = append([]util.Uint256(nil), p.Hashes...)
+		res = append(res, &cp)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].SentAt != res[j].SentAt {
+			return res[i].SentAt < res[j].SentAt
+		}
+		return res[i].ID().CompareTo(res[j].ID()) < 0
+	})
+	return res
+}
+
+// NewFileStore creates a FileStore using the given file. Transactions are
+// read from it if it exists, otherwise it will be created on the first change.
+func NewFileStore(path string) (*FileStore, error) {
+	var s = &FileStore{MemoryStore: *NewMemoryStore(), path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return s, nil
+		}
+		return nil, err
+	}
+	var txs []*PendingTx
+	if err := json.Unmarshal(data, &txs); err != nil {
+		return nil, fmt.Errorf("invalid store file: %w", err)
+	}
+	for _, p := range txs {
+		if len(p.Hashes) == 0 || p.Tx == nil {
+			return nil, errors.New("invalid store file: incomplete transaction")
+		}
+		s.put(p)
+	}
+	return s, nil
+}
+
+// Put implements the Store interface.
+func (s *FileStore) Put(p *PendingTx) error {
+	if len(p.Hashes) == 0 {
+		return errors.New("no transaction hashes")
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.put(p)
+	return s.save()
+}
+
+// Delete implements the Store interface.
+func (s *FileStore) Delete(id util.Uint256) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, ok := s.txs[id]; !ok {
+		return nil
+	}
+	delete(s.txs, id)
+	return s.save()
+}
+
+// save writes the file atomically (via temporary file rename).
+func (s *FileStore) save() error {
+	data, err := json.Marshal(s.list())
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
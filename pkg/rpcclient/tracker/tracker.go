@@ -0,0 +1,422 @@
+/*
+Package tracker provides transaction lifecycle tracking built on top of actor
+and waiter packages.
+
+Tracker keeps a set of pending transactions (in a persistent Store), waits for
+them to be accepted to the chain (via Actor's Waiter), re-broadcasts them if
+they're dropped from the mempool and replaces them with a higher network fee
+version (using Conflicts attribute) if they're stuck there. Callbacks are
+called when transaction is accepted (with HALT or FAULT state) or expires.
+
+Neo has one-block finality, so transaction accepted to the chain is never
+reverted and no additional confirmations are needed.
+*/
+package tracker
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/actor"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/waiter"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+)
+
+const (
+	// DefaultStuckBlocks is the default number of blocks after which a
+	// transaction not accepted to the chain is replaced.
+	DefaultStuckBlocks = 3
+	// DefaultFeeBumpPercent is the default network fee increase (relative to
+	// the previous version) for a replacement transaction.
+	DefaultFeeBumpPercent = 20
+	// DefaultMaxReplacements is the default number of replacements made for
+	// a single transaction.
+	DefaultMaxReplacements = 3
+)
+
+// RPCTracker is a set of RPC methods needed by Tracker in addition to the
+// ones used by Actor.
+type RPCTracker interface {
+	GetRawMemPool() ([]util.Uint256, error)
+}
+
+// Options are used to tune Tracker behavior.
+type Options struct {
+	// PollInterval is the interval between mempool checks, half of the block
+	// time is used by default.
+	PollInterval time.Duration
+	// StuckBlocks is the number of blocks after which a transaction (that
+	// is still not accepted) is replaced with a higher network fee version,
+	// DefaultStuckBlocks is used if not set.
+	StuckBlocks uint32
+	// FeeBumpPercent is the network fee increase for a replacement relative
+	// to the previous version, DefaultFeeBumpPercent is used if not set.
+	FeeBumpPercent int64
+	// MaxReplacements is the maximum number of replacements for a single
+	// transaction, DefaultMaxReplacements is used if zero, negative value
+	// disables replacements.
+	MaxReplacements int
+	// MaxNetworkFee limits the network fee of replacement transactions, no
+	// replacement is made if it's to exceed this value. Zero means no limit.
+	MaxNetworkFee int64
+
+	// OnSuccess is called when the transaction is accepted with HALT state.
+	// id is the original transaction hash (returned from Track) while the
+	// accepted transaction can be a replacement (see res.Container).
+	OnSuccess func(id util.Uint256, res *state.AppExecResult)
+	// OnFault is called when the transaction is accepted with FAULT state.
+	OnFault func(id util.Uint256, res *state.AppExecResult)
+	// OnExpire is called when no version of the transaction can be accepted
+	// anymore because of ValidUntilBlock.
+	OnExpire func(id util.Uint256)
+}
+
+// Tracker tracks transactions until they're accepted to the chain or expire.
+// Transactions must be created by the same Actor that is used by Tracker,
+// otherwise replacements can't be signed. It's safe for concurrent use.
+type Tracker struct {
+	actor *actor.Actor
+	rpc   RPCTracker
+	store Store
+	opts  Options
+
+	lock    sync.Mutex
+	started bool
+	pending map[util.Uint256]*entry
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+type entry struct {
+	PendingTx
+
+	// restart interrupts the current awaiting process to restart it with
+	// the new set of hashes.
+	restart context.CancelFunc
+}
+
+// New creates a Tracker using the given Actor, RPC and Store. Transactions
+// from the Store are loaded, but not tracked until Start is called. Actor
+// must support transaction awaiting (its Waiter can't be waiter.Null).
+func New(a *actor.Actor, rpc RPCTracker, store Store, opts Options) (*Tracker, error) {
+	if _, ok := a.Waiter.(waiter.Null); ok {
+		return nil, waiter.ErrAwaitingNotSupported
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = time.Millisecond * time.Duration(a.GetVersion().Protocol.MillisecondsPerBlock) / 2
+		if opts.PollInterval == 0 {
+			opts.PollInterval = time.Second
+		}
+	}
+	if opts.StuckBlocks == 0 {
+		opts.StuckBlocks = DefaultStuckBlocks
+	}
+	if opts.FeeBumpPercent <= 0 {
+		opts.FeeBumpPercent = DefaultFeeBumpPercent
+	}
+	if opts.MaxReplacements == 0 {
+		opts.MaxReplacements = DefaultMaxReplacements
+	}
+	txs, err := store.List()
+	if err != nil {
+		return nil, err
+	}
+	t := &Tracker{
+		actor:   a,
+		rpc:     rpc,
+		store:   store,
+		opts:    opts,
+		pending: make(map[util.Uint256]*entry, len(txs)),
+	}
+	t.ctx, t.cancel = context.WithCancel(context.Background())
+	for _, p := range txs {
+		t.pending[p.ID()] = &entry{PendingTx: *p}
+	}
+	return t, nil
+}
+
+// Start starts tracking all pending transactions.
+func (t *Tracker) Start() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.started {
+		return
+	}
+	t.started = true
+	for _, e := range t.pending {
+		t.wg.Add(1)
+		go t.watch(e)
+	}
+	t.wg.Add(1)
+	go t.loop()
+}
+
+// Stop stops tracking and waits for all internal routines to finish. Pending
+// transactions are kept in the Store. Tracker can't be restarted after Stop,
+// create a new one using the same Store if needed.
+func (t *Tracker) Stop() {
+	t.cancel()
+	t.wg.Wait()
+}
+
+// Track sends the given (signed) transaction to the network and starts
+// tracking it. The transaction is saved into the Store before sending. It
+// returns transaction hash (used as an identifier in callbacks) and
+// ValidUntilBlock value. Transactions rejected by the RPC server are not
+// tracked, but "already exists" error is not treated as a rejection.
+func (t *Tracker) Track(tx *transaction.Transaction) (util.Uint256, uint32, error) {
+	count, err := t.actor.GetBlockCount()
+	if err != nil {
+		return util.Uint256{}, 0, err
+	}
+	e := &entry{PendingTx: PendingTx{
+		Tx:              tx,
+		Hashes:          []util.Uint256{tx.Hash()},
+		ValidUntilBlock: tx.ValidUntilBlock,
+		SentAt:          count,
+	}}
+	if err := t.store.Put(&e.PendingTx); err != nil {
+		return util.Uint256{}, 0, err
+	}
+	h, vub, err := t.actor.Send(tx)
+	if err != nil && !errIsAlreadyExists(err) {
+		_ = t.store.Delete(e.ID())
+		return h, vub, err
+	}
+	t.lock.Lock()
+	t.pending[e.ID()] = e
+	if t.started {
+		t.wg.Add(1)
+		go t.watch(e)
+	}
+	t.lock.Unlock()
+	return e.ID(), vub, nil
+}
+
+// Pending returns the list of transactions currently tracked.
+func (t *Tracker) Pending() []PendingTx {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	var res = make([]PendingTx, 0, len(t.pending))
+	for _, e := range t.pending {
+		var p = e.PendingTx
+		p.Hashes = append([]util.Uint256(nil), e.Hashes...)
+		res = append(res, p)
+	}
+	return res
+}
+
+// watch waits for any version of the transaction to be accepted.
+func (t *Tracker) watch(e *entry) {
+	defer t.wg.Done()
+	for {
+		t.lock.Lock()
+		if t.pending[e.ID()] != e {
+			t.lock.Unlock()
+			return
+		}
+		ctx, cancel := context.WithCancel(t.ctx)
+		e.restart = cancel
+		vub := e.ValidUntilBlock
+		hashes := append([]util.Uint256(nil), e.Hashes...)
+		t.lock.Unlock()
+
+		res, err := t.actor.WaitAny(ctx, vub, hashes...)
+		cancel()
+		switch {
+		case err == nil:
+			t.finish(e, res, vub)
+			return
+		case errors.Is(err, waiter.ErrTxNotAccepted):
+			if t.finish(e, nil, vub) {
+				return
+			}
+		case t.ctx.Err() != nil:
+			return
+		case ctx.Err() != nil:
+			// Replaced, wait for the new set of hashes.
+		default:
+			// Some RPC problem, retry a bit later.
+			select {
+			case <-t.ctx.Done():
+				return
+			case <-time.After(t.opts.PollInterval):
+			}
+		}
+	}
+}
+
+// finish removes the transaction and calls appropriate callback. Expiration
+// (nil res) is only processed if the transaction wasn't replaced with a
+// higher ValidUntilBlock version, false is returned otherwise.
+func (t *Tracker) finish(e *entry, res *state.AppExecResult, vub uint32) bool {
+	t.lock.Lock()
+	if res == nil && e.ValidUntilBlock != vub {
+		t.lock.Unlock()
+		return false
+	}
+	delete(t.pending, e.ID())
+	_ = t.store.Delete(e.ID())
+	t.lock.Unlock()
+
+	switch {
+	case res == nil:
+		if t.opts.OnExpire != nil {
+			t.opts.OnExpire(e.ID())
+		}
+	case res.VMState == vmstate.Halt:
+		if t.opts.OnSuccess != nil {
+			t.opts.OnSuccess(e.ID(), res)
+		}
+	default:
+		if t.opts.OnFault != nil {
+			t.opts.OnFault(e.ID(), res)
+		}
+	}
+	return true
+}
+
+// loop periodically checks pending transactions against the mempool.
+func (t *Tracker) loop() {
+	defer t.wg.Done()
+	ticker := time.NewTicker(t.opts.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-t.ctx.Done():
+			return
+		case <-ticker.C:
+			t.check()
+		}
+	}
+}
+
+// check re-broadcasts transactions missing from the mempool and replaces
+// stuck ones.
+func (t *Tracker) check() {
+	count, err := t.actor.GetBlockCount()
+	if err != nil {
+		return
+	}
+	pool, err := t.rpc.GetRawMemPool()
+	if err != nil {
+		return
+	}
+	var inPool = make(map[util.Uint256]bool, len(pool))
+	for _, h := range pool {
+		inPool[h] = true
+	}
+
+	t.lock.Lock()
+	var entries = make([]*entry, 0, len(t.pending))
+	for _, e := range t.pending {
+		entries = append(entries, e)
+	}
+	t.lock.Unlock()
+
+	for _, e := range entries {
+		t.lock.Lock()
+		tx, sentAt, versions := e.Tx, e.SentAt, len(e.Hashes)
+		t.lock.Unlock()
+
+		if count >= sentAt+t.opts.StuckBlocks && versions-1 < t.opts.MaxReplacements &&
+			t.replace(e, count) {
+			continue
+		}
+		if !inPool[tx.Hash()] && count <= tx.ValidUntilBlock {
+			// Errors are not important here, it can already be
+			// accepted or it'll be retried on the next check.
+			_, _, _ = t.actor.Send(tx)
+		}
+	}
+}
+
+// replace creates, signs and sends a new version of the transaction with
+// higher network fee and Conflicts attributes for all previous versions. It
+// returns true if the replacement was successfully sent.
+func (t *Tracker) replace(e *entry, count uint32) bool {
+	t.lock.Lock()
+	old := e.Tx
+	hashes := append([]util.Uint256(nil), e.Hashes...)
+	t.lock.Unlock()
+
+	var attrs []transaction.Attribute
+	for _, a := range old.Attributes {
+		if a.Type != transaction.ConflictsT {
+			attrs = append(attrs, a)
+		}
+	}
+	for _, h := range hashes {
+		attrs = append(attrs, transaction.Attribute{
+			Type:  transaction.ConflictsT,
+			Value: &transaction.Conflicts{Hash: h},
+		})
+	}
+	tx, err := t.actor.MakeUnsignedUncheckedRun(old.Script, old.SystemFee, attrs)
+	if err != nil {
+		return false
+	}
+	bump := old.NetworkFee * t.opts.FeeBumpPercent / 100
+	if bump == 0 {
+		bump = 1
+	}
+	if tx.NetworkFee <= old.NetworkFee {
+		tx.NetworkFee = old.NetworkFee
+	}
+	tx.NetworkFee += bump
+	if t.opts.MaxNetworkFee > 0 && tx.NetworkFee > t.opts.MaxNetworkFee {
+		return false
+	}
+	if err := t.actor.Sign(tx); err != nil {
+		return false
+	}
+
+	t.lock.Lock()
+	if t.pending[e.ID()] != e { // Already finished.
+		t.lock.Unlock()
+		return true
+	}
+	var prev, p = e.PendingTx, e.PendingTx
+	p.Tx = tx
+	p.Hashes = append(hashes, tx.Hash())
+	p.SentAt = count
+	if tx.ValidUntilBlock > p.ValidUntilBlock {
+		p.ValidUntilBlock = tx.ValidUntilBlock
+	}
+	// Store first, the replacement can be accepted right after sending.
+	if err := t.store.Put(&p); err != nil {
+		t.lock.Unlock()
+		return false
+	}
+	e.PendingTx = p
+	t.lock.Unlock()
+
+	_, _, err = t.actor.Send(tx)
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if err != nil && !errIsAlreadyExists(err) {
+		if t.pending[e.ID()] == e {
+			e.PendingTx = prev
+			_ = t.store.Put(&prev)
+		}
+		return false
+	}
+	if e.restart != nil {
+		e.restart()
+	}
+	return true
+}
+
+// errIsAlreadyExists is similar to the one used by waiter, both C# and Go
+// nodes return this string (possibly among other data).
+func errIsAlreadyExists(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "already exists")
+}
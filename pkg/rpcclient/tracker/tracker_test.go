@@ -0,0 +1,312 @@
+package tracker
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/actor"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+// RPCClient is a simple chain emulator.
+type RPCClient struct {
+	lock    sync.Mutex
+	bCount  uint32
+	netFee  int64
+	mempool map[util.Uint256]*transaction.Transaction
+	sent    []*transaction.Transaction
+	appLogs map[util.Uint256]vmstate.State
+	sendErr error
+}
+
+func (r *RPCClient) InvokeContractVerify(contract util.Uint160, params []smartcontract.Parameter, signers []transaction.Signer, witnesses ...transaction.Witness) (*result.Invoke, error) {
+	panic("not implemented")
+}
+func (r *RPCClient) InvokeFunction(contract util.Uint160, operation string, params []smartcontract.Parameter, signers []transaction.Signer) (*result.Invoke, error) {
+	panic("not implemented")
+}
+func (r *RPCClient) InvokeScript(script []byte, signers []transaction.Signer) (*result.Invoke, error) {
+	panic("not implemented")
+}
+func (r *RPCClient) CalculateNetworkFee(tx *transaction.Transaction) (int64, error) {
+	return r.netFee, nil
+}
+func (r *RPCClient) GetBlockCount() (uint32, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.bCount, nil
+}
+func (r *RPCClient) GetVersion() (*result.Version, error) {
+	return &result.Version{
+		Protocol: result.Protocol{
+			Network:              netmode.UnitTestNet,
+			MillisecondsPerBlock: 10,
+			ValidatorsCount:      1,
+		},
+	}, nil
+}
+func (r *RPCClient) SendRawTransaction(tx *transaction.Transaction) (util.Uint256, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.sendErr != nil {
+		return util.Uint256{}, r.sendErr
+	}
+	r.sent = append(r.sent, tx)
+	for _, a := range tx.GetAttributes(transaction.ConflictsT) {
+		delete(r.mempool, a.Value.(*transaction.Conflicts).Hash)
+	}
+	r.mempool[tx.Hash()] = tx
+	return tx.Hash(), nil
+}
+func (r *RPCClient) TerminateSession(sessionID uuid.UUID) (bool, error) {
+	return false, nil
+}
+func (r *RPCClient) TraverseIterator(sessionID, iteratorID uuid.UUID, maxItemsCount int) ([]stackitem.Item, error) {
+	return nil, nil
+}
+func (r *RPCClient) Context() context.Context {
+	return context.Background()
+}
+func (r *RPCClient) GetApplicationLog(hash util.Uint256, trig *trigger.Type) (*result.ApplicationLog, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	st, ok := r.appLogs[hash]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return &result.ApplicationLog{
+		Container:  hash,
+		Executions: []state.Execution{{Trigger: trigger.Application, VMState: st}},
+	}, nil
+}
+func (r *RPCClient) GetRawMemPool() ([]util.Uint256, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	var res []util.Uint256
+	for h := range r.mempool {
+		res = append(res, h)
+	}
+	return res, nil
+}
+
+func (r *RPCClient) accept(h util.Uint256, st vmstate.State) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.mempool, h)
+	r.appLogs[h] = st
+	r.bCount++
+}
+
+func (r *RPCClient) addBlocks(n uint32) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.bCount += n
+}
+
+func (r *RPCClient) drop() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.mempool = make(map[util.Uint256]*transaction.Transaction)
+}
+
+func (r *RPCClient) sentCount() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return len(r.sent)
+}
+
+func (r *RPCClient) lastSent() *transaction.Transaction {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.sent[len(r.sent)-1]
+}
+
+type results struct {
+	lock    sync.Mutex
+	success map[util.Uint256]*state.AppExecResult
+	fault   map[util.Uint256]*state.AppExecResult
+	expired map[util.Uint256]bool
+}
+
+func (r *results) get(id util.Uint256) (*state.AppExecResult, *state.AppExecResult, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.success[id], r.fault[id], r.expired[id]
+}
+
+func newTestTracker(t *testing.T, store Store, opts Options) (*Tracker, *actor.Actor, *RPCClient, *results) {
+	rpc := &RPCClient{
+		bCount:  10,
+		netFee:  1000,
+		mempool: make(map[util.Uint256]*transaction.Transaction),
+		appLogs: make(map[util.Uint256]vmstate.State),
+	}
+	acc, err := wallet.NewAccount()
+	require.NoError(t, err)
+	a, err := actor.NewSimple(rpc, acc)
+	require.NoError(t, err)
+
+	res := &results{
+		success: make(map[util.Uint256]*state.AppExecResult),
+		fault:   make(map[util.Uint256]*state.AppExecResult),
+		expired: make(map[util.Uint256]bool),
+	}
+	opts.OnSuccess = func(id util.Uint256, aer *state.AppExecResult) {
+		res.lock.Lock()
+		res.success[id] = aer
+		res.lock.Unlock()
+	}
+	opts.OnFault = func(id util.Uint256, aer *state.AppExecResult) {
+		res.lock.Lock()
+		res.fault[id] = aer
+		res.lock.Unlock()
+	}
+	opts.OnExpire = func(id util.Uint256) {
+		res.lock.Lock()
+		res.expired[id] = true
+		res.lock.Unlock()
+	}
+	tr, err := New(a, rpc, store, opts)
+	require.NoError(t, err)
+	return tr, a, rpc, res
+}
+
+func makeTx(t *testing.T, a *actor.Actor) *transaction.Transaction {
+	tx, err := a.MakeUnsignedUncheckedRun([]byte{1, 2, 3}, 100, nil)
+	require.NoError(t, err)
+	require.NoError(t, a.Sign(tx))
+	return tx
+}
+
+func TestTrackerSuccess(t *testing.T) {
+	store := NewMemoryStore()
+	tr, a, rpc, res := newTestTracker(t, store, Options{})
+	tr.Start()
+	defer tr.Stop()
+
+	tx := makeTx(t, a)
+	id, vub, err := tr.Track(tx)
+	require.NoError(t, err)
+	require.Equal(t, tx.Hash(), id)
+	require.Equal(t, tx.ValidUntilBlock, vub)
+	require.Equal(t, 1, rpc.sentCount())
+	require.Equal(t, 1, len(tr.Pending()))
+	txs, err := store.List()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(txs))
+
+	rpc.accept(tx.Hash(), vmstate.Halt)
+	require.Eventually(t, func() bool {
+		s, _, _ := res.get(id)
+		return s != nil
+	}, time.Second, 5*time.Millisecond)
+	require.Equal(t, 0, len(tr.Pending()))
+	txs, err = store.List()
+	require.NoError(t, err)
+	require.Equal(t, 0, len(txs))
+
+	// Rejected transactions are not tracked.
+	rpc.sendErr = errors.New("bad")
+	_, _, err = tr.Track(makeTx(t, a))
+	require.Error(t, err)
+	require.Equal(t, 0, len(tr.Pending()))
+}
+
+func TestTrackerRebroadcast(t *testing.T) {
+	tr, a, rpc, res := newTestTracker(t, NewMemoryStore(), Options{})
+	tr.Start()
+	defer tr.Stop()
+
+	tx := makeTx(t, a)
+	id, _, err := tr.Track(tx)
+	require.NoError(t, err)
+
+	rpc.drop()
+	require.Eventually(t, func() bool { return rpc.sentCount() > 1 }, time.Second, 5*time.Millisecond)
+	require.Equal(t, tx.Hash(), rpc.lastSent().Hash())
+
+	rpc.accept(tx.Hash(), vmstate.Fault)
+	require.Eventually(t, func() bool {
+		_, f, _ := res.get(id)
+		return f != nil
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestTrackerReplace(t *testing.T) {
+	tr, a, rpc, res := newTestTracker(t, NewMemoryStore(), Options{StuckBlocks: 2})
+	tr.Start()
+	defer tr.Stop()
+
+	tx := makeTx(t, a)
+	id, _, err := tr.Track(tx)
+	require.NoError(t, err)
+
+	rpc.addBlocks(2)
+	require.Eventually(t, func() bool { return rpc.sentCount() > 1 }, time.Second, 5*time.Millisecond)
+	repl := rpc.lastSent()
+	require.NotEqual(t, tx.Hash(), repl.Hash())
+	require.True(t, repl.NetworkFee > tx.NetworkFee)
+	require.Equal(t, tx.Script, repl.Script)
+	conflicts := repl.GetAttributes(transaction.ConflictsT)
+	require.Equal(t, 1, len(conflicts))
+	require.Equal(t, tx.Hash(), conflicts[0].Value.(*transaction.Conflicts).Hash)
+
+	p := tr.Pending()
+	require.Equal(t, 1, len(p))
+	require.Equal(t, []util.Uint256{tx.Hash(), repl.Hash()}, p[0].Hashes)
+
+	rpc.accept(repl.Hash(), vmstate.Halt)
+	require.Eventually(t, func() bool {
+		s, _, _ := res.get(id)
+		return s != nil
+	}, time.Second, 5*time.Millisecond)
+	s, _, _ := res.get(id)
+	require.Equal(t, repl.Hash(), s.Container)
+}
+
+func TestTrackerExpire(t *testing.T) {
+	store, err := NewFileStore(filepath.Join(t.TempDir(), "pending.json"))
+	require.NoError(t, err)
+	tr, a, rpc, res := newTestTracker(t, store, Options{MaxReplacements: -1})
+
+	// Not started yet, it's only stored.
+	tx := makeTx(t, a)
+	id, _, err := tr.Track(tx)
+	require.NoError(t, err)
+
+	// Tracker is restored from the store.
+	store, err = NewFileStore(store.path)
+	require.NoError(t, err)
+	tr, err = New(a, rpc, store, tr.opts)
+	require.NoError(t, err)
+	p := tr.Pending()
+	require.Equal(t, 1, len(p))
+	require.Equal(t, tx.Hash(), p[0].Tx.Hash())
+
+	tr.Start()
+	defer tr.Stop()
+	rpc.addBlocks(tx.ValidUntilBlock)
+	require.Eventually(t, func() bool {
+		_, _, e := res.get(id)
+		return e
+	}, time.Second, 5*time.Millisecond)
+	require.Equal(t, 1, rpc.sentCount()) // No replacements.
+	txs, err := store.List()
+	require.NoError(t, err)
+	require.Equal(t, 0, len(txs))
+}
@@ -57,16 +57,27 @@ import (
 // will also be closed on disconnection from server or on situation when it's
 // impossible to send a subsequent notification to the subscriber's channel and
 // CloseNotificationChannelIfFull option is on.
+//
+// Reconnect option changes this behaviour, see WSOptions documentation for
+// details.
 type WSClient struct {
 	Client
 
-	ws          *websocket.Conn
+	// connLock protects readerDone and writerDone that are replaced on
+	// reconnection.
+	connLock    sync.RWMutex
+	wsEndpoint  string
 	wsOpts      WSOptions
 	readerDone  chan struct{}
 	writerDone  chan struct{}
 	requests    chan *neorpc.Request
 	shutdown    chan struct{}
 	closeCalled atomic.Bool
+	// done is closed when the client is completely stopped in Reconnect mode.
+	done chan struct{}
+	// reconnectLock serializes subscriptions restoration with subscription
+	// management in Reconnect mode.
+	reconnectLock sync.Mutex
 
 	closeErrLock sync.RWMutex
 	closeErr     error
@@ -78,6 +89,9 @@ type WSClient struct {
 	// notifications, if channel is not in the receivers list and corresponding subscription
 	// still exists, notification must not be sent.
 	receivers map[any][]string
+	// track contains Reconnect mode state, it's protected by
+	// subscriptionsLock.
+	track tracker
 
 	respLock     sync.RWMutex
 	respChannels map[uint64]chan *neorpc.Response
@@ -96,6 +110,29 @@ type WSOptions struct {
 	// thus it's still the caller's duty to call Unsubscribe() for this
 	// subscription.
 	CloseNotificationChannelIfFull bool
+	// Reconnect enables automatic reconnection mode. If the connection is
+	// lost (or the server reports missed events), WSClient doesn't close
+	// subscription channels and doesn't cancel its context, instead it
+	// reconnects to the same endpoint, restores all subscriptions (IDs
+	// returned from Receive* methods remain valid) and delivers events
+	// from the blocks accepted while the client was disconnected, so that
+	// subscribers see the same event sequence they'd see without
+	// disconnection (see docs/notifications.md for details and
+	// limitations). Requests made while the connection is down fail
+	// with ErrWSConnLost. Init must be called before the first
+	// subscription in this mode. Subscription channels are closed and
+	// the context is cancelled only on Close or if the client fails to
+	// reconnect after ReconnectAttempts tries.
+	Reconnect bool
+	// ReconnectMinDelay is the delay before the second reconnection
+	// attempt (the first one is made immediately), it's doubled after
+	// every failed attempt up to ReconnectMaxDelay. 1s and 1m are used by
+	// default.
+	ReconnectMinDelay time.Duration
+	ReconnectMaxDelay time.Duration
+	// ReconnectAttempts is the maximum number of consecutive failed
+	// reconnection attempts, 0 means no limit.
+	ReconnectAttempts int
 }
 
 // notificationReceiver is an interface aimed to provide WS subscriber functionality
@@ -302,6 +339,45 @@ func (r *executionNotificationReceiver) Close() {
 	close(r.ch)
 }
 
+// eventReceiver stores information about execution notifications subscriber
+// that receives notifications converted to some specific type.
+type eventReceiver[T any] struct {
+	executionNotificationReceiver
+	ch      chan<- T
+	convert func(*state.ContainedNotificationEvent) (T, error)
+}
+
+// Receiver implements notificationReceiver interface.
+func (r *eventReceiver[T]) Receiver() any {
+	return r.ch
+}
+
+// TrySend implements notificationReceiver interface.
+func (r *eventReceiver[T]) TrySend(ntf Notification, nonBlocking bool) (bool, bool) {
+	if !rpcevent.Matches(r, ntf) {
+		return false, false
+	}
+	ev, err := r.convert(ntf.Value.(*state.ContainedNotificationEvent))
+	if err != nil {
+		return false, false
+	}
+	if nonBlocking {
+		select {
+		case r.ch <- ev:
+		default:
+			return true, true
+		}
+	} else {
+		r.ch <- ev
+	}
+	return true, false
+}
+
+// Close implements notificationReceiver interface.
+func (r *eventReceiver[T]) Close() {
+	close(r.ch)
+}
+
 // executionReceiver stores information about application execution results subscriber.
 type executionReceiver struct {
 	filter *neorpc.ExecutionFilter
@@ -453,29 +529,23 @@ var errConnClosedByUser = errors.New("connection closed by user")
 // You should call Init method to initialize the network magic the client is
 // operating on.
 func NewWS(ctx context.Context, endpoint string, opts WSOptions) (*WSClient, error) {
-	dialer := websocket.Dialer{HandshakeTimeout: opts.DialTimeout}
-	ws, resp, err := dialer.DialContext(ctx, endpoint, nil)
-	if resp != nil && resp.Body != nil { // Can be non-nil even with error returned.
-		defer resp.Body.Close() // Not exactly required by websocket, but let's do this for bodyclose checker.
-	}
+	ws, err := dialWS(ctx, endpoint, opts)
 	if err != nil {
-		if resp != nil && resp.Body != nil {
-			var srvErr neorpc.HeaderAndError
-
-			dec := json.NewDecoder(resp.Body)
-			decErr := dec.Decode(&srvErr)
-			if decErr == nil && srvErr.Error != nil {
-				err = srvErr.Error
-			}
-		}
 		return nil, err
 	}
+	if opts.ReconnectMinDelay <= 0 {
+		opts.ReconnectMinDelay = defaultReconnectMinDelay
+	}
+	if opts.ReconnectMaxDelay <= 0 {
+		opts.ReconnectMaxDelay = defaultReconnectMaxDelay
+	}
 	wsc := &WSClient{
 		Client: Client{},
 
-		ws:            ws,
+		wsEndpoint:    endpoint,
 		wsOpts:        opts,
 		shutdown:      make(chan struct{}),
+		done:          make(chan struct{}),
 		readerDone:    make(chan struct{}),
 		writerDone:    make(chan struct{}),
 		respChannels:  make(map[uint64]chan *neorpc.Response),
@@ -490,12 +560,38 @@ func NewWS(ctx context.Context, endpoint string, opts WSOptions) (*WSClient, err
 	}
 	wsc.Client.cli = nil
 
-	go wsc.wsReader()
-	go wsc.wsWriter()
-	wsc.requestF = wsc.makeWsRequest
+	go wsc.wsReader(ws, wsc.readerDone)
+	go wsc.wsWriter(ws, wsc.readerDone, wsc.writerDone)
+	if opts.Reconnect {
+		go wsc.supervise()
+	}
+	wsc.requestF = chainMiddlewares(wsc.ctx, wsc.makeWsRequest, opts.Middlewares)
+	wsc.batchF = nil
 	return wsc, nil
 }
 
+// dialWS establishes a new websocket connection.
+func dialWS(ctx context.Context, endpoint string, opts WSOptions) (*websocket.Conn, error) {
+	dialer := websocket.Dialer{HandshakeTimeout: opts.DialTimeout}
+	ws, resp, err := dialer.DialContext(ctx, endpoint, nil)
+	if resp != nil && resp.Body != nil { // Can be non-nil even with error returned.
+		defer resp.Body.Close() // Not exactly required by websocket, but let's do this for bodyclose checker.
+	}
+	if err != nil {
+		if resp != nil && resp.Body != nil {
+			var srvErr neorpc.HeaderAndError
+
+			dec := json.NewDecoder(resp.Body)
+			decErr := dec.Decode(&srvErr)
+			if decErr == nil && srvErr.Error != nil {
+				err = srvErr.Error
+			}
+		}
+		return nil, err
+	}
+	return ws, nil
+}
+
 // Close closes connection to the remote side rendering this client instance
 // unusable.
 func (c *WSClient) Close() {
@@ -509,14 +605,25 @@ func (c *WSClient) Close() {
 		// Call to cancel will send signal to all users of Context().
 		c.Client.ctxCancel()
 	}
+	if c.wsOpts.Reconnect {
+		<-c.done
+		return
+	}
 	<-c.readerDone
 }
 
-func (c *WSClient) wsReader() {
-	c.ws.SetReadLimit(wsReadLimit)
-	c.ws.SetPongHandler(func(string) error {
-		err := c.ws.SetReadDeadline(time.Now().Add(wsPongLimit))
-		if err != nil {
+// conn returns the current connection reader and writer done channels.
+func (c *WSClient) conn() (chan struct{}, chan struct{}) {
+	c.connLock.RLock()
+	defer c.connLock.RUnlock()
+	return c.readerDone, c.writerDone
+}
+
+func (c *WSClient) wsReader(ws *websocket.Conn, readerDone chan struct{}) {
+	ws.SetReadLimit(wsReadLimit)
+	ws.SetPongHandler(func(string) error {
+		err := ws.SetReadDeadline(time.Now().Add(wsPongLimit))
+		if err != nil && !c.wsOpts.Reconnect {
 			c.setCloseErr(fmt.Errorf("failed to set pong read deadline: %w", err))
 		}
 		return err
@@ -525,12 +632,12 @@ func (c *WSClient) wsReader() {
 readloop:
 	for {
 		rr := new(requestResponse)
-		err := c.ws.SetReadDeadline(time.Now().Add(wsPongLimit))
+		err := ws.SetReadDeadline(time.Now().Add(wsPongLimit))
 		if err != nil {
 			connCloseErr = fmt.Errorf("failed to set response read deadline: %w", err)
 			break readloop
 		}
-		err = c.ws.ReadJSON(rr)
+		err = ws.ReadJSON(rr)
 		if err != nil {
 			// Timeout/connection loss/malformed response.
 			connCloseErr = fmt.Errorf("failed to read JSON response (timeout/connection loss/malformed response): %w", err)
@@ -543,6 +650,11 @@ readloop:
 				connCloseErr = fmt.Errorf("failed to perse event ID from string %s: %w", rr.Method, err)
 				break readloop
 			}
+			if event == neorpc.MissedEventID && c.wsOpts.Reconnect {
+				// Missed events are restored after reconnection.
+				connCloseErr = errors.New("server reported missed events")
+				break readloop
+			}
 			if event != neorpc.MissedEventID && len(rr.RawParams) != 1 {
 				// Bad event received.
 				connCloseErr = fmt.Errorf("bad event received: %s / %d", event, len(rr.RawParams))
@@ -608,22 +720,33 @@ readloop:
 			break readloop
 		}
 	}
-	if connCloseErr != nil {
+	if connCloseErr != nil && !c.wsOpts.Reconnect {
 		c.setCloseErr(connCloseErr)
 	}
-	close(c.readerDone)
 	c.respLock.Lock()
+	close(readerDone)
 	for _, ch := range c.respChannels {
 		close(ch)
 	}
 	c.respChannels = nil
 	c.respLock.Unlock()
+	// Reconnection is handled by supervise in Reconnect mode.
+	if !c.wsOpts.Reconnect {
+		c.stop()
+	}
+}
+
+// stop closes all subscription channels and cancels the client context.
+func (c *WSClient) stop() {
 	c.subscriptionsLock.Lock()
 	for rcvrCh, ids := range c.receivers {
 		c.dropSubCh(rcvrCh, ids[0], true)
 	}
 	c.subscriptionsLock.Unlock()
 	c.Client.ctxCancel()
+	if c.wsOpts.Reconnect {
+		close(c.done)
+	}
 }
 
 // dropSubCh closes corresponding subscriber's channel and removes it from the
@@ -639,48 +762,54 @@ func (c *WSClient) dropSubCh(rcvrCh any, id string, ignoreCloseNotificationChann
 	}
 }
 
-func (c *WSClient) wsWriter() {
+func (c *WSClient) wsWriter(ws *websocket.Conn, readerDone, writerDone chan struct{}) {
 	pingTicker := time.NewTicker(wsPingPeriod)
-	defer c.ws.Close()
+	defer ws.Close()
 	defer pingTicker.Stop()
-	defer close(c.writerDone)
+	defer close(writerDone)
 	var connCloseErr error
 writeloop:
 	for {
 		select {
 		case <-c.shutdown:
 			return
-		case <-c.readerDone:
+		case <-readerDone:
 			return
 		case req, ok := <-c.requests:
 			if !ok {
 				return
 			}
-			if err := c.ws.SetWriteDeadline(time.Now().Add(c.opts.RequestTimeout)); err != nil {
+			if err := ws.SetWriteDeadline(time.Now().Add(c.opts.RequestTimeout)); err != nil {
 				connCloseErr = fmt.Errorf("failed to set request write deadline: %w", err)
 				break writeloop
 			}
-			if err := c.ws.WriteJSON(req); err != nil {
+			if err := ws.WriteJSON(req); err != nil {
 				connCloseErr = fmt.Errorf("failed to write JSON request (%s / %d): %w", req.Method, len(req.Params), err)
 				break writeloop
 			}
 		case <-pingTicker.C:
-			if err := c.ws.SetWriteDeadline(time.Now().Add(wsWriteLimit)); err != nil {
+			if err := ws.SetWriteDeadline(time.Now().Add(wsWriteLimit)); err != nil {
 				connCloseErr = fmt.Errorf("failed to set ping write deadline: %w", err)
 				break writeloop
 			}
-			if err := c.ws.WriteMessage(websocket.PingMessage, []byte{}); err != nil {
+			if err := ws.WriteMessage(websocket.PingMessage, []byte{}); err != nil {
 				connCloseErr = fmt.Errorf("failed to write ping message: %w", err)
 				break writeloop
 			}
 		}
 	}
-	if connCloseErr != nil {
+	if connCloseErr != nil && !c.wsOpts.Reconnect {
 		c.setCloseErr(connCloseErr)
 	}
 }
 
 func (c *WSClient) notifySubscribers(ntf Notification) {
+	if c.wsOpts.Reconnect {
+		c.subscriptionsLock.Lock()
+		c.push(ntf)
+		c.subscriptionsLock.Unlock()
+		return
+	}
 	if ntf.Type == neorpc.MissedEventID {
 		c.subscriptionsLock.Lock()
 		for rcvr, ids := range c.receivers {
@@ -691,6 +820,13 @@ func (c *WSClient) notifySubscribers(ntf Notification) {
 		return
 	}
 	c.subscriptionsLock.Lock()
+	c.sendToReceivers(ntf)
+	c.subscriptionsLock.Unlock()
+}
+
+// sendToReceivers sends notification to all matching receivers, it must be
+// called with subscriptionsLock taken.
+func (c *WSClient) sendToReceivers(ntf Notification) {
 	for rcvrCh, ids := range c.receivers {
 		for _, id := range ids {
 			ok, dropCh := c.subscriptions[id].TrySend(ntf, c.wsOpts.CloseNotificationChannelIfFull)
@@ -703,7 +839,6 @@ func (c *WSClient) notifySubscribers(ntf Notification) {
 			}
 		}
 	}
-	c.subscriptionsLock.Unlock()
 }
 
 func (c *WSClient) unregisterRespChannel(id uint64) {
@@ -733,9 +868,10 @@ func (c *WSClient) closeErrOrConnLost() (err error) {
 
 func (c *WSClient) makeWsRequest(r *neorpc.Request) (*neorpc.Response, error) {
 	ch := make(chan *neorpc.Response)
+	readerDone, writerDone := c.conn()
 	c.respLock.Lock()
 	select {
-	case <-c.readerDone:
+	case <-readerDone:
 		c.respLock.Unlock()
 		return nil, fmt.Errorf("%w: before registering response channel", c.closeErrOrConnLost())
 	default:
@@ -743,16 +879,16 @@ func (c *WSClient) makeWsRequest(r *neorpc.Request) (*neorpc.Response, error) {
 		c.respLock.Unlock()
 	}
 	select {
-	case <-c.readerDone:
+	case <-readerDone:
 		return nil, fmt.Errorf("%w: before sending the request", c.closeErrOrConnLost())
-	case <-c.writerDone:
+	case <-writerDone:
 		return nil, fmt.Errorf("%w: before sending the request", c.closeErrOrConnLost())
 	case c.requests <- r:
 	}
 	select {
-	case <-c.readerDone:
+	case <-readerDone:
 		return nil, fmt.Errorf("%w: while waiting for the response", c.closeErrOrConnLost())
-	case <-c.writerDone:
+	case <-writerDone:
 		return nil, fmt.Errorf("%w: while waiting for the response", c.closeErrOrConnLost())
 	case resp, ok := <-ch:
 		if !ok {
@@ -771,6 +907,13 @@ func (c *WSClient) performSubscription(params []any, rcvr notificationReceiver)
 			return "", err
 		}
 	}
+	if c.wsOpts.Reconnect {
+		c.reconnectLock.Lock()
+		defer c.reconnectLock.Unlock()
+		if err := c.startTracking(); err != nil {
+			return "", err
+		}
+	}
 	if err := c.performRequest("subscribe", params, &resp); err != nil {
 		return "", err
 	}
@@ -867,6 +1010,32 @@ func (c *WSClient) ReceiveExecutionNotifications(flt *neorpc.NotificationFilter,
 	return c.performSubscription(params, r)
 }
 
+// ReceiveEvents registers provided channel as a receiver for execution
+// events converted into T with the given function, notifications that can't
+// be converted are skipped. Events can be filtered by the given
+// NotificationFilter, nil value doesn't add any filter. It's mostly useful for
+// contract-specific events and is used by generated RPC bindings. See WSClient
+// comments for generic Receive* behaviour details.
+func ReceiveEvents[T any](c *WSClient, flt *neorpc.NotificationFilter, rcvr chan<- T, convert func(*state.ContainedNotificationEvent) (T, error)) (string, error) {
+	if rcvr == nil {
+		return "", ErrNilNotificationReceiver
+	}
+	if convert == nil {
+		return "", errors.New("nil converter")
+	}
+	params := []any{"notification_from_execution"}
+	if flt != nil {
+		flt = flt.Copy()
+		params = append(params, *flt)
+	}
+	r := &eventReceiver[T]{
+		executionNotificationReceiver: executionNotificationReceiver{filter: flt},
+		ch:                            rcvr,
+		convert:                       convert,
+	}
+	return c.performSubscription(params, r)
+}
+
 // ReceiveExecutions registers provided channel as a receiver for
 // application execution result events generated during transaction execution.
 // Events can be filtered by the given ExecutionFilter, nil value doesn't add any filter.
@@ -935,7 +1104,9 @@ func (c *WSClient) UnsubscribeAll() error {
 	c.subscriptionsLock.Lock()
 	subs := make([]string, 0, len(c.subscriptions))
 	for id := range c.subscriptions {
-		subs = append(subs, id)
+		if id != c.track.id {
+			subs = append(subs, id)
+		}
 	}
 	c.subscriptionsLock.Unlock()
 
@@ -961,7 +1132,11 @@ func (c *WSClient) UnsubscribeAll() error {
 // may still receive WS notifications.
 func (c *WSClient) performUnsubscription(id string) error {
 	var resp bool
-	if err := c.performRequest("unsubscribe", []any{id}, &resp); err != nil {
+	if c.wsOpts.Reconnect {
+		c.reconnectLock.Lock()
+		defer c.reconnectLock.Unlock()
+	}
+	if err := c.performRequest("unsubscribe", []any{c.serverID(id)}, &resp); err != nil {
 		return err
 	}
 	if !resp {
@@ -989,6 +1164,7 @@ func (c *WSClient) performUnsubscription(id string) error {
 		c.receivers[ch] = ids
 	}
 	delete(c.subscriptions, id)
+	delete(c.track.serverIDs, id)
 	return nil
 }
 
@@ -1014,7 +1190,8 @@ func (c *WSClient) GetError() error {
 	return c.closeErr
 }
 
-// Context returns WSClient Cancel context that will be terminated on Client shutdown.
+// Context returns WSClient Cancel context that will be terminated on Client
+// shutdown (including disconnection unless Reconnect option is enabled).
 func (c *WSClient) Context() context.Context {
 	return c.Client.ctx
 }
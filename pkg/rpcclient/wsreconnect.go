@@ -0,0 +1,451 @@
+package rpcclient
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/rpcevent"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+)
+
+const (
+	// defaultReconnectMinDelay is the default WSOptions.ReconnectMinDelay.
+	defaultReconnectMinDelay = time.Second
+	// defaultReconnectMaxDelay is the default WSOptions.ReconnectMaxDelay.
+	defaultReconnectMaxDelay = time.Minute
+)
+
+// tracker contains WSClient state used to restore the event stream after
+// reconnection. Blocks are the synchronization points here: server sends all
+// execution, notification and transaction events of some block before its
+// header_of_added_block event (followed by block_added), so the last header
+// received determines the height all events were delivered for.
+type tracker struct {
+	// id is the internal header subscription ID (empty if tracking is not
+	// started yet).
+	id string
+	// serverIDs maps subscription IDs returned to the user to the current
+	// server-side IDs (they're the same until reconnection).
+	serverIDs map[string]string
+	// height is the index of the last block header received.
+	height uint32
+	// lastBlock is the index of the last block received.
+	lastBlock uint32
+	// events is the number of execution, notification and transaction events
+	// received after the last header (they belong to the next block).
+	events int
+
+	// restoring is set while subscriptions are restored, events are kept in
+	// pending then.
+	restoring bool
+	// filtering is set after restoration until the first header of a block
+	// that is newer than skipTill, block events are kept in pending until
+	// the header is received and dropped if they're already delivered.
+	filtering bool
+	skipTill  uint32
+	pending   []Notification
+}
+
+// trackReceiver is an internal header_of_added_block subscriber used to track
+// the height of the events delivered.
+type trackReceiver struct{}
+
+// EventID implements neorpc.Comparator interface.
+func (r *trackReceiver) EventID() neorpc.EventID {
+	return neorpc.HeaderOfAddedBlockEventID
+}
+
+// Filter implements neorpc.Comparator interface.
+func (r *trackReceiver) Filter() neorpc.SubscriptionFilter {
+	return nil
+}
+
+// Receiver implements notificationReceiver interface.
+func (r *trackReceiver) Receiver() any {
+	return r
+}
+
+// TrySend implements notificationReceiver interface, headers are processed
+// in deliver, so it does nothing.
+func (r *trackReceiver) TrySend(ntf Notification, nonBlocking bool) (bool, bool) {
+	return rpcevent.Matches(r, ntf), false
+}
+
+// Close implements notificationReceiver interface.
+func (r *trackReceiver) Close() {}
+
+// startTracking subscribes to headers to track the current height if it's
+// not done yet. It must be called with reconnectLock taken.
+func (c *WSClient) startTracking() error {
+	c.subscriptionsLock.RLock()
+	started := c.track.id != ""
+	c.subscriptionsLock.RUnlock()
+	if started {
+		return nil
+	}
+	// Headers can't be decoded without it.
+	if !c.cache.initDone {
+		return errNetworkNotInitialized
+	}
+	var (
+		id   string
+		rcvr = new(trackReceiver)
+	)
+	if err := c.performRequest("subscribe", []any{rcvr.EventID().String()}, &id); err != nil {
+		return err
+	}
+	// Subscription goes first, so that no block is missed.
+	count, err := c.GetBlockCount()
+	if err != nil {
+		return err
+	}
+
+	c.subscriptionsLock.Lock()
+	defer c.subscriptionsLock.Unlock()
+	c.track.id = id
+	c.subscriptions[id] = rcvr
+	c.receivers[rcvr] = []string{id}
+	if c.track.serverIDs == nil {
+		c.track.serverIDs = make(map[string]string)
+	}
+	if count > 0 && count-1 > c.track.height {
+		c.track.height = count - 1
+		c.track.lastBlock = count - 1
+		c.track.events = 0
+	}
+	return nil
+}
+
+// serverID returns the current server-side ID of the subscription.
+func (c *WSClient) serverID(id string) string {
+	c.subscriptionsLock.RLock()
+	defer c.subscriptionsLock.RUnlock()
+	if sid, ok := c.track.serverIDs[id]; ok {
+		return sid
+	}
+	return id
+}
+
+// push handles an event received from the server in Reconnect mode, it must
+// be called with subscriptionsLock taken.
+func (c *WSClient) push(ntf Notification) {
+	var t = &c.track
+	if t.restoring {
+		t.pending = append(t.pending, ntf)
+		return
+	}
+	if !t.filtering {
+		c.deliver(ntf)
+		return
+	}
+	switch ntf.Type {
+	case neorpc.NotaryRequestEventID:
+		// Not bound to blocks.
+		c.deliver(ntf)
+	case neorpc.BlockEventID:
+		if ntf.Value.(*block.Block).Index > t.skipTill {
+			c.deliver(ntf)
+		}
+	case neorpc.HeaderOfAddedBlockEventID:
+		if ntf.Value.(*block.Header).Index <= t.skipTill {
+			// Everything is delivered already.
+			t.pending = nil
+			return
+		}
+		t.filtering = false
+		pending := t.pending
+		t.pending = nil
+		for _, p := range pending {
+			c.deliver(p)
+		}
+		c.deliver(ntf)
+	default:
+		t.pending = append(t.pending, ntf)
+	}
+}
+
+// deliver sends the event to subscribers and updates the tracked height, it
+// must be called with subscriptionsLock taken.
+func (c *WSClient) deliver(ntf Notification) {
+	switch ntf.Type {
+	case neorpc.HeaderOfAddedBlockEventID:
+		c.track.height = ntf.Value.(*block.Header).Index
+		c.track.events = 0
+	case neorpc.BlockEventID:
+		c.track.lastBlock = ntf.Value.(*block.Block).Index
+	case neorpc.ExecutionEventID, neorpc.NotificationEventID, neorpc.TransactionEventID:
+		c.track.events++
+	}
+	c.sendToReceivers(ntf)
+}
+
+// supervise waits for the connection to be lost and reconnects to the server
+// in Reconnect mode.
+func (c *WSClient) supervise() {
+	for {
+		readerDone, _ := c.conn()
+		<-readerDone
+		if c.closeCalled.Load() || !c.reconnect() {
+			c.stop()
+			return
+		}
+	}
+}
+
+// reconnect tries to establish a new connection and restore subscriptions
+// with exponential backoff. It returns false if the client is closed or
+// the number of attempts is exceeded.
+func (c *WSClient) reconnect() bool {
+	c.subscriptionsLock.Lock()
+	c.track.restoring = true
+	c.track.filtering = false
+	c.track.pending = nil
+	c.subscriptionsLock.Unlock()
+
+	var delay time.Duration
+	for attempt := 1; ; attempt++ {
+		if delay > 0 {
+			timer := time.NewTimer(delay)
+			select {
+			case <-c.shutdown:
+				timer.Stop()
+				return false
+			case <-timer.C:
+			}
+		}
+		err := c.connect()
+		if err == nil {
+			return true
+		}
+		if c.closeCalled.Load() {
+			return false
+		}
+		if c.wsOpts.ReconnectAttempts > 0 && attempt >= c.wsOpts.ReconnectAttempts {
+			c.setCloseErr(fmt.Errorf("failed to reconnect: %w", err))
+			return false
+		}
+		switch {
+		case delay == 0:
+			delay = c.wsOpts.ReconnectMinDelay
+		case delay < c.wsOpts.ReconnectMaxDelay:
+			delay *= 2
+		}
+		if delay > c.wsOpts.ReconnectMaxDelay {
+			delay = c.wsOpts.ReconnectMaxDelay
+		}
+	}
+}
+
+// connect establishes a new connection and restores subscriptions over it.
+func (c *WSClient) connect() error {
+	ws, err := dialWS(c.ctx, c.wsEndpoint, c.wsOpts)
+	if err != nil {
+		return err
+	}
+	var (
+		readerDone = make(chan struct{})
+		writerDone = make(chan struct{})
+	)
+	c.respLock.Lock()
+	c.respChannels = make(map[uint64]chan *neorpc.Response)
+	c.respLock.Unlock()
+	c.connLock.Lock()
+	c.readerDone = readerDone
+	c.writerDone = writerDone
+	c.connLock.Unlock()
+	go c.wsReader(ws, readerDone)
+	go c.wsWriter(ws, readerDone, writerDone)
+
+	err = c.restore()
+	if err != nil {
+		_ = ws.Close()
+		<-readerDone
+		return fmt.Errorf("failed to restore subscriptions: %w", err)
+	}
+	return nil
+}
+
+// restore resubscribes to all events with the same parameters and delivers
+// events missed while the client was disconnected.
+func (c *WSClient) restore() error {
+	c.reconnectLock.Lock()
+	defer c.reconnectLock.Unlock()
+
+	c.subscriptionsLock.RLock()
+	subs := make(map[string]notificationReceiver, len(c.subscriptions))
+	for id, rcvr := range c.subscriptions {
+		subs[id] = rcvr
+	}
+	c.subscriptionsLock.RUnlock()
+
+	serverIDs := make(map[string]string, len(subs))
+	for id, rcvr := range subs {
+		var (
+			sid    string
+			params = []any{rcvr.EventID().String()}
+		)
+		if flt := rcvr.Filter(); flt != nil {
+			params = append(params, flt)
+		}
+		if err := c.performRequest("subscribe", params, &sid); err != nil {
+			return fmt.Errorf("%s subscription: %w", rcvr.EventID(), err)
+		}
+		serverIDs[id] = sid
+	}
+	c.subscriptionsLock.Lock()
+	c.track.serverIDs = serverIDs
+	tracking := c.track.id != ""
+	c.subscriptionsLock.Unlock()
+
+	var skipTill uint32
+	if tracking {
+		count, err := c.GetBlockCount()
+		if err != nil {
+			return err
+		}
+		skipTill = count - 1
+		err = c.backfill(skipTill)
+		if err != nil {
+			return err
+		}
+	}
+
+	c.subscriptionsLock.Lock()
+	defer c.subscriptionsLock.Unlock()
+	pending := c.track.pending
+	c.track.pending = nil
+	c.track.restoring = false
+	c.track.filtering = tracking
+	c.track.skipTill = skipTill
+	for _, ntf := range pending {
+		c.push(ntf)
+	}
+	return nil
+}
+
+// backfill delivers events for all blocks after the last one delivered up to
+// the given height.
+func (c *WSClient) backfill(height uint32) error {
+	c.subscriptionsLock.RLock()
+	var (
+		from      = c.track.height
+		lastBlock = c.track.lastBlock
+		skip      = c.track.events
+		events    = make(map[neorpc.EventID]bool)
+	)
+	for _, rcvr := range c.subscriptions {
+		events[rcvr.EventID()] = true
+	}
+	c.subscriptionsLock.RUnlock()
+
+	if events[neorpc.BlockEventID] && lastBlock < from && from <= height {
+		b, err := c.GetBlockByIndex(from)
+		if err != nil {
+			return err
+		}
+		c.subscriptionsLock.Lock()
+		c.deliver(Notification{Type: neorpc.BlockEventID, Value: b})
+		c.subscriptionsLock.Unlock()
+	}
+	for h := from + 1; h <= height; h++ {
+		ntfs, err := c.blockEvents(h, events)
+		if err != nil {
+			return err
+		}
+		c.subscriptionsLock.Lock()
+		for _, ntf := range ntfs {
+			// Some events of the first block can be delivered already.
+			if skip > 0 && h == from+1 && ntf.Type != neorpc.HeaderOfAddedBlockEventID &&
+				ntf.Type != neorpc.BlockEventID && c.matchesAny(ntf) {
+				skip--
+				continue
+			}
+			c.deliver(ntf)
+		}
+		c.subscriptionsLock.Unlock()
+	}
+	return nil
+}
+
+// matchesAny checks whether the event matches any subscription, it must be
+// called with subscriptionsLock taken.
+func (c *WSClient) matchesAny(ntf Notification) bool {
+	for _, rcvr := range c.subscriptions {
+		if rpcevent.Matches(rcvr, ntf) {
+			return true
+		}
+	}
+	return false
+}
+
+// blockEvents returns events for the block with the given index in the same
+// order the server sends them. Only events of the given types (and headers)
+// are returned.
+func (c *WSClient) blockEvents(index uint32, events map[neorpc.EventID]bool) ([]Notification, error) {
+	b, err := c.GetBlockByIndex(index)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		res      []Notification
+		withLogs = events[neorpc.ExecutionEventID] || events[neorpc.NotificationEventID]
+		addExec  = func(container util.Uint256, exec state.Execution, isTx bool) {
+			if events[neorpc.ExecutionEventID] {
+				res = append(res, Notification{
+					Type:  neorpc.ExecutionEventID,
+					Value: &state.AppExecResult{Container: container, Execution: exec},
+				})
+			}
+			if !events[neorpc.NotificationEventID] || (isTx && exec.VMState != vmstate.Halt) {
+				return
+			}
+			for i := range exec.Events {
+				res = append(res, Notification{
+					Type: neorpc.NotificationEventID,
+					Value: &state.ContainedNotificationEvent{
+						Container:         container,
+						NotificationEvent: exec.Events[i],
+					},
+				})
+			}
+		}
+		persist []state.Execution
+	)
+	if withLogs {
+		log, err := c.GetApplicationLog(b.Hash(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("block %d application log: %w", index, err)
+		}
+		persist = log.Executions
+	}
+	// OnPersist goes first and PostPersist goes last.
+	if len(persist) > 0 {
+		addExec(b.Hash(), persist[0], false)
+	}
+	for _, tx := range b.Transactions {
+		if withLogs {
+			log, err := c.GetApplicationLog(tx.Hash(), nil)
+			if err != nil {
+				return nil, fmt.Errorf("transaction %s application log: %w", tx.Hash().StringLE(), err)
+			}
+			for _, exec := range log.Executions {
+				addExec(tx.Hash(), exec, true)
+			}
+		}
+		if events[neorpc.TransactionEventID] {
+			res = append(res, Notification{Type: neorpc.TransactionEventID, Value: tx})
+		}
+	}
+	if len(persist) > 1 {
+		addExec(b.Hash(), persist[1], false)
+	}
+	res = append(res, Notification{Type: neorpc.HeaderOfAddedBlockEventID, Value: &b.Header})
+	if events[neorpc.BlockEventID] {
+		res = append(res, Notification{Type: neorpc.BlockEventID, Value: b})
+	}
+	return res, nil
+}
@@ -485,6 +485,35 @@ func TestNotary(t *testing.T) {
 	checkFallbackTxs(t, r, false)
 	r, _ = checkCompleteMixedRequest(t, 3, true)
 	checkFallbackTxs(t, r, false)
+
+	// Request status of the completed mixed request.
+	mainHash := r[0].MainTransaction.Hash()
+	var status notary.RequestStatus
+	require.Eventually(t, func() bool {
+		var ok bool
+		status, ok = ntr1.Request(mainHash)
+		return ok && status.Sent
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, mainHash, status.Hash)
+	require.True(t, status.Valid)
+	require.Equal(t, len(r), len(status.Fallbacks))
+	require.Equal(t, 0, len(status.SentFallbacks))
+	require.Equal(t, 5, len(status.Witnesses))
+	for i, w := range status.Witnesses[:3] {
+		require.Equal(t, r[0].MainTransaction.Signers[i].Account, w.Account)
+		require.Equal(t, notary.Signature, w.Type)
+		require.Equal(t, 0, w.SigsLeft)
+		require.Equal(t, w.Keys, w.Signed)
+	}
+	require.Equal(t, notary.MultiSignature, status.Witnesses[3].Type)
+	require.Equal(t, 0, status.Witnesses[3].SigsLeft)
+	require.Equal(t, 3, len(status.Witnesses[3].Keys))
+	require.Equal(t, 2, len(status.Witnesses[3].Signed))
+	require.Equal(t, notary.Contract, status.Witnesses[4].Type)
+	require.Equal(t, bc.GetNotaryContractScriptHash(), status.Witnesses[4].Account)
+	require.Contains(t, ntr1.Requests(), status)
+	_, ok := ntr1.Request(util.Uint256{1, 2, 3})
+	require.False(t, ok)
 	// PostPersist: missing account
 	setFinalizeWithError(true)
 	r, requesters := checkCompleteStandardRequest(t, 1, false)
@@ -599,6 +628,13 @@ func TestNotary(t *testing.T) {
 	checkMainTx(t, requesters, requests, len(requests), false)
 	checkFallbackTxs(t, lucky, true)
 	checkFallbackTxs(t, unluckies, false)
+	// sent fallbacks are listed until all of them are sent
+	mainHash = requests[0].MainTransaction.Hash()
+	require.Eventually(t, func() bool {
+		status, ok = ntr1.Request(mainHash)
+		return ok && len(status.SentFallbacks) == len(lucky)
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, len(unluckies), len(status.Fallbacks))
 	// reset finalisation function for unlucky fallbacks to finalise without an error
 	setChoosy(false)
 	setFinalizeWithError(false)
@@ -609,6 +645,11 @@ func TestNotary(t *testing.T) {
 	checkMainTx(t, requesters, requests, len(requests), false)
 	checkFallbackTxs(t, lucky, true)
 	checkFallbackTxs(t, unluckies, true)
+	// request is removed after the last fallback is sent
+	require.Eventually(t, func() bool {
+		_, ok = ntr1.Request(mainHash)
+		return !ok
+	}, time.Second, 10*time.Millisecond)
 
 	// PostPersist: different NVBs
 	// check OnNewRequest with finalization error and different NVBs
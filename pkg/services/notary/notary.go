@@ -95,6 +95,9 @@ type (
 		// We stop trying to send the mainTx to the network if the chain reaches the minNotValidBefore height.
 		minNotValidBefore uint32
 		fallbacks         []*transaction.Transaction
+		// sentFallbacks contains fallback transactions that were
+		// successfully sent to the network.
+		sentFallbacks []*transaction.Transaction
 
 		witnessInfo []witnessInfo
 	}
@@ -260,10 +263,8 @@ func (n *Notary) OnNewRequest(payload *payload.P2PNotaryRequest) {
 	defer n.reqMtx.Unlock()
 	r, exists := n.requests[payload.MainTransaction.Hash()]
 	if exists {
-		for _, fb := range r.fallbacks {
-			if fb.Hash().Equals(payload.FallbackTransaction.Hash()) {
-				return // then we already have processed this request
-			}
+		if hasTx(r.fallbacks, payload.FallbackTransaction.Hash()) || hasTx(r.sentFallbacks, payload.FallbackTransaction.Hash()) {
+			return // then we already have processed this request
 		}
 		if nvbFallback < r.minNotValidBefore {
 			r.minNotValidBefore = nvbFallback
@@ -358,15 +359,31 @@ func (n *Notary) OnRequestRemoval(pld *payload.P2PNotaryRequest) {
 	if !ok {
 		return
 	}
-	for i, fb := range r.fallbacks {
-		if fb.Hash().Equals(pld.FallbackTransaction.Hash()) {
-			r.fallbacks = append(r.fallbacks[:i], r.fallbacks[i+1:]...)
-			break
+	r.fallbacks = removeTx(r.fallbacks, pld.FallbackTransaction.Hash())
+	r.sentFallbacks = removeTx(r.sentFallbacks, pld.FallbackTransaction.Hash())
+	if len(r.fallbacks) == 0 && len(r.sentFallbacks) == 0 {
+		delete(n.requests, r.main.Hash())
+	}
+}
+
+// hasTx checks whether the transaction with the given hash is in the list.
+func hasTx(txs []*transaction.Transaction, h util.Uint256) bool {
+	for _, tx := range txs {
+		if tx.Hash().Equals(h) {
+			return true
 		}
 	}
-	if len(r.fallbacks) == 0 {
-		delete(n.requests, r.main.Hash())
+	return false
+}
+
+// removeTx removes the transaction with the given hash from the list.
+func removeTx(txs []*transaction.Transaction, h util.Uint256) []*transaction.Transaction {
+	for i, tx := range txs {
+		if tx.Hash().Equals(h) {
+			return append(txs[:i], txs[i+1:]...)
+		}
 	}
+	return txs
 }
 
 // PostPersist is a callback which is called after a new block event is received.
@@ -475,8 +492,11 @@ func (n *Notary) newTxCallbackLoop() {
 			if isMain {
 				r.isSent = true
 			} else {
+				// Sent fallback is kept for the request status to be
+				// available until all of them are sent.
 				for i := range r.fallbacks {
 					if r.fallbacks[i].Hash() == tx.tx.Hash() {
+						r.sentFallbacks = append(r.sentFallbacks, r.fallbacks[i])
 						r.fallbacks = append(r.fallbacks[:i], r.fallbacks[i+1:]...)
 						break
 					}
@@ -0,0 +1,131 @@
+package notary
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+type (
+	// RequestStatus represents the state of notary request processing for
+	// the main transaction.
+	RequestStatus struct {
+		// Hash is the main transaction hash.
+		Hash util.Uint256
+		// Valid is false if main transaction witnesses can't be completed,
+		// only fallbacks are sent in this case.
+		Valid bool
+		// Sent is true if the main transaction was completed and sent to
+		// the network.
+		Sent bool
+		// NotValidBefore is the minimum NotValidBefore height of fallbacks,
+		// the main transaction can't be sent after this height.
+		NotValidBefore uint32
+		// Witnesses contains signature collection progress for every main
+		// transaction signer, it's empty for invalid requests.
+		Witnesses []WitnessStatus
+		// Fallbacks contains pending fallback transactions.
+		Fallbacks []FallbackStatus
+		// SentFallbacks contains fallback transactions sent to the network.
+		SentFallbacks []FallbackStatus
+	}
+
+	// WitnessStatus represents signature collection progress for a main
+	// transaction signer.
+	WitnessStatus struct {
+		Account util.Uint160
+		Type    RequestType
+		// SigsLeft is the number of signatures left to collect.
+		SigsLeft int
+		// Keys contains all keys of the signature or multisignature
+		// witness.
+		Keys keys.PublicKeys
+		// Signed contains keys that provided valid signatures.
+		Signed keys.PublicKeys
+	}
+
+	// FallbackStatus is a fallback transaction description.
+	FallbackStatus struct {
+		Hash            util.Uint256
+		NotValidBefore  uint32
+		ValidUntilBlock uint32
+	}
+)
+
+// Requests returns the status of all notary requests known to the service
+// sorted by main transaction hash.
+func (n *Notary) Requests() []RequestStatus {
+	n.reqMtx.RLock()
+	res := make([]RequestStatus, 0, len(n.requests))
+	for h, r := range n.requests {
+		res = append(res, r.status(h))
+	}
+	n.reqMtx.RUnlock()
+	sort.Slice(res, func(i, j int) bool {
+		return bytes.Compare(res[i].Hash[:], res[j].Hash[:]) < 0
+	})
+	return res
+}
+
+// Request returns the status of notary request for the main transaction with
+// the given hash. false is returned if the request is not known to the
+// service (it wasn't received or it was already removed from the pool).
+func (n *Notary) Request(h util.Uint256) (RequestStatus, bool) {
+	n.reqMtx.RLock()
+	defer n.reqMtx.RUnlock()
+	r, ok := n.requests[h]
+	if !ok {
+		return RequestStatus{}, false
+	}
+	return r.status(h), true
+}
+
+// status returns the request status, it must be called with the requests
+// lock held.
+func (r *request) status(h util.Uint256) RequestStatus {
+	res := RequestStatus{
+		Hash:           h,
+		Valid:          r.witnessInfo != nil,
+		Sent:           r.isSent,
+		NotValidBefore: r.minNotValidBefore,
+		Fallbacks:      fallbackStatuses(r.fallbacks),
+		SentFallbacks:  fallbackStatuses(r.sentFallbacks),
+	}
+	for i, wi := range r.witnessInfo {
+		ws := WitnessStatus{
+			Account:  r.main.Signers[i].Account,
+			Type:     wi.typ,
+			SigsLeft: int(wi.nSigsLeft),
+			Keys:     append(keys.PublicKeys{}, wi.pubs...),
+		}
+		switch wi.typ {
+		case Signature:
+			if wi.nSigsLeft == 0 {
+				ws.Signed = keys.PublicKeys{wi.pubs[0]}
+			}
+		case MultiSignature:
+			for _, pub := range wi.pubs {
+				if wi.sigs[pub] != nil {
+					ws.Signed = append(ws.Signed, pub)
+				}
+			}
+		}
+		res.Witnesses = append(res.Witnesses, ws)
+	}
+	return res
+}
+
+func fallbackStatuses(txs []*transaction.Transaction) []FallbackStatus {
+	res := make([]FallbackStatus, len(txs))
+	for i, tx := range txs {
+		res[i] = FallbackStatus{
+			Hash:            tx.Hash(),
+			NotValidBefore:  tx.GetAttributes(transaction.NotValidBeforeT)[0].Value.(*transaction.NotValidBefore).Height,
+			ValidUntilBlock: tx.ValidUntilBlock,
+		}
+	}
+	return res
+}
@@ -0,0 +1,197 @@
+package oracle
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+)
+
+// maxCacheEntries is the maximum number of cached responses, new responses
+// are not cached when this limit is reached until older ones expire.
+const maxCacheEntries = 1024
+
+type (
+	// responseCache is a short-lived cache of successful filtered oracle
+	// responses keyed by URL and filter. It also deduplicates concurrent
+	// requests for the same key: only one of them is processed while others
+	// wait for its result.
+	responseCache struct {
+		ttl time.Duration
+
+		lock    sync.Mutex
+		entries map[string]*cacheEntry
+	}
+
+	// cacheEntry is a cached (or being processed) response.
+	cacheEntry struct {
+		// done is closed when the result is ready.
+		done    chan struct{}
+		result  []byte
+		code    transaction.OracleResponseCode
+		expires time.Time
+	}
+
+	// hostLimiter limits the number of concurrent requests per URI host.
+	hostLimiter struct {
+		limit int
+
+		lock  sync.Mutex
+		hosts map[string]*hostSlots
+	}
+
+	// hostSlots is a per-host semaphore with the number of requests using
+	// it (both waiting and running).
+	hostSlots struct {
+		sem   chan struct{}
+		users int
+	}
+)
+
+func newResponseCache(ttl time.Duration) *responseCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &responseCache{
+		ttl:     ttl,
+		entries: make(map[string]*cacheEntry),
+	}
+}
+
+// cacheKey returns the cache key for the request URL and filter.
+func cacheKey(url string, filter *string) string {
+	if filter == nil {
+		return url
+	}
+	// Filter can't be confused with URL part since URL can't contain
+	// zero bytes.
+	return url + "\x00" + *filter
+}
+
+// get returns the cached response for the key waiting for it if it's being
+// processed. If there is no response, ok is false and the caller must process
+// the request and call put with the result. The cache may be nil, then
+// nothing is cached.
+func (c *responseCache) get(key string) ([]byte, transaction.OracleResponseCode, bool) {
+	if c == nil {
+		return nil, 0, false
+	}
+	for {
+		c.lock.Lock()
+		e, ok := c.entries[key]
+		if !ok {
+			c.entries[key] = &cacheEntry{done: make(chan struct{})}
+			c.lock.Unlock()
+			return nil, 0, false
+		}
+		c.lock.Unlock()
+
+		<-e.done
+		// Other codes can change on retry (or with another attempt's
+		// node), so waiters process failed requests themselves.
+		if e.code == transaction.Success && time.Now().Before(e.expires) {
+			return e.result, e.code, true
+		}
+		c.lock.Lock()
+		if c.entries[key] == e {
+			delete(c.entries, key)
+		}
+		c.lock.Unlock()
+	}
+}
+
+// put stores the response for the key previously missed by get. Only
+// successful responses are stored.
+func (c *responseCache) put(key string, result []byte, code transaction.OracleResponseCode) {
+	if c == nil {
+		return
+	}
+	now := time.Now()
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	e := c.entries[key]
+	e.result, e.code = result, code
+	if code != transaction.Success {
+		delete(c.entries, key)
+	} else {
+		e.expires = now.Add(c.ttl)
+		if len(c.entries) > maxCacheEntries {
+			c.purge(now)
+		}
+		if len(c.entries) > maxCacheEntries {
+			delete(c.entries, key)
+		}
+	}
+	close(e.done)
+}
+
+// purge removes expired entries, it must be called with the lock held.
+func (c *responseCache) purge(now time.Time) {
+	for k, e := range c.entries {
+		if !e.expires.IsZero() && !now.Before(e.expires) {
+			delete(c.entries, k)
+		}
+	}
+}
+
+func newHostLimiter(limit int) *hostLimiter {
+	if limit <= 0 {
+		return nil
+	}
+	return &hostLimiter{
+		limit: limit,
+		hosts: make(map[string]*hostSlots),
+	}
+}
+
+// acquire waits for the free slot for the host, it returns false if the
+// context is done before. release must be called for every successful acquire.
+// The limiter may be nil, then there is no limit.
+func (l *hostLimiter) acquire(ctx context.Context, host string) bool {
+	if l == nil {
+		return true
+	}
+	host = strings.ToLower(host)
+	l.lock.Lock()
+	s, ok := l.hosts[host]
+	if !ok {
+		s = &hostSlots{sem: make(chan struct{}, l.limit)}
+		l.hosts[host] = s
+	}
+	s.users++
+	l.lock.Unlock()
+
+	select {
+	case s.sem <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		l.leave(host, s)
+		return false
+	}
+}
+
+// release frees the slot taken by acquire.
+func (l *hostLimiter) release(host string) {
+	if l == nil {
+		return
+	}
+	host = strings.ToLower(host)
+	l.lock.Lock()
+	s := l.hosts[host]
+	l.lock.Unlock()
+	<-s.sem
+	l.leave(host, s)
+}
+
+// leave removes the host semaphore when it's not used anymore.
+func (l *hostLimiter) leave(host string, s *hostSlots) {
+	l.lock.Lock()
+	s.users--
+	if s.users == 0 {
+		delete(l.hosts, host)
+	}
+	l.lock.Unlock()
+}
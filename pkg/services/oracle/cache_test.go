@@ -0,0 +1,184 @@
+package oracle
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseCache(t *testing.T) {
+	require.Nil(t, newResponseCache(0))
+
+	t.Run("disabled", func(t *testing.T) {
+		var c *responseCache
+		_, _, ok := c.get("key")
+		require.False(t, ok)
+		c.put("key", []byte{1}, transaction.Success)
+		_, _, ok = c.get("key")
+		require.False(t, ok)
+	})
+
+	t.Run("key", func(t *testing.T) {
+		f := "$.a"
+		require.Equal(t, "https://example.com", cacheKey("https://example.com", nil))
+		require.NotEqual(t, cacheKey("https://example.com", nil), cacheKey("https://example.com", &f))
+	})
+
+	t.Run("hit", func(t *testing.T) {
+		c := newResponseCache(time.Minute)
+		_, _, ok := c.get("key")
+		require.False(t, ok)
+		c.put("key", []byte{1, 2, 3}, transaction.Success)
+
+		res, code, ok := c.get("key")
+		require.True(t, ok)
+		require.Equal(t, transaction.Success, code)
+		require.Equal(t, []byte{1, 2, 3}, res)
+
+		_, _, ok = c.get("other")
+		require.False(t, ok)
+	})
+
+	t.Run("failures are not cached", func(t *testing.T) {
+		c := newResponseCache(time.Minute)
+		for _, code := range []transaction.OracleResponseCode{
+			transaction.ProtocolNotSupported, transaction.ConsensusUnreachable,
+			transaction.NotFound, transaction.Timeout, transaction.Forbidden,
+			transaction.ResponseTooLarge, transaction.InsufficientFunds,
+			transaction.ContentTypeNotSupported, transaction.Error,
+		} {
+			_, _, ok := c.get("key")
+			require.False(t, ok, code)
+			c.put("key", nil, code)
+		}
+		_, _, ok := c.get("key")
+		require.False(t, ok)
+	})
+
+	t.Run("failures are not shared", func(t *testing.T) {
+		c := newResponseCache(time.Minute)
+		_, _, ok := c.get("key")
+		require.False(t, ok)
+
+		done := make(chan bool)
+		go func() {
+			_, _, ok := c.get("key")
+			done <- ok
+		}()
+		time.Sleep(10 * time.Millisecond)
+		c.put("key", nil, transaction.NotFound)
+		// The waiter has to process the request itself.
+		require.False(t, <-done)
+		c.put("key", []byte{1}, transaction.Success)
+		res, _, ok := c.get("key")
+		require.True(t, ok)
+		require.Equal(t, []byte{1}, res)
+	})
+
+	t.Run("expiration", func(t *testing.T) {
+		c := newResponseCache(time.Millisecond)
+		_, _, ok := c.get("key")
+		require.False(t, ok)
+		c.put("key", []byte{1}, transaction.Success)
+		time.Sleep(2 * time.Millisecond)
+		_, _, ok = c.get("key")
+		require.False(t, ok)
+	})
+
+	t.Run("size limit", func(t *testing.T) {
+		c := newResponseCache(time.Minute)
+		for i := 0; i <= maxCacheEntries; i++ {
+			key := string(rune('a' + i))
+			_, _, ok := c.get(key)
+			require.False(t, ok)
+			c.put(key, nil, transaction.Success)
+		}
+		require.Equal(t, maxCacheEntries, len(c.entries))
+	})
+
+	t.Run("deduplication", func(t *testing.T) {
+		c := newResponseCache(time.Minute)
+		_, _, ok := c.get("key")
+		require.False(t, ok)
+
+		var (
+			wg   sync.WaitGroup
+			hits atomic.Int32
+		)
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				res, _, ok := c.get("key")
+				if ok && string(res) == "data" {
+					hits.Add(1)
+				}
+			}()
+		}
+		time.Sleep(10 * time.Millisecond)
+		require.Zero(t, hits.Load())
+		c.put("key", []byte("data"), transaction.Success)
+		wg.Wait()
+		require.EqualValues(t, 5, hits.Load())
+	})
+}
+
+func TestHostLimiter(t *testing.T) {
+	require.Nil(t, newHostLimiter(0))
+	var nl *hostLimiter
+	require.True(t, nl.acquire(context.Background(), "example.com"))
+	nl.release("example.com")
+
+	l := newHostLimiter(2)
+	ctx := context.Background()
+	require.True(t, l.acquire(ctx, "example.com"))
+	require.True(t, l.acquire(ctx, "EXAMPLE.com"))
+	require.True(t, l.acquire(ctx, "other.com"))
+
+	tctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	require.False(t, l.acquire(tctx, "example.com"))
+
+	l.release("example.com")
+	require.True(t, l.acquire(ctx, "example.com"))
+	l.release("example.com")
+	l.release("example.com")
+	l.release("other.com")
+	require.Empty(t, l.hosts)
+}
+
+func TestOracle_getResultCache(t *testing.T) {
+	var calls atomic.Int32
+	o := newFetcherTestOracle(t, config.OracleConfiguration{})
+	o.cache = newResponseCache(time.Minute)
+	o.limiter = newHostLimiter(1)
+	o.RegisterFetcher("test", testFetcher(func(ctx context.Context, req FetchRequest) (FetchResponse, error) {
+		calls.Add(1)
+		return FetchResponse{
+			Code: transaction.Success,
+			Body: io.NopCloser(strings.NewReader(`{"a":1,"b":2}`)),
+		}, nil
+	}), config.OracleFetcherConfiguration{})
+
+	filterA, filterB := "$.a", "$.b"
+	for i := 0; i < 3; i++ {
+		res, code := o.getResult(nil, request{ID: uint64(i), Req: &state.OracleRequest{URL: "test:data", Filter: &filterA}}, 0)
+		require.Equal(t, transaction.Success, code)
+		require.Equal(t, "[1]", string(res))
+	}
+	require.EqualValues(t, 1, calls.Load())
+
+	res, code := o.getResult(nil, request{ID: 3, Req: &state.OracleRequest{URL: "test:data", Filter: &filterB}}, 0)
+	require.Equal(t, transaction.Success, code)
+	require.Equal(t, "[2]", string(res))
+	require.EqualValues(t, 2, calls.Load())
+}
@@ -0,0 +1,285 @@
+package oracle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/services/oracle/neofs"
+	"go.uber.org/zap"
+)
+
+type (
+	// Fetcher retrieves data for oracle requests with a particular URI
+	// scheme.
+	Fetcher interface {
+		// Fetch performs the request. Successful response has
+		// transaction.Success code and a body that is closed by the caller,
+		// other codes must come without a body. If an error is returned,
+		// it's logged and the response code is used as is (unless it's
+		// transaction.Success, then transaction.Error is used).
+		Fetch(ctx context.Context, req FetchRequest) (FetchResponse, error)
+	}
+
+	// FetchRequest is an oracle request passed to Fetcher.
+	FetchRequest struct {
+		// ID is the oracle request ID.
+		ID uint64
+		// Attempt is the number of previous attempts to process this request.
+		Attempt int
+		URL     *url.URL
+		// Key is the oracle node key, it can be used to sign requests.
+		Key *keys.PrivateKey
+	}
+
+	// FetchResponse is a response returned from Fetcher.
+	FetchResponse struct {
+		Code transaction.OracleResponseCode
+		// ContentType is the MIME type of the body.
+		ContentType string
+		Body        io.ReadCloser
+	}
+
+	// fetcherEntry is a registered Fetcher with its limits.
+	fetcherEntry struct {
+		Fetcher
+		timeout time.Duration
+		maxSize int
+		// contentTypes is nil if the content type is not checked.
+		contentTypes []string
+	}
+
+	// httpFetcher performs https:// requests with the default oracle client.
+	httpFetcher struct {
+		client HTTPClient
+	}
+
+	// allowlistFetcher performs http:// requests to allowed hosts only.
+	allowlistFetcher struct {
+		client HTTPClient
+		hosts  map[string]bool
+	}
+
+	// ipfsFetcher gets ipfs:// data via HTTP gateway.
+	ipfsFetcher struct {
+		client  HTTPClient
+		gateway *url.URL
+	}
+
+	// neofsFetcher gets neofs: objects from NeoFS nodes.
+	neofsFetcher struct {
+		nodes []string
+	}
+)
+
+// ErrForbiddenHost is returned for requests to hosts that are not allowed.
+var ErrForbiddenHost = errors.New("host is not allowed")
+
+// RegisterFetcher registers the Fetcher for the given URI scheme replacing
+// the existing one if any. cfg limits apply to all requests with this scheme
+// (see config.OracleFetcherConfiguration for defaults). It's not safe for
+// concurrent use and must be called before Start.
+func (o *Oracle) RegisterFetcher(scheme string, f Fetcher, cfg config.OracleFetcherConfiguration) {
+	e := &fetcherEntry{
+		Fetcher:      f,
+		timeout:      cfg.Timeout,
+		maxSize:      cfg.MaxResponseSize,
+		contentTypes: cfg.AllowedContentTypes,
+	}
+	if e.timeout <= 0 {
+		e.timeout = o.MainCfg.RequestTimeout
+	}
+	if e.maxSize <= 0 || e.maxSize > transaction.MaxOracleResultSize {
+		e.maxSize = transaction.MaxOracleResultSize
+	}
+	if e.contentTypes == nil {
+		e.contentTypes = o.MainCfg.AllowedContentTypes
+	}
+	o.fetchers[scheme] = e
+}
+
+// registerDefaultFetchers registers fetchers for all schemes enabled in the
+// configuration.
+func (o *Oracle) registerDefaultFetchers() error {
+	o.RegisterFetcher("https", httpFetcher{client: o.Client}, config.OracleFetcherConfiguration{})
+	o.RegisterFetcher(neofs.URIScheme, neofsFetcher{nodes: o.MainCfg.NeoFS.Nodes}, config.OracleFetcherConfiguration{
+		Timeout: o.MainCfg.NeoFS.Timeout,
+	})
+	// NeoFS objects have no content type.
+	o.fetchers[neofs.URIScheme].contentTypes = nil
+
+	if cfg := o.MainCfg.IPFS; cfg.Gateway != "" {
+		gw, err := url.Parse(cfg.Gateway)
+		if err != nil {
+			return fmt.Errorf("invalid IPFS gateway: %w", err)
+		}
+		o.RegisterFetcher("ipfs", ipfsFetcher{client: newPlainClient(), gateway: gw}, cfg.OracleFetcherConfiguration)
+	}
+	if cfg := o.MainCfg.HTTP; len(cfg.AllowedHosts) != 0 {
+		o.RegisterFetcher("http", newAllowlistFetcher(cfg.AllowedHosts), cfg.OracleFetcherConfiguration)
+	}
+	if cfg := o.MainCfg.NeoRPC; len(cfg.Endpoints) != 0 {
+		f, err := newNeoRPCFetcher(cfg)
+		if err != nil {
+			return err
+		}
+		o.RegisterFetcher(NeoRPCScheme, f, cfg.OracleFetcherConfiguration)
+	}
+	return nil
+}
+
+// fetch gets the response data for the request using the registered fetcher.
+func (o *Oracle) fetch(priv *keys.PrivateKey, id uint64, attempt int, u *url.URL, rawURL string) ([]byte, transaction.OracleResponseCode) {
+	f, ok := o.fetchers[u.Scheme]
+	if !ok {
+		o.Log.Warn("unknown oracle request scheme", zap.String("url", rawURL))
+		return nil, transaction.ProtocolNotSupported
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), f.timeout)
+	defer cancel()
+	if !o.limiter.acquire(ctx, u.Hostname()) {
+		o.Log.Warn("oracle request timed out waiting for host", zap.String("url", rawURL))
+		return nil, transaction.Timeout
+	}
+	defer o.limiter.release(u.Hostname())
+	start := time.Now()
+	defer func() { addFetchTimeMetric(u.Scheme, time.Since(start)) }()
+	r, err := f.Fetch(ctx, FetchRequest{ID: id, Attempt: attempt, URL: u, Key: priv})
+	if err != nil {
+		if r.Code == transaction.Success {
+			r.Code = transaction.Error
+		}
+		o.Log.Warn("oracle request failed", zap.String("url", rawURL), zap.Error(err), zap.Stringer("code", r.Code))
+		return nil, r.Code
+	}
+	if r.Code != transaction.Success {
+		return nil, r.Code
+	}
+	defer r.Body.Close() // intentionally skip the closing error.
+	if f.contentTypes != nil && !checkMediaType(r.ContentType, f.contentTypes) {
+		return nil, transaction.ContentTypeNotSupported
+	}
+	return o.readResponse(r.Body, rawURL, f.maxSize)
+}
+
+// Fetch implements the Fetcher interface.
+func (f httpFetcher) Fetch(ctx context.Context, req FetchRequest) (FetchResponse, error) {
+	return doHTTPRequest(ctx, f.client, req.URL.String())
+}
+
+func newAllowlistFetcher(hosts []string) allowlistFetcher {
+	f := allowlistFetcher{hosts: make(map[string]bool, len(hosts))}
+	for _, h := range hosts {
+		f.hosts[strings.ToLower(h)] = true
+	}
+	client := newPlainClient()
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) > maxRedirections {
+			return fmt.Errorf("%w: %d redirections are reached", ErrRestrictedRedirect, maxRedirections)
+		}
+		if !f.allowed(req.URL) {
+			return fmt.Errorf("%w: redirected to %s", ErrRestrictedRedirect, req.URL.Host)
+		}
+		return nil
+	}
+	f.client = client
+	return f
+}
+
+// allowed checks whether the URL host is in the allowlist.
+func (f allowlistFetcher) allowed(u *url.URL) bool {
+	return f.hosts[strings.ToLower(u.Hostname())]
+}
+
+// Fetch implements the Fetcher interface.
+func (f allowlistFetcher) Fetch(ctx context.Context, req FetchRequest) (FetchResponse, error) {
+	if !f.allowed(req.URL) {
+		return FetchResponse{Code: transaction.Forbidden}, fmt.Errorf("%w: %s", ErrForbiddenHost, req.URL.Hostname())
+	}
+	return doHTTPRequest(ctx, f.client, req.URL.String())
+}
+
+// Fetch implements the Fetcher interface. ipfs://<CID>/<path> URIs are
+// requested from <gateway>/ipfs/<CID>/<path>.
+func (f ipfsFetcher) Fetch(ctx context.Context, req FetchRequest) (FetchResponse, error) {
+	if req.URL.Host == "" {
+		return FetchResponse{}, errors.New("no CID specified")
+	}
+	u := f.gateway.JoinPath("ipfs", req.URL.Host, req.URL.Path)
+	u.RawQuery = req.URL.RawQuery
+	return doHTTPRequest(ctx, f.client, u.String())
+}
+
+// Fetch implements the Fetcher interface. Nodes are used in round-robin
+// fashion for subsequent attempts.
+func (f neofsFetcher) Fetch(ctx context.Context, req FetchRequest) (FetchResponse, error) {
+	if len(f.nodes) == 0 {
+		return FetchResponse{}, errors.New("no NeoFS nodes configured")
+	}
+	index := (int(req.ID) + req.Attempt) % len(f.nodes)
+	rc, err := neofs.Get(ctx, req.Key, req.URL, f.nodes[index])
+	if err != nil {
+		if rc != nil {
+			rc.Close() // intentionally skip the closing error, make it unified with Oracle `https` protocol.
+		}
+		return FetchResponse{}, err
+	}
+	return FetchResponse{Code: transaction.Success, Body: rc}, nil
+}
+
+// doHTTPRequest performs GET request and converts HTTP status into oracle
+// response code.
+func doHTTPRequest(ctx context.Context, client HTTPClient, u string) (FetchResponse, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return FetchResponse{}, fmt.Errorf("failed to create http request: %w", err)
+	}
+	httpReq.Header.Set("User-Agent", "NeoOracleService/3.0")
+	httpReq.Header.Set("Content-Type", "application/json")
+	r, err := client.Do(httpReq)
+	if err != nil {
+		if errors.Is(err, ErrRestrictedRedirect) {
+			return FetchResponse{Code: transaction.Forbidden}, err
+		}
+		return FetchResponse{}, err
+	}
+	var code transaction.OracleResponseCode
+	switch r.StatusCode {
+	case http.StatusOK:
+		return FetchResponse{
+			Code:        transaction.Success,
+			ContentType: r.Header.Get("Content-Type"),
+			Body:        r.Body,
+		}, nil
+	case http.StatusForbidden:
+		code = transaction.Forbidden
+	case http.StatusNotFound:
+		code = transaction.NotFound
+	case http.StatusRequestTimeout:
+		code = transaction.Timeout
+	default:
+		code = transaction.Error
+	}
+	r.Body.Close()
+	return FetchResponse{Code: code}, nil
+}
+
+// newPlainClient returns a client for operator-configured services, private
+// hosts are allowed for it.
+func newPlainClient() *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			DisableKeepAlives: true,
+			DialContext:       (&net.Dialer{}).DialContext,
+		},
+	}
+}
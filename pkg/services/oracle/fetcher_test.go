@@ -0,0 +1,168 @@
+package oracle
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func newFetcherTestOracle(t *testing.T, cfg config.OracleConfiguration) *Oracle {
+	cfg.RequestTimeout = time.Second
+	o := &Oracle{
+		Config: Config{
+			Log:     zaptest.NewLogger(t),
+			MainCfg: cfg,
+			Client:  newPlainClient(),
+		},
+		fetchers: make(map[string]*fetcherEntry),
+	}
+	require.NoError(t, o.registerDefaultFetchers())
+	return o
+}
+
+func fetchTest(o *Oracle, rawURL string) ([]byte, transaction.OracleResponseCode) {
+	u, err := url.ParseRequestURI(rawURL)
+	if err != nil {
+		panic(err)
+	}
+	return o.fetch(nil, 1, 0, u, rawURL)
+}
+
+func TestFetchers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ipfs/bafycid/data.json", "/data.json":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"value":42}`))
+		case "/text":
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte(`text`))
+		case "/large":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(strings.Repeat("1", 100)))
+		case "/redirect":
+			http.Redirect(w, r, "http://example.com/data.json", http.StatusFound)
+		case "/rpc":
+			var req neorpc.Request
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			resp := neorpc.Response{HeaderAndError: neorpc.HeaderAndError{Header: neorpc.Header{JSONRPC: neorpc.JSONRPCVersion, ID: []byte("1")}}}
+			switch req.Method {
+			case "getblockcount":
+				resp.Result = []byte(`123`)
+			case "getstorage":
+				require.Equal(t, []any{"0x0102", "a2V5"}, req.Params)
+				resp.Error = neorpc.ErrUnknownStorageItem
+			default:
+				resp.Error = neorpc.NewMethodNotFoundError(req.Method)
+			}
+			require.NoError(t, json.NewEncoder(w).Encode(resp))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	srvURL, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	t.Run("disabled", func(t *testing.T) {
+		o := newFetcherTestOracle(t, config.OracleConfiguration{})
+		for _, s := range []string{"ipfs://bafycid/data.json", srv.URL + "/data.json", "neo-rpc://mainnet/getblockcount", "ftp://some/file"} {
+			_, code := fetchTest(o, s)
+			require.Equal(t, transaction.ProtocolNotSupported, code, s)
+		}
+	})
+
+	t.Run("ipfs", func(t *testing.T) {
+		o := newFetcherTestOracle(t, config.OracleConfiguration{
+			IPFS: config.IPFSConfiguration{Gateway: srv.URL},
+		})
+		res, code := fetchTest(o, "ipfs://bafycid/data.json")
+		require.Equal(t, transaction.Success, code)
+		require.Equal(t, `{"value":42}`, string(res))
+
+		_, code = fetchTest(o, "ipfs://othercid/data.json")
+		require.Equal(t, transaction.NotFound, code)
+	})
+
+	t.Run("http", func(t *testing.T) {
+		o := newFetcherTestOracle(t, config.OracleConfiguration{
+			AllowedContentTypes: []string{"application/json"},
+			HTTP: config.OracleHTTPConfiguration{
+				OracleFetcherConfiguration: config.OracleFetcherConfiguration{MaxResponseSize: 50},
+				AllowedHosts:               []string{srvURL.Hostname()},
+			},
+		})
+		res, code := fetchTest(o, srv.URL+"/data.json")
+		require.Equal(t, transaction.Success, code)
+		require.Equal(t, `{"value":42}`, string(res))
+
+		_, code = fetchTest(o, srv.URL+"/text")
+		require.Equal(t, transaction.ContentTypeNotSupported, code)
+		_, code = fetchTest(o, srv.URL+"/large")
+		require.Equal(t, transaction.ResponseTooLarge, code)
+		_, code = fetchTest(o, srv.URL+"/redirect")
+		require.Equal(t, transaction.Forbidden, code)
+		_, code = fetchTest(o, "http://example.com/data.json")
+		require.Equal(t, transaction.Forbidden, code)
+	})
+
+	t.Run("neo-rpc", func(t *testing.T) {
+		o := newFetcherTestOracle(t, config.OracleConfiguration{
+			NeoRPC: config.OracleNeoRPCConfiguration{
+				Endpoints: map[string]string{"MainNet": srv.URL + "/rpc"},
+				Methods:   []string{"getblockcount", "getstorage", "getversion"},
+			},
+		})
+		res, code := fetchTest(o, "neo-rpc://mainnet/getblockcount")
+		require.Equal(t, transaction.Success, code)
+		require.Equal(t, "123", string(res))
+
+		_, code = fetchTest(o, "neo-rpc://mainnet/getstorage?params="+url.QueryEscape(`["0x0102","a2V5"]`))
+		require.Equal(t, transaction.NotFound, code)
+		_, code = fetchTest(o, "neo-rpc://mainnet/getversion")
+		require.Equal(t, transaction.Error, code)
+		_, code = fetchTest(o, "neo-rpc://mainnet/submitblock")
+		require.Equal(t, transaction.Forbidden, code)
+		_, code = fetchTest(o, "neo-rpc://testnet/getblockcount")
+		require.Equal(t, transaction.NotFound, code)
+		_, code = fetchTest(o, "neo-rpc://mainnet/getblockcount?params=[")
+		require.Equal(t, transaction.Error, code)
+
+		_, err := newNeoRPCFetcher(config.OracleNeoRPCConfiguration{Endpoints: map[string]string{"mainnet": ""}})
+		require.Error(t, err)
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		o := newFetcherTestOracle(t, config.OracleConfiguration{})
+		o.RegisterFetcher("test", testFetcher(func(ctx context.Context, req FetchRequest) (FetchResponse, error) {
+			_, ok := ctx.Deadline()
+			require.True(t, ok)
+			require.Equal(t, uint64(1), req.ID)
+			return FetchResponse{
+				Code: transaction.Success,
+				Body: io.NopCloser(strings.NewReader(req.URL.Opaque)),
+			}, nil
+		}), config.OracleFetcherConfiguration{})
+		res, code := fetchTest(o, "test:data")
+		require.Equal(t, transaction.Success, code)
+		require.Equal(t, "data", string(res))
+	})
+}
+
+type testFetcher func(ctx context.Context, req FetchRequest) (FetchResponse, error)
+
+func (f testFetcher) Fetch(ctx context.Context, req FetchRequest) (FetchResponse, error) {
+	return f(ctx, req)
+}
@@ -2,19 +2,61 @@ package oracle
 
 import (
 	"bytes"
+	"encoding/csv"
 	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
 	"unicode/utf8"
 
 	json "github.com/nspcc-dev/go-ordered-json"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/services/oracle/jsonpath"
+	"github.com/nspcc-dev/neo-go/pkg/services/oracle/xpath"
 )
 
+// Filter prefixes for non-JSON data formats. Filters without a prefix are
+// JSONPath expressions.
+const (
+	xpathFilterPrefix = "xpath:"
+	csvFilterPrefix   = "csv:"
+	regexFilterPrefix = "regex:"
+)
+
+// maxFilterResults is the maximum number of values returned by CSV and regex
+// filters.
+const maxFilterResults = 1024
+
+var errTooManyResults = errors.New("too many results")
+
 func filter(value []byte, path string) ([]byte, error) {
 	if !utf8.Valid(value) {
 		return nil, errors.New("not an UTF-8")
 	}
 
+	var (
+		result any
+		err    error
+	)
+	switch {
+	case strings.HasPrefix(path, xpathFilterPrefix):
+		result, err = xpath.Get(path[len(xpathFilterPrefix):], value)
+	case strings.HasPrefix(path, csvFilterPrefix):
+		result, err = filterCSV(value, path[len(csvFilterPrefix):])
+	case strings.HasPrefix(path, regexFilterPrefix):
+		result, err = filterRegex(value, path[len(regexFilterPrefix):])
+	default:
+		result, err = filterJSON(value, path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(result)
+}
+
+func filterJSON(value []byte, path string) ([]any, error) {
 	buf := bytes.NewBuffer(value)
 	d := json.NewDecoder(buf)
 	d.UseOrderedObject()
@@ -28,7 +70,75 @@ func filter(value []byte, path string) ([]byte, error) {
 	if !ok {
 		return nil, errors.New("invalid filter")
 	}
-	return json.Marshal(result)
+	return result, nil
+}
+
+// filterCSV returns values of the column specified either by its 0-based
+// index or by its name (the first record is a header then). Records that
+// don't have this column are skipped.
+func filterCSV(value []byte, column string) ([]string, error) {
+	r := csv.NewReader(bytes.NewReader(value))
+	r.FieldsPerRecord = -1
+	r.ReuseRecord = true
+
+	index, err := strconv.Atoi(column)
+	if err != nil {
+		header, err := r.Read()
+		if err != nil {
+			return nil, fmt.Errorf("can't read CSV header: %w", err)
+		}
+		index = -1
+		for i := range header {
+			if header[i] == column {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return nil, fmt.Errorf("no %q column in CSV header", column)
+		}
+	} else if index < 0 {
+		return nil, fmt.Errorf("invalid CSV column index %d", index)
+	}
+
+	res := []string{}
+	for {
+		rec, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return res, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if index < len(rec) {
+			if len(res) == maxFilterResults {
+				return nil, errTooManyResults
+			}
+			res = append(res, rec[index])
+		}
+	}
+}
+
+// filterRegex returns all matches of the regular expression, if it has
+// capturing groups the first one is returned for every match.
+func filterRegex(value []byte, expr string) ([]string, error) {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	matches := re.FindAllSubmatch(value, maxFilterResults+1)
+	if len(matches) > maxFilterResults {
+		return nil, errTooManyResults
+	}
+	group := 0
+	if re.NumSubexp() > 0 {
+		group = 1
+	}
+	res := make([]string, len(matches))
+	for i := range matches {
+		res[i] = string(matches[i][group])
+	}
+	return res, nil
 }
 
 func filterRequest(result []byte, req *state.OracleRequest) ([]byte, error) {
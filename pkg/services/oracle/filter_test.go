@@ -49,6 +49,60 @@ func TestFilter(t *testing.T) {
 		_, err := filter([]byte{0xFF}, "Manufacturers[0].Name")
 		require.Error(t, err)
 	})
+
+	t.Run("filter expression", func(t *testing.T) {
+		actual, err := filter([]byte(js), "$.Manufacturers[*].Products[?(@.Price > 10)].Name")
+		require.NoError(t, err)
+		require.Equal(t, `["Anvil","Elbow Grease"]`, string(actual))
+	})
+}
+
+func TestFilterFormats(t *testing.T) {
+	const (
+		xml = `<stores><store id="1"><name>Lambton Quay</name></store><store id="2"><name>Willis Street</name></store></stores>`
+		csv = "name,price\nAnvil,50\n\"Elbow, Grease\",99.95\nbroken\n"
+	)
+	testCases := []struct {
+		data, path, result string
+	}{
+		{xml, "xpath://store/name", `["Lambton Quay","Willis Street"]`},
+		{xml, "xpath:/stores/store[@id='2']/name/text()", `["Willis Street"]`},
+		{xml, "xpath:/stores/store/@id", `["1","2"]`},
+		{xml, "xpath:/stores/item", `[]`},
+		{csv, "csv:price", `["50","99.95"]`},
+		{csv, "csv:0", `["name","Anvil","Elbow, Grease","broken"]`},
+		{csv, "csv:1", `["price","50","99.95"]`},
+		{csv, `regex:\d+\.\d+`, `["99.95"]`},
+		{csv, `regex:(\w+),\d`, `["Anvil"]`},
+		{csv, `regex:^none$`, `[]`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			actual, err := filter([]byte(tc.data), tc.path)
+			require.NoError(t, err)
+			require.Equal(t, tc.result, string(actual))
+		})
+	}
+
+	errCases := []struct {
+		data, path string
+	}{
+		{xml, "xpath:stores"},
+		{xml, "xpath:/stores/@id/name"},
+		{"<a>", "xpath:/a"},
+		{csv, "csv:unknown"},
+		{csv, "csv:-1"},
+		{"", "csv:name"},
+		{csv, "regex:("},
+		{strings.Repeat("a", maxFilterResults+1), "regex:a"},
+		{strings.Repeat("a\n", maxFilterResults+1), "csv:0"},
+	}
+	for _, tc := range errCases {
+		t.Run(tc.path, func(t *testing.T) {
+			_, err := filter([]byte(tc.data), tc.path)
+			require.Error(t, err)
+		})
+	}
 }
 
 func TestFilterOOM(t *testing.T) {
@@ -0,0 +1,348 @@
+package jsonpath
+
+import (
+	"strconv"
+	"strings"
+
+	json "github.com/nspcc-dev/go-ordered-json"
+)
+
+type (
+	// filterExpr is a boolean filter expression, it's a disjunction of
+	// conjunctions of comparisons.
+	filterExpr [][]filterCmp
+
+	// filterCmp is a single comparison, op is empty for the existence check
+	// of the left operand.
+	filterCmp struct {
+		left  filterOperand
+		op    string
+		right filterOperand
+	}
+
+	// filterOperand is either a relative (`@`-based) path or a literal.
+	filterOperand struct {
+		isPath  bool
+		path    []filterStep
+		literal any
+	}
+
+	// filterStep is a single relative path step: object field or array
+	// index access.
+	filterStep struct {
+		key     string
+		index   int
+		isIndex bool
+	}
+)
+
+// processFilter handles `[?(expr)]` filter expressions. It selects array
+// elements (or object values) the expression is true for.
+func (p *pathParser) processFilter(objs []any) ([]any, bool) {
+	if !p.consume("(") {
+		return nil, false
+	}
+	expr, ok := p.parseFilterExpr()
+	if !ok || !p.consume(")") || !p.consume("]") {
+		return nil, false
+	}
+
+	candidates, ok := p.descend(objs)
+	if !ok {
+		return nil, false
+	}
+	var values []any
+	for _, v := range candidates {
+		if expr.match(v) {
+			values = append(values, v)
+		}
+	}
+	return values, true
+}
+
+// skipSpaces skips whitespace in filter expressions.
+func (p *pathParser) skipSpaces() {
+	for p.i < len(p.s) && p.s[p.i] == ' ' {
+		p.i++
+	}
+}
+
+// consume skips whitespace and the given string if it's next.
+func (p *pathParser) consume(s string) bool {
+	p.skipSpaces()
+	if strings.HasPrefix(p.s[p.i:], s) {
+		p.i += len(s)
+		return true
+	}
+	return false
+}
+
+func (p *pathParser) parseFilterExpr() (filterExpr, bool) {
+	var expr filterExpr
+	for {
+		var conj []filterCmp
+		for {
+			cmp, ok := p.parseFilterCmp()
+			if !ok {
+				return nil, false
+			}
+			conj = append(conj, cmp)
+			if !p.consume("&&") {
+				break
+			}
+		}
+		expr = append(expr, conj)
+		if !p.consume("||") {
+			return expr, true
+		}
+	}
+}
+
+func (p *pathParser) parseFilterCmp() (filterCmp, bool) {
+	var (
+		cmp filterCmp
+		ok  bool
+	)
+	cmp.left, ok = p.parseFilterOperand()
+	if !ok {
+		return cmp, false
+	}
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.consume(op) {
+			cmp.op = op
+			cmp.right, ok = p.parseFilterOperand()
+			return cmp, ok
+		}
+	}
+	// Existence check makes sense for paths only.
+	return cmp, cmp.left.isPath
+}
+
+func (p *pathParser) parseFilterOperand() (filterOperand, bool) {
+	var op filterOperand
+	p.skipSpaces()
+	if p.i >= len(p.s) {
+		return op, false
+	}
+	switch c := p.s[p.i]; {
+	case c == '@':
+		p.i++
+		op.isPath = true
+		for {
+			step, ok, done := p.parseFilterStep()
+			if !ok {
+				return op, false
+			}
+			if done {
+				return op, true
+			}
+			op.path = append(op.path, step)
+		}
+	case c == '\'':
+		s, n, ok := p.parseString()
+		if !ok {
+			return op, false
+		}
+		p.i += n
+		s = strings.Trim(s, "'")
+		if err := json.Unmarshal([]byte(`"`+s+`"`), &s); err != nil {
+			return op, false
+		}
+		op.literal = s
+	case c == '-' || ('0' <= c && c <= '9'):
+		end := p.i + 1
+		for end < len(p.s) && strings.IndexByte("0123456789.eE+-", p.s[end]) >= 0 {
+			end++
+		}
+		f, err := strconv.ParseFloat(p.s[p.i:end], 64)
+		if err != nil {
+			return op, false
+		}
+		p.i = end
+		op.literal = f
+	default:
+		s, n, _ := p.parseIdent()
+		switch s {
+		case "true":
+			op.literal = true
+		case "false":
+			op.literal = false
+		case "null":
+			op.literal = nil
+		default:
+			return op, false
+		}
+		p.i += n
+	}
+	return op, true
+}
+
+// parseFilterStep parses the next relative path step, done is true if
+// there are no more steps.
+func (p *pathParser) parseFilterStep() (filterStep, bool, bool) {
+	var step filterStep
+	if p.i >= len(p.s) {
+		return step, true, true
+	}
+	switch p.s[p.i] {
+	case '.':
+		p.i++
+		typ, value := p.nextToken()
+		if typ != pathIdentifier {
+			return step, false, false
+		}
+		step.key = value
+	case '[':
+		p.i++
+		typ, value := p.nextToken()
+		switch typ {
+		case pathString:
+			s := strings.Trim(value, "'")
+			if err := json.Unmarshal([]byte(`"`+s+`"`), &s); err != nil {
+				return step, false, false
+			}
+			step.key = s
+		case pathNumber:
+			index, err := strconv.ParseInt(value, 10, 32)
+			if err != nil {
+				return step, false, false
+			}
+			step.index = int(index)
+			step.isIndex = true
+		default:
+			return step, false, false
+		}
+		if typ, _ := p.nextToken(); typ != pathRightBracket {
+			return step, false, false
+		}
+	default:
+		return step, true, true
+	}
+	return step, true, false
+}
+
+func (e filterExpr) match(v any) bool {
+	for _, conj := range e {
+		ok := true
+		for _, cmp := range conj {
+			if !cmp.match(v) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (c filterCmp) match(v any) bool {
+	left, ok := c.left.value(v)
+	if !ok {
+		return false
+	}
+	if c.op == "" {
+		return true
+	}
+	right, ok := c.right.value(v)
+	if !ok {
+		return false
+	}
+	switch c.op {
+	case "==":
+		return equal(left, right)
+	case "!=":
+		return !equal(left, right)
+	}
+	var res int
+	switch l := left.(type) {
+	case float64:
+		r, ok := right.(float64)
+		if !ok {
+			return false
+		}
+		switch {
+		case l < r:
+			res = -1
+		case l > r:
+			res = 1
+		}
+	case string:
+		r, ok := right.(string)
+		if !ok {
+			return false
+		}
+		res = strings.Compare(l, r)
+	default:
+		return false
+	}
+	switch c.op {
+	case "<":
+		return res < 0
+	case "<=":
+		return res <= 0
+	case ">":
+		return res > 0
+	default: // ">="
+		return res >= 0
+	}
+}
+
+// equal compares scalar values, objects and arrays are never equal.
+func equal(a, b any) bool {
+	switch a := a.(type) {
+	case float64:
+		b, ok := b.(float64)
+		return ok && a == b
+	case string:
+		b, ok := b.(string)
+		return ok && a == b
+	case bool:
+		b, ok := b.(bool)
+		return ok && a == b
+	case nil:
+		return b == nil
+	default:
+		return false
+	}
+}
+
+// value returns the operand value for the given element.
+func (o filterOperand) value(v any) (any, bool) {
+	if !o.isPath {
+		return o.literal, true
+	}
+	for _, s := range o.path {
+		if s.isIndex {
+			arr, ok := v.([]any)
+			if !ok {
+				return nil, false
+			}
+			i := s.index
+			if i < 0 {
+				i += len(arr)
+			}
+			if i < 0 || i >= len(arr) {
+				return nil, false
+			}
+			v = arr[i]
+			continue
+		}
+		obj, ok := v.(json.OrderedObject)
+		if !ok {
+			return nil, false
+		}
+		var found bool
+		for i := range obj {
+			if obj[i].Key == s.key {
+				v, found = obj[i].Value, true
+				break
+			}
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	return v, true
+}
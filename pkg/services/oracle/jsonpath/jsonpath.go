@@ -31,6 +31,7 @@ const (
 	pathIdentifier
 	pathString
 	pathNumber
+	pathQuestion
 )
 
 const (
@@ -104,6 +105,8 @@ func (p *pathParser) nextToken() (pathTokenType, string) {
 		typ = pathComma
 	case ':':
 		typ = pathColon
+	case '?':
+		typ = pathQuestion
 	case '\'':
 		typ = pathString
 		value, numRead, ok = p.parseString()
@@ -303,7 +306,7 @@ func (p *pathParser) descendByIndex(objs []any, indices ...int) ([]any, bool) {
 }
 
 // processLeftBracket processes index expressions which can be either
-// array/map access, array sub-slice or union of indices.
+// array/map access, array sub-slice, union of indices or filter expression.
 func (p *pathParser) processLeftBracket(objs []any) ([]any, bool) {
 	typ, value := p.nextToken()
 	switch typ {
@@ -316,6 +319,8 @@ func (p *pathParser) processLeftBracket(objs []any) ([]any, bool) {
 		return p.descend(objs)
 	case pathColon:
 		return p.processSlice(objs, 0)
+	case pathQuestion:
+		return p.processFilter(objs)
 	case pathNumber:
 		subTyp, _ := p.nextToken()
 		switch subTyp {
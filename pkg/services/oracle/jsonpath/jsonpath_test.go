@@ -334,3 +334,58 @@ func TestCSharpCompat(t *testing.T) {
 		require.False(t, ok)
 	})
 }
+
+func TestFilter(t *testing.T) {
+	js := `{"items":[
+		{"name":"a","price":5,"tags":["x"],"ok":true},
+		{"name":"b","price":15,"tags":["y","z"],"ok":false},
+		{"name":"c","price":25.5,"extra":{"n":1}},
+		{"name":"d","price":"20"}
+	],"obj":{"k1":{"v":1},"k2":{"v":2}}}`
+
+	testCases := []pathTestCase{
+		{"$.items[?(@.price > 10)].name", `["b","c"]`},
+		{"$.items[?(@.price>=15 && @.price<=25.5)].name", `["b","c"]`},
+		{"$.items[?(@.price < 10 || @.name == 'd')].name", `["a","d"]`},
+		{"$.items[?(@.price == '20')].name", `["d"]`},
+		{"$.items[?(@.name != 'a')].name", `["b","c","d"]`},
+		{"$.items[?(@.name > 'b')].name", `["c","d"]`},
+		{"$.items[?(@.ok == true)].name", `["a"]`},
+		{"$.items[?(@.ok)].name", `["a","b"]`},
+		{"$.items[?(@.extra.n == 1)].name", `["c"]`},
+		{"$.items[?(@['extra']['n'] == 1)].name", `["c"]`},
+		{"$.items[?(@.tags[1] == 'z')].name", `["b"]`},
+		{"$.items[?(@.tags[-1] == 'x')].name", `["a"]`},
+		{"$.items[?(@.missing == null)].name", `[]`},
+		{"$.items[?(@.price > -1e1)].name", `["a","b","c"]`},
+		{"$.obj[?(@.v == 2)]", `[{"v":2}]`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			tc.testUnmarshalGet(t, js)
+		})
+	}
+
+	errCases := []string{
+		"$[?]",
+		"$[?(]",
+		"$[?()]",
+		"$[?(@.a)",
+		"$[?(@.a > )]",
+		"$[?(@.a > 1 &&)]",
+		"$[?(@.a > abc)]",
+		"$[?(@.a > 'abc)]",
+		"$[?(1)]",
+		"$[?(@.1)]",
+		"$[?(@[1)]",
+		"$[?(@['\\u123'])]",
+		"$[?(@.a > 1.2.3)]",
+		"$..[?(@.v)]",
+	}
+	for _, tc := range errCases {
+		t.Run(tc, func(t *testing.T) {
+			_, ok := unmarshalGet(t, js, tc)
+			require.False(t, ok)
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package oracle
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+)
+
+// NeoRPCScheme is the URI scheme used to get data from other Neo networks.
+// URIs have neo-rpc://<network>/<method>?params=<JSON array> format where
+// network is one of the configured endpoint names and params are optional.
+// Response data is the JSON-encoded result of the RPC call.
+const NeoRPCScheme = "neo-rpc"
+
+// defaultNeoRPCMethods is a list of read-only RPC methods allowed by default.
+var defaultNeoRPCMethods = []string{
+	"findstates",
+	"findstorage",
+	"getapplicationlog",
+	"getbestblockhash",
+	"getblock",
+	"getblockcount",
+	"getblockhash",
+	"getblockheader",
+	"getblockheadercount",
+	"getcontractstate",
+	"getnativecontracts",
+	"getnep11balances",
+	"getnep11properties",
+	"getnep17balances",
+	"getnextblockvalidators",
+	"getproof",
+	"getrawtransaction",
+	"getstate",
+	"getstateheight",
+	"getstateroot",
+	"getstorage",
+	"gettransactionheight",
+	"getversion",
+	"verifyproof",
+}
+
+// maxNeoRPCResponseSize limits the amount of data read from the RPC node,
+// the result is checked against the scheme limit after decoding.
+const maxNeoRPCResponseSize = 4 * transaction.MaxOracleResultSize
+
+// neoRPCFetcher performs read-only RPC calls to configured nodes.
+type neoRPCFetcher struct {
+	client    HTTPClient
+	endpoints map[string]string
+	methods   map[string]bool
+}
+
+func newNeoRPCFetcher(cfg config.OracleNeoRPCConfiguration) (neoRPCFetcher, error) {
+	methods := cfg.Methods
+	if len(methods) == 0 {
+		methods = defaultNeoRPCMethods
+	}
+	f := neoRPCFetcher{
+		client:    newPlainClient(),
+		endpoints: make(map[string]string, len(cfg.Endpoints)),
+		methods:   make(map[string]bool, len(methods)),
+	}
+	for name, endpoint := range cfg.Endpoints {
+		if endpoint == "" {
+			return f, fmt.Errorf("empty RPC endpoint for %s network", name)
+		}
+		f.endpoints[strings.ToLower(name)] = endpoint
+	}
+	for _, m := range methods {
+		f.methods[strings.ToLower(m)] = true
+	}
+	return f, nil
+}
+
+// Fetch implements the Fetcher interface.
+func (f neoRPCFetcher) Fetch(ctx context.Context, req FetchRequest) (FetchResponse, error) {
+	endpoint, ok := f.endpoints[strings.ToLower(req.URL.Host)]
+	if !ok {
+		return FetchResponse{Code: transaction.NotFound}, fmt.Errorf("unknown network: %s", req.URL.Host)
+	}
+	method := strings.ToLower(strings.Trim(req.URL.Path, "/"))
+	if !f.methods[method] {
+		return FetchResponse{Code: transaction.Forbidden}, fmt.Errorf("method is not allowed: %s", method)
+	}
+	var params []json.RawMessage
+	if p := req.URL.Query().Get("params"); p != "" {
+		if err := json.Unmarshal([]byte(p), &params); err != nil {
+			return FetchResponse{}, fmt.Errorf("invalid params: %w", err)
+		}
+	}
+	rpcReq := neorpc.Request{
+		JSONRPC: neorpc.JSONRPCVersion,
+		Method:  method,
+		Params:  make([]any, len(params)),
+		ID:      req.ID,
+	}
+	for i := range params {
+		rpcReq.Params[i] = params[i]
+	}
+	body, err := json.Marshal(rpcReq)
+	if err != nil {
+		return FetchResponse{}, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(body))
+	if err != nil {
+		return FetchResponse{}, fmt.Errorf("failed to create http request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	r, err := f.client.Do(httpReq)
+	if err != nil {
+		return FetchResponse{}, err
+	}
+	defer r.Body.Close()
+
+	var resp neorpc.Response
+	err = json.NewDecoder(io.LimitReader(r.Body, maxNeoRPCResponseSize)).Decode(&resp)
+	if err != nil {
+		return FetchResponse{}, fmt.Errorf("invalid RPC response (HTTP status %d): %w", r.StatusCode, err)
+	}
+	if resp.Error != nil {
+		code := transaction.Error
+		if resp.Error.Code <= neorpc.ErrUnknownBlockCode && resp.Error.Code >= neorpc.ErrUnknownHeightCode {
+			code = transaction.NotFound
+		}
+		return FetchResponse{Code: code}, resp.Error
+	}
+	if len(resp.Result) == 0 {
+		return FetchResponse{}, errors.New("empty RPC result")
+	}
+	return FetchResponse{
+		Code:        transaction.Success,
+		ContentType: "application/json",
+		Body:        io.NopCloser(bytes.NewReader(resp.Result)),
+	}, nil
+}
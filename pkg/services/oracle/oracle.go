@@ -66,6 +66,13 @@ type (
 		responses map[uint64]*incompleteTx
 		// removed contains ids of requests which won't be processed further due to expiration.
 		removed map[uint64]bool
+		// fetchers contains request handlers by URI scheme.
+		fetchers map[string]*fetcherEntry
+		// cache contains recent responses, it's nil if caching is disabled.
+		cache *responseCache
+		// limiter limits concurrent requests per host, it's nil if there
+		// is no limit.
+		limiter *hostLimiter
 
 		wallet *wallet.Wallet
 	}
@@ -126,6 +133,7 @@ func NewOracle(cfg Config) (*Oracle, error) {
 		pending:    make(map[uint64]*state.OracleRequest),
 		responses:  make(map[uint64]*incompleteTx),
 		removed:    make(map[uint64]bool),
+		fetchers:   make(map[string]*fetcherEntry),
 	}
 	if o.MainCfg.RequestTimeout == 0 {
 		o.MainCfg.RequestTimeout = defaultRequestTimeout
@@ -137,6 +145,8 @@ func NewOracle(cfg Config) (*Oracle, error) {
 		o.MainCfg.MaxConcurrentRequests = defaultMaxConcurrentRequests
 	}
 	o.requestCh = make(chan request, o.MainCfg.MaxConcurrentRequests)
+	o.cache = newResponseCache(o.MainCfg.ResponseCacheTTL)
+	o.limiter = newHostLimiter(o.MainCfg.MaxConcurrentHostRequests)
 	if o.MainCfg.MaxTaskTimeout == 0 {
 		o.MainCfg.MaxTaskTimeout = defaultMaxTaskTimeout
 	}
@@ -170,6 +180,9 @@ func NewOracle(cfg Config) (*Oracle, error) {
 	if o.Client == nil {
 		o.Client = getDefaultClient(o.MainCfg)
 	}
+	if err := o.registerDefaultFetchers(); err != nil {
+		return nil, err
+	}
 	return o, nil
 }
 
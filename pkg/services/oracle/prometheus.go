@@ -0,0 +1,53 @@
+package oracle
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics used in monitoring service.
+var (
+	cacheHits = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Help:      "Number of oracle requests served from the response cache",
+			Name:      "oracle_cache_hits",
+			Namespace: "neogo",
+		},
+	)
+	cacheMisses = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Help:      "Number of oracle requests not found in the response cache",
+			Name:      "oracle_cache_misses",
+			Namespace: "neogo",
+		},
+	)
+	fetchTime = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Help:      "Oracle request data fetching time by URI scheme",
+			Name:      "oracle_fetch_time",
+			Namespace: "neogo",
+		},
+		[]string{"scheme"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		cacheHits,
+		cacheMisses,
+		fetchTime,
+	)
+}
+
+func addCacheMetric(hit bool) {
+	if hit {
+		cacheHits.Inc()
+	} else {
+		cacheMisses.Inc()
+	}
+}
+
+func addFetchTimeMetric(scheme string, t time.Duration) {
+	fetchTime.WithLabelValues(scheme).Observe(t.Seconds())
+}
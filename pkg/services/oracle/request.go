@@ -1,10 +1,8 @@
 package oracle
 
 import (
-	"context"
 	"errors"
 	"mime"
-	"net/http"
 	"net/url"
 	"time"
 
@@ -12,7 +10,6 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
-	"github.com/nspcc-dev/neo-go/pkg/services/oracle/neofs"
 	"go.uber.org/zap"
 )
 
@@ -112,82 +109,8 @@ func (o *Oracle) processRequest(priv *keys.PrivateKey, req request) error {
 	if incTx == nil {
 		return nil
 	}
-	resp := &transaction.OracleResponse{ID: req.ID, Code: transaction.Success}
-	u, err := url.ParseRequestURI(req.Req.URL)
-	if err != nil {
-		o.Log.Warn("malformed oracle request", zap.String("url", req.Req.URL), zap.Error(err))
-		resp.Code = transaction.ProtocolNotSupported
-	} else {
-		switch u.Scheme {
-		case "https":
-			httpReq, err := http.NewRequest("GET", req.Req.URL, nil)
-			if err != nil {
-				o.Log.Warn("failed to create http request", zap.String("url", req.Req.URL), zap.Error(err))
-				resp.Code = transaction.Error
-				break
-			}
-			httpReq.Header.Set("User-Agent", "NeoOracleService/3.0")
-			httpReq.Header.Set("Content-Type", "application/json")
-			r, err := o.Client.Do(httpReq)
-			if err != nil {
-				if errors.Is(err, ErrRestrictedRedirect) {
-					resp.Code = transaction.Forbidden
-				} else {
-					resp.Code = transaction.Error
-				}
-				o.Log.Warn("oracle request failed", zap.String("url", req.Req.URL), zap.Error(err), zap.Stringer("code", resp.Code))
-				break
-			}
-			defer r.Body.Close()
-			switch r.StatusCode {
-			case http.StatusOK:
-				if !checkMediaType(r.Header.Get("Content-Type"), o.MainCfg.AllowedContentTypes) {
-					resp.Code = transaction.ContentTypeNotSupported
-					break
-				}
-
-				resp.Result, resp.Code = o.readResponse(r.Body, req.Req.URL)
-			case http.StatusForbidden:
-				resp.Code = transaction.Forbidden
-			case http.StatusNotFound:
-				resp.Code = transaction.NotFound
-			case http.StatusRequestTimeout:
-				resp.Code = transaction.Timeout
-			default:
-				resp.Code = transaction.Error
-			}
-		case neofs.URIScheme:
-			if len(o.MainCfg.NeoFS.Nodes) == 0 {
-				o.Log.Warn("no NeoFS nodes configured", zap.String("url", req.Req.URL))
-				resp.Code = transaction.Error
-				break
-			}
-			ctx, cancel := context.WithTimeout(context.Background(), o.MainCfg.NeoFS.Timeout)
-			defer cancel()
-			index := (int(req.ID) + incTx.attempts) % len(o.MainCfg.NeoFS.Nodes)
-			rc, err := neofs.Get(ctx, priv, u, o.MainCfg.NeoFS.Nodes[index])
-			if err != nil {
-				resp.Code = transaction.Error
-				o.Log.Warn("failed to perform oracle request", zap.String("url", req.Req.URL), zap.Error(err))
-				if rc != nil {
-					rc.Close() // intentionally skip the closing error, make it unified with Oracle `https` protocol.
-				}
-				break
-			}
-			resp.Result, resp.Code = o.readResponse(rc, req.Req.URL)
-			rc.Close() // intentionally skip the closing error, make it unified with Oracle `https` protocol.
-		default:
-			resp.Code = transaction.ProtocolNotSupported
-			o.Log.Warn("unknown oracle request scheme", zap.String("url", req.Req.URL))
-		}
-	}
-	if resp.Code == transaction.Success {
-		resp.Result, err = filterRequest(resp.Result, req.Req)
-		if err != nil {
-			o.Log.Warn("oracle filter failed", zap.Uint64("request", req.ID), zap.Error(err))
-			resp.Code = transaction.Error
-		}
-	}
+	resp := &transaction.OracleResponse{ID: req.ID}
+	resp.Result, resp.Code = o.getResult(priv, req, incTx.attempts)
 	o.Log.Debug("oracle request processed", zap.String("url", req.Req.URL), zap.Int("code", int(resp.Code)), zap.String("result", string(resp.Result)))
 
 	currentHeight := o.Chain.BlockHeight()
@@ -244,6 +167,40 @@ func (o *Oracle) processRequest(priv *keys.PrivateKey, req request) error {
 	return nil
 }
 
+// getResult returns the filtered response data for the request, it's taken
+// from the cache if the same request was successfully processed recently.
+func (o *Oracle) getResult(priv *keys.PrivateKey, req request, attempt int) ([]byte, transaction.OracleResponseCode) {
+	key := cacheKey(req.Req.URL, req.Req.Filter)
+	if res, code, ok := o.cache.get(key); ok {
+		addCacheMetric(true)
+		return res, code
+	}
+	if o.cache != nil {
+		addCacheMetric(false)
+	}
+
+	var (
+		res  []byte
+		code transaction.OracleResponseCode
+	)
+	u, err := url.ParseRequestURI(req.Req.URL)
+	if err != nil {
+		o.Log.Warn("malformed oracle request", zap.String("url", req.Req.URL), zap.Error(err))
+		code = transaction.ProtocolNotSupported
+	} else {
+		res, code = o.fetch(priv, req.ID, attempt, u, req.Req.URL)
+	}
+	if code == transaction.Success {
+		res, err = filterRequest(res, req.Req)
+		if err != nil {
+			o.Log.Warn("oracle filter failed", zap.Uint64("request", req.ID), zap.Error(err))
+			res, code = nil, transaction.Error
+		}
+	}
+	o.cache.put(key, res, code)
+	return res, code
+}
+
 func (o *Oracle) processFailedRequest(priv *keys.PrivateKey, req request) {
 	// Request is being processed again.
 	incTx := o.getResponse(req.ID, false)
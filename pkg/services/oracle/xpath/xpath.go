@@ -0,0 +1,433 @@
+/*
+Package xpath implements a restricted XPath subset for oracle response
+filtering.
+
+Supported expressions are absolute location paths consisting of child (`/`)
+and descendant (`//`) steps. Each step is either an element name (namespaces
+are ignored, only local names are matched) or `*` optionally followed by
+predicates: position (`[1]`, 1-based), attribute existence (`[@id]`),
+attribute value (`[@id='x']`) or child element value (`[name='x']`). The
+last step can also be `@attr` to select attribute values or `text()` to
+select text nodes. The result is a list of string values of selected nodes in
+the document order.
+*/
+package xpath
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// maxNestingDepth is the maximum document nesting depth.
+	maxNestingDepth = 64
+	// maxObjects is the maximum number of results.
+	maxObjects = 1024
+)
+
+type (
+	// node is an element (or document) node of the parsed document.
+	node struct {
+		name     string
+		attrs    []xml.Attr
+		children []*node
+		// texts are the text nodes of the element.
+		texts []string
+		// content contains element text and child nodes in the document
+		// order, it's used to compute the string value.
+		content []any
+		order   int
+	}
+
+	// step is a single location path step.
+	step struct {
+		descendant bool
+		// name is the element name test ("*" matches any element).
+		name       string
+		predicates []predicate
+		// attr is set for the final attribute selection step.
+		attr string
+		// text is set for the final text() step.
+		text bool
+	}
+
+	// predicate is a step predicate.
+	predicate struct {
+		// position is 1-based, 0 if not a position predicate.
+		position int
+		attr     string
+		child    string
+		// value is the value to compare to, nil for existence check.
+		value *string
+	}
+)
+
+var (
+	errInvalidPath  = errors.New("invalid XPath")
+	errTooDeep      = errors.New("document is too deep")
+	errTooManyNodes = errors.New("too many objects")
+)
+
+// Get returns string values of the nodes of XML document selected by the path.
+func Get(path string, doc []byte) ([]string, error) {
+	steps, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	root, err := parseDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := []*node{root}
+	for i, s := range steps {
+		if s.attr != "" || s.text {
+			if i != len(steps)-1 {
+				return nil, fmt.Errorf("%w: %s must be the last step", errInvalidPath, stepName(s))
+			}
+			return selectValues(ctx, s)
+		}
+		ctx = selectNodes(ctx, s)
+		if len(ctx) > maxObjects {
+			return nil, errTooManyNodes
+		}
+	}
+	res := make([]string, len(ctx))
+	for i := range ctx {
+		res[i] = ctx[i].stringValue()
+	}
+	return res, nil
+}
+
+func stepName(s step) string {
+	if s.text {
+		return "text()"
+	}
+	return "@" + s.attr
+}
+
+// parsePath parses the location path into steps.
+func parsePath(path string) ([]step, error) {
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("%w: path must be absolute", errInvalidPath)
+	}
+	var steps []step
+	for len(path) != 0 {
+		var s step
+		switch {
+		case strings.HasPrefix(path, "//"):
+			s.descendant = true
+			path = path[2:]
+		case path[0] == '/':
+			path = path[1:]
+		default:
+			return nil, fmt.Errorf("%w: unexpected %q", errInvalidPath, path)
+		}
+		var (
+			end = stepEnd(path)
+			str = path[:end]
+		)
+		path = path[end:]
+		i := strings.IndexByte(str, '[')
+		if i < 0 {
+			i = len(str)
+		}
+		name := str[:i]
+		switch {
+		case name == "text()":
+			s.text = true
+		case strings.HasPrefix(name, "@") && isName(name[1:]):
+			s.attr = name[1:]
+		case name == "*" || isName(name):
+			s.name = name
+		default:
+			return nil, fmt.Errorf("%w: invalid step %q", errInvalidPath, str)
+		}
+		for str = str[i:]; len(str) != 0; {
+			j := indexUnquoted(str, ']')
+			if str[0] != '[' || j < 0 {
+				return nil, fmt.Errorf("%w: invalid predicate %q", errInvalidPath, str)
+			}
+			p, err := parsePredicate(str[1:j])
+			if err != nil {
+				return nil, err
+			}
+			s.predicates = append(s.predicates, p)
+			str = str[j+1:]
+		}
+		if (s.attr != "" || s.text) && len(s.predicates) != 0 {
+			return nil, fmt.Errorf("%w: predicates are not allowed for %s", errInvalidPath, stepName(s))
+		}
+		steps = append(steps, s)
+	}
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("%w: empty path", errInvalidPath)
+	}
+	return steps, nil
+}
+
+// stepEnd returns the end of the first step.
+func stepEnd(path string) int {
+	if i := indexUnquoted(path, '/'); i >= 0 {
+		return i
+	}
+	return len(path)
+}
+
+// indexUnquoted returns the index of the first b outside of quotes or -1.
+func indexUnquoted(s string, b byte) int {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == b:
+			return i
+		}
+	}
+	return -1
+}
+
+func parsePredicate(s string) (predicate, error) {
+	var p predicate
+	s = strings.TrimSpace(s)
+	if n, err := strconv.Atoi(s); err == nil {
+		if n < 1 {
+			return p, fmt.Errorf("%w: invalid position %d", errInvalidPath, n)
+		}
+		p.position = n
+		return p, nil
+	}
+	name, value, hasValue := strings.Cut(s, "=")
+	name = strings.TrimSpace(name)
+	if hasValue {
+		value = strings.TrimSpace(value)
+		if len(value) < 2 || (value[0] != '\'' && value[0] != '"') || value[len(value)-1] != value[0] {
+			return p, fmt.Errorf("%w: invalid literal %s", errInvalidPath, value)
+		}
+		value = value[1 : len(value)-1]
+		p.value = &value
+	}
+	switch {
+	case strings.HasPrefix(name, "@") && isName(name[1:]):
+		p.attr = name[1:]
+	case isName(name) && hasValue:
+		p.child = name
+	default:
+		return p, fmt.Errorf("%w: invalid predicate %q", errInvalidPath, s)
+	}
+	return p, nil
+}
+
+func isName(s string) bool {
+	if len(s) == 0 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '_' && !('a' <= c && c <= 'z') && !('A' <= c && c <= 'Z') &&
+			(i == 0 || (c != '-' && c != '.' && !('0' <= c && c <= '9'))) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseDocument parses XML document into the tree with the document node
+// as the root.
+func parseDocument(doc []byte) (*node, error) {
+	var (
+		d     = xml.NewDecoder(bytes.NewReader(doc))
+		root  = &node{}
+		stack = []*node{root}
+		count int
+	)
+	d.Strict = true
+	for {
+		tok, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		cur := stack[len(stack)-1]
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if len(stack) > maxNestingDepth {
+				return nil, errTooDeep
+			}
+			count++
+			n := &node{name: t.Name.Local, attrs: t.Attr, order: count}
+			cur.children = append(cur.children, n)
+			cur.content = append(cur.content, n)
+			stack = append(stack, n)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if cur == root {
+				continue
+			}
+			s := string(t)
+			if l := len(cur.content); l != 0 {
+				if prev, ok := cur.content[l-1].(string); ok {
+					cur.content[l-1] = prev + s
+					cur.texts[len(cur.texts)-1] += s
+					continue
+				}
+			}
+			cur.content = append(cur.content, s)
+			cur.texts = append(cur.texts, s)
+		}
+	}
+	if len(root.children) != 1 {
+		return nil, errors.New("document must have a single root element")
+	}
+	return root, nil
+}
+
+// stringValue returns the concatenation of all descendant text nodes.
+func (n *node) stringValue() string {
+	var sb strings.Builder
+	n.writeText(&sb)
+	return sb.String()
+}
+
+func (n *node) writeText(sb *strings.Builder) {
+	for _, c := range n.content {
+		switch c := c.(type) {
+		case string:
+			sb.WriteString(c)
+		case *node:
+			c.writeText(sb)
+		}
+	}
+}
+
+func (n *node) attr(name string) (string, bool) {
+	for _, a := range n.attrs {
+		if a.Name.Local == name {
+			return a.Value, true
+		}
+	}
+	return "", false
+}
+
+// children returns children of the given nodes matching the step, predicates
+// are applied to children of every parent separately.
+func (s step) children(parents []*node) []*node {
+	var res []*node
+	for _, p := range parents {
+		var group []*node
+		for _, c := range p.children {
+			if s.name == "*" || c.name == s.name {
+				group = append(group, c)
+			}
+		}
+		for _, pr := range s.predicates {
+			group = pr.filter(group)
+		}
+		res = append(res, group...)
+	}
+	return res
+}
+
+// selectNodes applies the element step to the context nodes.
+func selectNodes(ctx []*node, s step) []*node {
+	parents := ctx
+	if s.descendant {
+		parents = descendantsOrSelf(ctx)
+	}
+	return unique(s.children(parents))
+}
+
+// selectValues applies the final attribute or text() step.
+func selectValues(ctx []*node, s step) ([]string, error) {
+	if s.descendant {
+		ctx = descendantsOrSelf(ctx)
+	}
+	var res []string
+	for _, n := range ctx {
+		if s.text {
+			res = append(res, n.texts...)
+		} else if v, ok := n.attr(s.attr); ok {
+			res = append(res, v)
+		}
+		if len(res) > maxObjects {
+			return nil, errTooManyNodes
+		}
+	}
+	if res == nil {
+		res = []string{}
+	}
+	return res, nil
+}
+
+func (p predicate) filter(nodes []*node) []*node {
+	if p.position != 0 {
+		if p.position > len(nodes) {
+			return nil
+		}
+		return nodes[p.position-1 : p.position]
+	}
+	var res []*node
+	for _, n := range nodes {
+		if p.match(n) {
+			res = append(res, n)
+		}
+	}
+	return res
+}
+
+func (p predicate) match(n *node) bool {
+	if p.attr != "" {
+		v, ok := n.attr(p.attr)
+		return ok && (p.value == nil || v == *p.value)
+	}
+	for _, c := range n.children {
+		if c.name == p.child && c.stringValue() == *p.value {
+			return true
+		}
+	}
+	return false
+}
+
+// descendantsOrSelf returns the nodes with all of their descendants.
+func descendantsOrSelf(nodes []*node) []*node {
+	var res []*node
+	var walk func(n *node)
+	walk = func(n *node) {
+		res = append(res, n)
+		for _, c := range n.children {
+			walk(c)
+		}
+	}
+	for _, n := range nodes {
+		walk(n)
+	}
+	return unique(res)
+}
+
+// unique removes duplicates and sorts nodes in the document order.
+func unique(nodes []*node) []*node {
+	seen := make(map[*node]bool, len(nodes))
+	res := nodes[:0]
+	for _, n := range nodes {
+		if !seen[n] {
+			seen[n] = true
+			res = append(res, n)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].order < res[j].order })
+	return res
+}
@@ -0,0 +1,89 @@
+package xpath
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGet(t *testing.T) {
+	doc := `<?xml version="1.0"?>
+<catalog xmlns:x="urn:x">
+	<book id="b1" lang="en"><title>Go</title><price>10</price></book>
+	<book id="b2"><title>Neo</title><price>20</price><x:note>rare</x:note></book>
+	<shelf><book id="b3"><title>Deep <i>nested</i></title></book></shelf>
+</catalog>`
+
+	testCases := []struct {
+		path   string
+		result []string
+	}{
+		{"/catalog/book/title", []string{"Go", "Neo"}},
+		{"//book/title", []string{"Go", "Neo", "Deep nested"}},
+		{"//title/text()", []string{"Go", "Neo", "Deep "}},
+		{"/catalog/book/@id", []string{"b1", "b2"}},
+		{"//@lang", []string{"en"}},
+		{"//book[2]/title", []string{"Neo"}},
+		{"//book[1]/@id", []string{"b1", "b3"}},
+		{"/catalog/book[1]/price", []string{"10"}},
+		{"/catalog/book[5]", []string{}},
+		{"//book[@lang]/@id", []string{"b1"}},
+		{"//book[@id='b3']/title/i", []string{"nested"}},
+		{`//book[title="Neo"]/price`, []string{"20"}},
+		{"//book[@id='b2'][price='20']/note", []string{"rare"}},
+		{"/catalog/*/book/@id", []string{"b3"}},
+		{"/catalog/missing", []string{}},
+		{"//*[@id='b1']/*", []string{"Go", "10"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			actual, err := Get(tc.path, []byte(doc))
+			require.NoError(t, err)
+			require.Equal(t, tc.result, actual)
+		})
+	}
+
+	for _, path := range []string{
+		"",
+		"catalog",
+		"/catalog/",
+		"/catalog/@id/book",
+		"/catalog/text()/book",
+		"/catalog/book[0]",
+		"/catalog/book[@id='b1'",
+		"/catalog/book[title]",
+		"/catalog/book[@id=b1]",
+		"/catalog/book/@id[1]",
+		"/1book",
+	} {
+		t.Run("invalid "+path, func(t *testing.T) {
+			_, err := Get(path, []byte(doc))
+			require.ErrorIs(t, err, errInvalidPath)
+		})
+	}
+
+	t.Run("invalid document", func(t *testing.T) {
+		for _, d := range []string{"", "<a>", "<a></b>", "<a/><b/>", "text"} {
+			_, err := Get("/a", []byte(d))
+			require.Error(t, err, d)
+		}
+	})
+
+	t.Run("too deep", func(t *testing.T) {
+		d := strings.Repeat("<a>", maxNestingDepth+1) + strings.Repeat("</a>", maxNestingDepth+1)
+		_, err := Get("/a", []byte(d))
+		require.ErrorIs(t, err, errTooDeep)
+	})
+
+	t.Run("too many objects", func(t *testing.T) {
+		d := "<a>" + strings.Repeat("<b/>", maxObjects+1) + "</a>"
+		_, err := Get("/a/b", []byte(d))
+		require.ErrorIs(t, err, errTooManyNodes)
+
+		d = "<a>" + strings.Repeat("<b/>", maxObjects) + "</a>"
+		res, err := Get("//b", []byte(d))
+		require.NoError(t, err)
+		require.Len(t, res, maxObjects)
+	})
+}
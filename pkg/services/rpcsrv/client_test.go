@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"sort"
@@ -39,8 +40,10 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/network"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/actor"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/cryptolib"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/gas"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/invoker"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/ledger"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/management"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/neo"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/nep11"
@@ -50,7 +53,10 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/notary"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/oracle"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/policy"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/pool"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/rolemgmt"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/stdlib"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/unwrap"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
@@ -108,6 +114,127 @@ func TestClient_NEP17(t *testing.T) {
 	})
 }
 
+func TestClient_Pool(t *testing.T) {
+	_, _, httpSrv := initServerWithInMemoryChain(t)
+
+	p, err := pool.Dial(context.Background(), []string{httpSrv.URL, httpSrv.URL}, rpcclient.Options{}, pool.Options{})
+	require.NoError(t, err)
+	t.Cleanup(p.Close)
+
+	inv := invoker.New(p, nil)
+	gasR := gas.NewReader(inv)
+	b, err := gasR.BalanceOf(testchain.CommitteeScriptHash())
+	require.NoError(t, err)
+	require.Positive(t, b.Sign())
+
+	neoR := neo.NewReader(inv)
+	iter, err := neoR.GetAllCandidates()
+	require.NoError(t, err)
+	cands, err := iter.Next(10)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(cands))
+	require.NoError(t, iter.Terminate())
+
+	act, err := actor.New(p, []actor.SignerAccount{{
+		Signer: transaction.Signer{
+			Account: testchain.CommitteeScriptHash(),
+			Scopes:  transaction.CalledByEntry,
+		},
+		Account: &wallet.Account{
+			Address: testchain.CommitteeAddress(),
+			Contract: &wallet.Contract{
+				Script: testchain.CommitteeVerificationScript(),
+			},
+		},
+	}})
+	require.NoError(t, err)
+	tx, err := gas.New(act).TransferUnsigned(testchain.CommitteeScriptHash(), util.Uint160{1, 2, 3}, big.NewInt(1), nil)
+	require.NoError(t, err)
+	require.NotZero(t, tx.NetworkFee)
+
+	for _, st := range p.Status() {
+		require.True(t, st.Healthy)
+	}
+}
+
+func TestClient_Batch(t *testing.T) {
+	chain, _, httpSrv := initServerWithInMemoryChain(t)
+
+	run := func(t *testing.T, c *rpcclient.Client) {
+		b := c.NewBatch()
+		count := b.GetBlockCount()
+		var blocks []*rpcclient.BatchCall[*block.Block]
+		for i := uint32(0); i < 5; i++ {
+			blocks = append(blocks, b.GetBlockByIndex(i))
+		}
+		hash := b.GetBlockHash(1)
+		appLog := b.GetApplicationLog(chain.GetHeaderHash(1), nil)
+		missing := b.GetBlockHash(100500)
+		symbol := b.InvokeFunction(nativehashes.GasToken, "symbol", []smartcontract.Parameter{}, nil)
+		headers := rpcclient.AddBatchCall[uint32](b, "getblockheadercount", nil)
+		require.Equal(t, 11, b.Len())
+
+		_, err := count.Result()
+		require.Error(t, err)
+		require.NoError(t, b.Send())
+		require.Error(t, b.Send())
+
+		cnt, err := count.Result()
+		require.NoError(t, err)
+		require.Equal(t, chain.BlockHeight()+1, cnt)
+		for i, call := range blocks {
+			blk, err := call.Result()
+			require.NoError(t, err)
+			require.Equal(t, chain.GetHeaderHash(uint32(i)), blk.Hash())
+		}
+		h, err := hash.Result()
+		require.NoError(t, err)
+		require.Equal(t, chain.GetHeaderHash(1), h)
+		log, err := appLog.Result()
+		require.NoError(t, err)
+		require.Equal(t, chain.GetHeaderHash(1), log.Container)
+		_, err = missing.Result()
+		require.ErrorIs(t, err, neorpc.ErrUnknownHeight)
+		sym, err := unwrap.PrintableASCIIString(symbol.Result())
+		require.NoError(t, err)
+		require.Equal(t, "GAS", sym)
+		hc, err := headers.Result()
+		require.NoError(t, err)
+		require.Equal(t, chain.HeaderHeight()+1, hc)
+
+		require.NoError(t, c.NewBatch().Send())
+	}
+
+	t.Run("http", func(t *testing.T) {
+		c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+		require.NoError(t, err)
+		t.Cleanup(c.Close)
+		require.NoError(t, c.Init())
+		run(t, c)
+
+		tok, err := neptoken.Info(c, nativehashes.GasToken)
+		require.NoError(t, err)
+		require.Equal(t, "GAS", tok.Symbol)
+		require.EqualValues(t, 8, tok.Decimals)
+
+		accs := []util.Uint160{testchain.CommitteeScriptHash(), {1, 2, 3}}
+		bals, err := gas.NewReader(invoker.New(c, nil)).BalancesOf(accs...)
+		require.NoError(t, err)
+		require.Len(t, bals, 2)
+		expected, err := gas.NewReader(invoker.New(c, nil)).BalanceOf(accs[0])
+		require.NoError(t, err)
+		require.Equal(t, expected, bals[0])
+		require.Zero(t, bals[1].Sign())
+	})
+	t.Run("ws", func(t *testing.T) {
+		c, err := rpcclient.NewWS(context.Background(), "ws"+strings.TrimPrefix(httpSrv.URL, "http")+"/ws", rpcclient.WSOptions{})
+		require.NoError(t, err)
+		t.Cleanup(c.Close)
+		require.NoError(t, c.Init())
+		run(t, &c.Client)
+	})
+}
+
 func TestClientRoleManagement(t *testing.T) {
 	chain, _, httpSrv := initServerWithInMemoryChain(t)
 
@@ -249,6 +376,181 @@ func TestClientPolicyContract(t *testing.T) {
 	require.True(t, ret)
 }
 
+func TestClientLedgerContract(t *testing.T) {
+	chain, _, httpSrv := initServerWithInMemoryChain(t)
+
+	c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+	require.NoError(t, err)
+	t.Cleanup(c.Close)
+	require.NoError(t, c.Init())
+
+	lc := ledger.NewReader(invoker.New(c, nil))
+	unknown := hash.Sha256([]byte{1, 2, 3})
+
+	h, err := lc.CurrentHash()
+	require.NoError(t, err)
+	require.Equal(t, chain.CurrentBlockHash(), h)
+
+	idx, err := lc.CurrentIndex()
+	require.NoError(t, err)
+	require.Equal(t, chain.BlockHeight(), idx)
+
+	expected, err := chain.GetBlock(chain.GetHeaderHash(1))
+	require.NoError(t, err)
+	require.NotEqual(t, 0, len(expected.Transactions))
+	for _, get := range []func() (*ledger.Block, error){
+		func() (*ledger.Block, error) { return lc.GetBlock(1) },
+		func() (*ledger.Block, error) { return lc.GetBlockByHash(expected.Hash()) },
+	} {
+		b, err := get()
+		require.NoError(t, err)
+		require.Equal(t, expected.Hash(), b.Hash)
+		require.Equal(t, expected.PrevHash, b.PrevHash)
+		require.Equal(t, expected.Index, b.Index)
+		require.Equal(t, expected.Timestamp, b.Timestamp)
+		require.Equal(t, uint32(len(expected.Transactions)), b.TransactionsLength)
+	}
+	b, err := lc.GetBlockByHash(unknown)
+	require.NoError(t, err)
+	require.Nil(t, b)
+
+	etx := expected.Transactions[0]
+	for _, get := range []func() (*ledger.Transaction, error){
+		func() (*ledger.Transaction, error) { return lc.GetTransaction(etx.Hash()) },
+		func() (*ledger.Transaction, error) { return lc.GetTransactionFromBlock(1, 0) },
+		func() (*ledger.Transaction, error) { return lc.GetTransactionFromBlockByHash(expected.Hash(), 0) },
+	} {
+		tx, err := get()
+		require.NoError(t, err)
+		require.Equal(t, etx.Hash(), tx.Hash)
+		require.Equal(t, etx.Sender(), tx.Sender)
+		require.Equal(t, etx.Script, tx.Script)
+	}
+	tx, err := lc.GetTransaction(unknown)
+	require.NoError(t, err)
+	require.Nil(t, tx)
+
+	height, err := lc.GetTransactionHeight(etx.Hash())
+	require.NoError(t, err)
+	require.Equal(t, int64(1), height)
+	height, err = lc.GetTransactionHeight(unknown)
+	require.NoError(t, err)
+	require.Equal(t, int64(-1), height)
+
+	signers, err := lc.GetTransactionSigners(etx.Hash())
+	require.NoError(t, err)
+	require.Equal(t, etx.Signers, signers)
+
+	st, err := lc.GetTransactionVMState(etx.Hash())
+	require.NoError(t, err)
+	require.Equal(t, vmstate.Halt, st)
+	st, err = lc.GetTransactionVMState(unknown)
+	require.NoError(t, err)
+	require.Equal(t, vmstate.None, st)
+}
+
+func TestClientStdLib(t *testing.T) {
+	_, _, httpSrv := initServerWithInMemoryChain(t)
+
+	c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+	require.NoError(t, err)
+	t.Cleanup(c.Close)
+	require.NoError(t, c.Init())
+
+	sl := stdlib.NewReader(invoker.New(c, nil))
+
+	data, err := sl.Serialize(42)
+	require.NoError(t, err)
+	itm, err := sl.Deserialize(data)
+	require.NoError(t, err)
+	require.Equal(t, stackitem.Make(42), itm)
+
+	data, err = sl.JSONSerialize([]any{1, "a"})
+	require.NoError(t, err)
+	require.Equal(t, `[1,"a"]`, string(data))
+	_, err = sl.JSONDeserialize(data)
+	require.NoError(t, err)
+
+	s, err := sl.Base64Encode([]byte{1, 2, 3})
+	require.NoError(t, err)
+	require.Equal(t, base64.StdEncoding.EncodeToString([]byte{1, 2, 3}), s)
+	data, err = sl.Base64Decode(s)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 2, 3}, data)
+
+	s, err = sl.Base58CheckEncode([]byte{1, 2, 3})
+	require.NoError(t, err)
+	data, err = sl.Base58CheckDecode(s)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 2, 3}, data)
+
+	s, err = sl.Itoa(big.NewInt(255), 16)
+	require.NoError(t, err)
+	require.Equal(t, "0ff", s)
+	s, err = sl.Itoa10(big.NewInt(-42))
+	require.NoError(t, err)
+	require.Equal(t, "-42", s)
+	n, err := sl.Atoi10("-42")
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(-42), n)
+
+	i, err := sl.MemorySearchLastIndex([]byte("abcabc"), []byte("bc"), 6)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), i)
+	i, err = sl.MemorySearchIndex([]byte("abcabc"), []byte("bc"), 2)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), i)
+
+	strs, err := sl.StringSplitNonEmpty("a,,b", ",")
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, strs)
+
+	i, err = sl.StrLen("ä")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), i)
+}
+
+func TestClientCryptoLib(t *testing.T) {
+	_, _, httpSrv := initServerWithInMemoryChain(t)
+
+	c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+	require.NoError(t, err)
+	t.Cleanup(c.Close)
+	require.NoError(t, c.Init())
+
+	cl := cryptolib.NewReader(invoker.New(c, nil))
+
+	sha, err := cl.Sha256([]byte{1, 2, 3})
+	require.NoError(t, err)
+	require.Equal(t, hash.Sha256([]byte{1, 2, 3}), sha)
+
+	ripe, err := cl.Ripemd160([]byte{1, 2, 3})
+	require.NoError(t, err)
+	require.Equal(t, hash.RipeMD160([]byte{1, 2, 3}), ripe)
+
+	pk := testchain.PrivateKey(0)
+	msg := []byte{1, 2, 3}
+	ok, err := cl.VerifyWithECDsa(msg, pk.PublicKey(), pk.Sign(msg), cryptolib.Secp256r1Sha256)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	g1, err := hex.DecodeString("97f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb")
+	require.NoError(t, err)
+	sum, err := cl.Bls12381Add(g1, g1)
+	require.NoError(t, err)
+	mul := make([]byte, 32)
+	mul[0] = 2
+	prod, err := cl.Bls12381Mul(g1, mul, false)
+	require.NoError(t, err)
+	require.Equal(t, sum, prod)
+	ok, err = cl.Bls12381Equal(sum, prod)
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = cl.Bls12381Equal(g1, prod)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
 func TestClientManagementContract(t *testing.T) {
 	chain, _, httpSrv := initServerWithInMemoryChain(t)
 
@@ -394,6 +696,10 @@ func TestClientNEOContract(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 0, len(cands)) // No registrations.
 
+	votes, err := neoR.GetCandidateVote(testchain.PrivateKey(0).PublicKey())
+	require.NoError(t, err)
+	require.Equal(t, int64(-1), votes) // Not a candidate.
+
 	iter, err := neoR.GetAllCandidates()
 	require.NoError(t, err)
 	cands, err = iter.Next(10)
@@ -1274,6 +1580,16 @@ func TestClient_NEP11_ND(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, [][]byte{[]byte("neo.com")}, items)
 	})
+	t.Run("IterateTokens", func(t *testing.T) {
+		iter := n11.IterateTokens()
+		var items [][]byte
+		iter.All()(func(tok []byte) bool {
+			items = append(items, tok)
+			return true
+		})
+		require.NoError(t, iter.Err())
+		require.Equal(t, [][]byte{[]byte("neo.com")}, items)
+	})
 	t.Run("Properties", func(t *testing.T) {
 		p, err := n11.Properties([]byte("neo.com"))
 		require.NoError(t, err)
@@ -2115,6 +2431,260 @@ waitloop:
 	}
 }
 
+// tcpProxy forwards TCP connections to the target address and allows to
+// break them.
+type tcpProxy struct {
+	ln     net.Listener
+	target string
+
+	lock  sync.Mutex
+	down  bool
+	conns []net.Conn
+}
+
+func newTCPProxy(t *testing.T, target string) *tcpProxy {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	p := &tcpProxy{ln: ln, target: target}
+	go p.serve()
+	t.Cleanup(func() {
+		_ = ln.Close()
+		p.setDown(true)
+	})
+	return p
+}
+
+func (p *tcpProxy) serve() {
+	for {
+		conn, err := p.ln.Accept()
+		if err != nil {
+			return
+		}
+		p.lock.Lock()
+		if p.down {
+			p.lock.Unlock()
+			_ = conn.Close()
+			continue
+		}
+		srv, err := net.Dial("tcp", p.target)
+		if err != nil {
+			p.lock.Unlock()
+			_ = conn.Close()
+			continue
+		}
+		p.conns = append(p.conns, conn, srv)
+		p.lock.Unlock()
+		go func() {
+			_, _ = srv.(*net.TCPConn).ReadFrom(conn)
+			_ = srv.Close()
+		}()
+		go func() {
+			_, _ = conn.(*net.TCPConn).ReadFrom(srv)
+			_ = conn.Close()
+		}()
+	}
+}
+
+// setDown breaks all current connections and refuses new ones if down is true.
+func (p *tcpProxy) setDown(down bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.down = down
+	if down {
+		for _, conn := range p.conns {
+			_ = conn.Close()
+		}
+		p.conns = nil
+	}
+}
+
+func TestWSClient_Reconnect(t *testing.T) {
+	chain, _, httpSrv := initServerWithInMemoryChain(t)
+	proxy := newTCPProxy(t, httpSrv.Listener.Addr().String())
+
+	c, err := rpcclient.NewWS(context.Background(), "ws://"+proxy.ln.Addr().String()+"/ws", rpcclient.WSOptions{
+		Reconnect:         true,
+		ReconnectMinDelay: 10 * time.Millisecond,
+		ReconnectMaxDelay: 50 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	t.Cleanup(c.Close)
+	require.NoError(t, c.Init())
+
+	var (
+		blockCh = make(chan *block.Block, 10)
+		execCh  = make(chan *state.AppExecResult, 20)
+		txCh    = make(chan *transaction.Transaction, 10)
+		ntfCh   = make(chan *state.ContainedNotificationEvent, 20)
+	)
+	blockID, err := c.ReceiveBlocks(nil, blockCh)
+	require.NoError(t, err)
+	_, err = c.ReceiveExecutions(nil, execCh)
+	require.NoError(t, err)
+	_, err = c.ReceiveTransactions(nil, txCh)
+	require.NoError(t, err)
+	roleMgmt := nativehashes.RoleManagement
+	_, err = c.ReceiveExecutionNotifications(&neorpc.NotificationFilter{Contract: &roleMgmt}, ntfCh)
+	require.NoError(t, err)
+
+	// The transaction to be accepted while the client is disconnected.
+	hc, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+	require.NoError(t, err)
+	t.Cleanup(hc.Close)
+	require.NoError(t, hc.Init())
+	act, err := actor.New(hc, []actor.SignerAccount{{
+		Signer: transaction.Signer{
+			Account: testchain.CommitteeScriptHash(),
+			Scopes:  transaction.CalledByEntry,
+		},
+		Account: &wallet.Account{
+			Address: testchain.CommitteeAddress(),
+			Contract: &wallet.Contract{
+				Script: testchain.CommitteeVerificationScript(),
+			},
+		},
+	}})
+	require.NoError(t, err)
+	tx, err := rolemgmt.New(act).DesignateAsRoleUnsigned(noderoles.Oracle, keys.PublicKeys{testchain.PrivateKeyByID(0).PublicKey()})
+	require.NoError(t, err)
+	tx.Scripts[0].InvocationScript = testchain.SignCommittee(tx)
+
+	var blocks []*block.Block
+	addBlock := func(txs ...*transaction.Transaction) {
+		b := testchain.NewBlock(t, chain, 1, 0, txs...)
+		require.NoError(t, chain.AddBlock(b))
+		blocks = append(blocks, b)
+	}
+	addBlock()
+	require.Equal(t, blocks[0].Hash(), (<-blockCh).Hash())
+
+	proxy.setDown(true)
+	addBlock()
+	addBlock(tx)
+	addBlock()
+	proxy.setDown(false)
+	// It doesn't matter whether the client is reconnected by this moment.
+	addBlock()
+
+	for _, b := range blocks[1:] {
+		select {
+		case actual := <-blockCh:
+			require.Equal(t, b.Index, actual.Index)
+			require.Equal(t, b.Hash(), actual.Hash())
+		case <-time.After(5 * time.Second):
+			t.Fatalf("block %d is not received", b.Index)
+		}
+	}
+	var containers []util.Uint256
+	for _, b := range blocks {
+		containers = append(containers, b.Hash())
+		for _, tx := range b.Transactions {
+			containers = append(containers, tx.Hash())
+		}
+		containers = append(containers, b.Hash())
+	}
+	for i, h := range containers {
+		select {
+		case aer := <-execCh:
+			require.Equal(t, h, aer.Container, i)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("execution %d is not received", i)
+		}
+	}
+	select {
+	case actual := <-txCh:
+		require.Equal(t, tx.Hash(), actual.Hash())
+	case <-time.After(5 * time.Second):
+		t.Fatal("transaction is not received")
+	}
+	select {
+	case ntf := <-ntfCh:
+		require.Equal(t, tx.Hash(), ntf.Container)
+		require.Equal(t, "Designation", ntf.Name)
+	case <-time.After(5 * time.Second):
+		t.Fatal("notification is not received")
+	}
+	// No duplicates.
+	time.Sleep(100 * time.Millisecond)
+	require.Empty(t, blockCh)
+	require.Empty(t, execCh)
+	require.Empty(t, txCh)
+	require.Empty(t, ntfCh)
+
+	require.NoError(t, c.Unsubscribe(blockID))
+	require.NoError(t, c.UnsubscribeAll())
+	require.NoError(t, c.GetError())
+	require.NoError(t, c.Context().Err())
+}
+
+func TestWSClient_ReceiveEvents(t *testing.T) {
+	chain, _, httpSrv := initServerWithInMemoryChain(t)
+
+	c, err := rpcclient.NewWS(context.Background(), "ws"+strings.TrimPrefix(httpSrv.URL, "http")+"/ws", rpcclient.WSOptions{})
+	require.NoError(t, err)
+	t.Cleanup(c.Close)
+	require.NoError(t, c.Init())
+
+	type transfer struct {
+		To     util.Uint160
+		Amount *big.Int
+	}
+	var (
+		name = "Transfer"
+		gasH = nativehashes.GasToken
+		ch   = make(chan *transfer, 10)
+	)
+	// Mints and burns can't be converted and are skipped.
+	convert := func(ntf *state.ContainedNotificationEvent) (*transfer, error) {
+		arr := ntf.Item.Value().([]stackitem.Item)
+		if _, err := arr[0].TryBytes(); err != nil {
+			return nil, err
+		}
+		to, err := arr[1].TryBytes()
+		if err != nil {
+			return nil, err
+		}
+		res := &transfer{Amount: arr[2].Value().(*big.Int)}
+		res.To, err = util.Uint160DecodeBytesBE(to)
+		return res, err
+	}
+	_, err = rpcclient.ReceiveEvents[*transfer](c, &neorpc.NotificationFilter{Contract: &gasH, Name: &name}, nil, convert)
+	require.ErrorIs(t, err, rpcclient.ErrNilNotificationReceiver)
+	_, err = rpcclient.ReceiveEvents(c, &neorpc.NotificationFilter{Contract: &gasH, Name: &name}, ch, nil)
+	require.Error(t, err)
+	id, err := rpcclient.ReceiveEvents(c, &neorpc.NotificationFilter{Contract: &gasH, Name: &name}, ch, convert)
+	require.NoError(t, err)
+
+	act, err := actor.New(c, []actor.SignerAccount{{
+		Signer: transaction.Signer{
+			Account: testchain.CommitteeScriptHash(),
+			Scopes:  transaction.CalledByEntry,
+		},
+		Account: &wallet.Account{
+			Address: testchain.CommitteeAddress(),
+			Contract: &wallet.Contract{
+				Script: testchain.CommitteeVerificationScript(),
+			},
+		},
+	}})
+	require.NoError(t, err)
+	tx, err := gas.New(act).TransferUnsigned(testchain.CommitteeScriptHash(), util.Uint160{1, 2, 3}, big.NewInt(1), nil)
+	require.NoError(t, err)
+	tx.Scripts[0].InvocationScript = testchain.SignCommittee(tx)
+	require.NoError(t, chain.AddBlock(testchain.NewBlock(t, chain, 1, 0, tx)))
+
+	select {
+	case tr := <-ch:
+		require.Equal(t, util.Uint160{1, 2, 3}, tr.To)
+		require.Equal(t, big.NewInt(1), tr.Amount)
+	case <-time.After(5 * time.Second):
+		t.Fatal("event is not received")
+	}
+	time.Sleep(100 * time.Millisecond)
+	require.Empty(t, ch)
+	require.NoError(t, c.Unsubscribe(id))
+}
+
 // TestWSClient_SubscriptionsCompat is aimed to test both deprecated and relevant
 // subscriptions API with filtered and non-filtered subscriptions from the WSClient
 // user side.
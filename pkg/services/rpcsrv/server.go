@@ -13,6 +13,7 @@ import (
 	"math/big"
 	"net"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -24,6 +25,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/config/limits"
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/consensus"
 	"github.com/nspcc-dev/neo-go/pkg/core"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
@@ -44,6 +46,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/rpcevent"
 	"github.com/nspcc-dev/neo-go/pkg/network"
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/services/notary"
 	"github.com/nspcc-dev/neo-go/pkg/services/oracle/broadcaster"
 	"github.com/nspcc-dev/neo-go/pkg/services/rpcsrv/params"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
@@ -66,6 +69,7 @@ type (
 		BlockHeight() uint32
 		CalculateAttributesFee(tx *transaction.Transaction) int64
 		CalculateClaimable(h util.Uint160, endHeight uint32) (*big.Int, error)
+		ApplyPolicyToTxSet([]*transaction.Transaction) []*transaction.Transaction
 		CurrentBlockHash() util.Uint256
 		FeePerByte() int64
 		ForEachNEP11Transfer(acc util.Uint160, newestTimestamp uint64, f func(*state.NEP11Transfer) (bool, error)) error
@@ -125,6 +129,12 @@ type (
 		AddResponse(pub *keys.PublicKey, reqID uint64, txSig []byte)
 	}
 
+	// NotaryHandler is the interface notary service needs to provide for the Server.
+	NotaryHandler interface {
+		Requests() []notary.RequestStatus
+		Request(h util.Uint256) (notary.RequestStatus, bool)
+	}
+
 	// Server represents the JSON-RPC 2.0 server.
 	Server struct {
 		http  []*http.Server
@@ -139,6 +149,7 @@ type (
 		stateRootEnabled bool
 		coreServer       *network.Server
 		oracle           *atomic.Value
+		notary           *atomic.Value
 		log              *zap.Logger
 		shutdown         chan struct{}
 		started          atomic.Bool
@@ -147,6 +158,10 @@ type (
 		sessionsLock sync.Mutex
 		sessions     map[string]*session
 
+		// feeEstimateLock protects feeEstimateCache used by getfeeestimate.
+		feeEstimateLock  sync.Mutex
+		feeEstimateCache feeEstimateCache
+
 		subsLock    sync.RWMutex
 		subscribers map[*subscriber]bool
 
@@ -167,6 +182,36 @@ type (
 		subEventsToExitCh chan struct{}
 	}
 
+	// feeEstimateCache holds the memory pool and recent blocks data used by
+	// getfeeestimate, it's only valid for the given chain height.
+	feeEstimateCache struct {
+		height uint32
+		// valid is false if the cache is empty.
+		valid bool
+		// mempoolSize is the number of verified memory pool transactions.
+		mempoolSize int
+		// nextFees is a sorted list of fee-per-byte values of transactions
+		// competing for the next block, it's empty if all of them fit.
+		nextFees []int64
+		// blockFees contains fee-per-byte values of transactions for every
+		// analyzed block starting from the latest one, it's nil for blocks
+		// that are not full.
+		blockFees [][]int64
+	}
+
+	// feeEstimateData is the data getfeeestimate result is calculated from.
+	feeEstimateData struct {
+		mempoolSize int
+		// nextFees is a sorted list of fee-per-byte values of transactions
+		// competing for the next block.
+		nextFees []int64
+		// analyzed is the number of blocks actually analyzed.
+		analyzed int
+		// recentFees is a sorted list of fee-per-byte values of transactions
+		// from the analyzed full blocks.
+		recentFees []int64
+	}
+
 	// session holds a set of iterators got after invoke* call with corresponding
 	// finalizer and session expiration timer.
 	session struct {
@@ -205,6 +250,14 @@ const (
 
 	// defaultSessionPoolSize is the number of concurrently running iterator sessions.
 	defaultSessionPoolSize = 20
+
+	// defaultFeeEstimateBlocks is the default number of recent blocks analyzed
+	// by getfeeestimate.
+	defaultFeeEstimateBlocks = 10
+
+	// maxFeeEstimateBlocks is the maximum number of recent blocks analyzed by
+	// getfeeestimate.
+	maxFeeEstimateBlocks = 100
 )
 
 var rpcHandlers = map[string]func(*Server, params.Params) (any, *neorpc.Error){
@@ -223,7 +276,9 @@ var rpcHandlers = map[string]func(*Server, params.Params) (any, *neorpc.Error){
 	"getcandidates":                (*Server).getCandidates,
 	"getcommittee":                 (*Server).getCommittee,
 	"getconnectioncount":           (*Server).getConnectionCount,
+	"getconsensustimeline":         (*Server).getConsensusTimeline,
 	"getcontractstate":             (*Server).getContractState,
+	"getfeeestimate":               (*Server).getFeeEstimate,
 	"getnativecontracts":           (*Server).getNativeContracts,
 	"getnep11balances":             (*Server).getNEP11Balances,
 	"getnep11properties":           (*Server).getNEP11Properties,
@@ -233,6 +288,8 @@ var rpcHandlers = map[string]func(*Server, params.Params) (any, *neorpc.Error){
 	"getpeers":                     (*Server).getPeers,
 	"getproof":                     (*Server).getProof,
 	"getrawmempool":                (*Server).getRawMempool,
+	"getnotaryrequest":             (*Server).getNotaryRequest,
+	"getnotaryrequests":            (*Server).getNotaryRequests,
 	"getrawnotarypool":             (*Server).getRawNotaryPool,
 	"getrawnotarytransaction":      (*Server).getRawNotaryTransaction,
 	"getrawtransaction":            (*Server).getrawtransaction,
@@ -352,6 +409,7 @@ func New(chain Ledger, conf config.RPC, coreServer *network.Server,
 		coreServer:       coreServer,
 		log:              log,
 		oracle:           oracleWrapped,
+		notary:           new(atomic.Value),
 		shutdown:         make(chan struct{}),
 		errChan:          errChan,
 
@@ -489,6 +547,12 @@ func (s *Server) SetOracleHandler(orc OracleHandler) {
 	s.oracle.Store(orc)
 }
 
+// SetNotaryHandler allows to update notary handler used by the Server, nil
+// disables notary request inspection calls.
+func (s *Server) SetNotaryHandler(n NotaryHandler) {
+	s.notary.Store(&n)
+}
+
 func (s *Server) handleHTTPRequest(w http.ResponseWriter, httpRequest *http.Request) {
 	// Restrict request body before further processing.
 	httpRequest.Body = http.MaxBytesReader(w, httpRequest.Body, int64(s.config.MaxRequestBodyBytes))
@@ -784,6 +848,42 @@ func (s *Server) getConnectionCount(_ params.Params) (any, *neorpc.Error) {
 	return s.coreServer.PeerCount(), nil
 }
 
+// getConsensusTimeline returns consensus messages of the recent rounds, it's
+// only available on nodes running consensus service.
+func (s *Server) getConsensusTimeline(_ params.Params) (any, *neorpc.Error) {
+	var svc = s.coreServer.GetService(consensus.ServiceName)
+	tr, ok := svc.(consensus.TimelineReporter)
+	if !ok {
+		return nil, neorpc.NewInternalServerError("consensus timeline is not available")
+	}
+	var rounds = tr.Timeline()
+	res := make([]result.RoundTimeline, 0, len(rounds))
+	for _, r := range rounds {
+		rt := result.RoundTimeline{
+			Height:   r.Height,
+			Start:    r.Start,
+			View:     r.View,
+			Messages: make([]result.TimelineMessage, 0, len(r.Messages)),
+		}
+		if !r.End.IsZero() {
+			end := r.End
+			rt.End = &end
+		}
+		for _, m := range r.Messages {
+			rt.Messages = append(rt.Messages, result.TimelineMessage{
+				Time:      m.Time,
+				Type:      m.Type.String(),
+				Height:    m.Height,
+				View:      m.View,
+				Validator: m.Validator,
+				Sent:      m.Sent,
+			})
+		}
+		res = append(res, rt)
+	}
+	return res, nil
+}
+
 func (s *Server) blockHashFromParam(param *params.Param) (util.Uint256, *neorpc.Error) {
 	var (
 		hash util.Uint256
@@ -917,6 +1017,122 @@ func (s *Server) getRawMempool(reqParams params.Params) (any, *neorpc.Error) {
 	}, nil
 }
 
+// getFeeEstimate suggests fee-per-byte values for different priorities based
+// on the next block projected from the memory pool and recent full blocks.
+func (s *Server) getFeeEstimate(reqParams params.Params) (any, *neorpc.Error) {
+	var blocks = defaultFeeEstimateBlocks
+	if len(reqParams) > 0 {
+		n, err := reqParams[0].GetInt()
+		if err != nil || n <= 0 || n > maxFeeEstimateBlocks {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("number of blocks should be in [1, %d] range", maxFeeEstimateBlocks))
+		}
+		blocks = n
+	}
+
+	var (
+		data = s.getFeeEstimateData(blocks)
+		res  = result.FeeEstimate{
+			FeePerByte:  s.chain.FeePerByte(),
+			MempoolSize: data.mempoolSize,
+			Blocks:      data.analyzed,
+		}
+	)
+	res.Low = suggestFeePerByte(res.FeePerByte, data.nextFees, data.recentFees, 0)
+	res.Medium = suggestFeePerByte(res.FeePerByte, data.nextFees, data.recentFees, 50)
+	res.High = suggestFeePerByte(res.FeePerByte, data.nextFees, data.recentFees, 90)
+	return res, nil
+}
+
+// getFeeEstimateData returns memory pool fee data and fee-per-byte values of
+// transactions from the given number of recent full blocks. The memory pool
+// and every block are only analyzed once per chain height, subsequent calls
+// (with any number of blocks) use the cached data.
+func (s *Server) getFeeEstimateData(blocks int) feeEstimateData {
+	var (
+		cfg    = s.chain.GetConfig()
+		height = s.chain.BlockHeight()
+		c      = &s.feeEstimateCache
+	)
+	s.feeEstimateLock.Lock()
+	defer s.feeEstimateLock.Unlock()
+	if !c.valid || c.height != height {
+		var (
+			mpTxes = s.chain.GetMemPool().GetVerifiedTransactions()
+			next   = s.chain.ApplyPolicyToTxSet(mpTxes)
+		)
+		*c = feeEstimateCache{height: height, valid: true, mempoolSize: len(mpTxes)}
+		// Pool is ordered by priority, so if there are transactions that
+		// don't fit into the next block they're outbid by the ones that do.
+		if len(next) < len(mpTxes) {
+			c.nextFees = feesPerByte(next)
+			sort.Slice(c.nextFees, func(i, j int) bool { return c.nextFees[i] < c.nextFees[j] })
+		}
+	}
+	for len(c.blockFees) < blocks && uint32(len(c.blockFees)) <= height {
+		b, err := s.chain.GetBlock(s.chain.GetHeaderHash(height - uint32(len(c.blockFees))))
+		if err != nil {
+			break
+		}
+		var fees []int64
+		if isFullBlock(b, cfg.ProtocolConfiguration) {
+			fees = feesPerByte(b.Transactions)
+		}
+		c.blockFees = append(c.blockFees, fees)
+	}
+	var res = feeEstimateData{
+		mempoolSize: c.mempoolSize,
+		nextFees:    c.nextFees,
+		analyzed:    len(c.blockFees),
+	}
+	if res.analyzed > blocks {
+		res.analyzed = blocks
+	}
+	for _, fees := range c.blockFees[:res.analyzed] {
+		res.recentFees = append(res.recentFees, fees...)
+	}
+	sort.Slice(res.recentFees, func(i, j int) bool { return res.recentFees[i] < res.recentFees[j] })
+	return res
+}
+
+// feesPerByte returns fee-per-byte values of the given transactions except
+// high-priority ones (they're not competing by fee).
+func feesPerByte(txes []*transaction.Transaction) []int64 {
+	var res = make([]int64, 0, len(txes))
+	for _, tx := range txes {
+		if !tx.HasAttribute(transaction.HighPriority) {
+			res = append(res, tx.FeePerByte())
+		}
+	}
+	return res
+}
+
+// isFullBlock checks whether the block is (almost) full, so transactions
+// included into it had to compete for space.
+func isFullBlock(b *block.Block, cfg config.ProtocolConfiguration) bool {
+	var sysFee int64
+	for _, tx := range b.Transactions {
+		sysFee += tx.SystemFee
+	}
+	return len(b.Transactions) >= int(cfg.MaxTransactionsPerBlock) ||
+		uint64(b.GetExpectedBlockSize())*10 > uint64(cfg.MaxBlockSize)*9 ||
+		sysFee*10 > cfg.MaxBlockSystemFee*9
+}
+
+// suggestFeePerByte returns the fee-per-byte value that outbids the given
+// percentile of both sorted fee sets, it's never less than the minimum one.
+func suggestFeePerByte(minimum int64, nextFees []int64, recentFees []int64, percentile int) int64 {
+	var res = minimum
+	for _, fees := range [][]int64{nextFees, recentFees} {
+		if len(fees) == 0 {
+			continue
+		}
+		if v := fees[(len(fees)-1)*percentile/100] + 1; v > res {
+			res = v
+		}
+	}
+	return res
+}
+
 func (s *Server) validateAddress(reqParams params.Params) (any, *neorpc.Error) {
 	param, err := reqParams.Value(0).GetString()
 	if err != nil {
@@ -3169,6 +3385,88 @@ func (s *Server) getRawNotaryPool(_ params.Params) (any, *neorpc.Error) {
 	return res, nil
 }
 
+// getNotaryHandler returns the notary handler if notary service is running.
+func (s *Server) getNotaryHandler() (NotaryHandler, *neorpc.Error) {
+	if !s.chain.P2PSigExtensionsEnabled() {
+		return nil, neorpc.NewInternalServerError("P2PSignatureExtensions are disabled")
+	}
+	n, _ := s.notary.Load().(*NotaryHandler)
+	if n == nil || *n == nil {
+		return nil, neorpc.ErrNotaryDisabled
+	}
+	return *n, nil
+}
+
+func (s *Server) getNotaryRequests(_ params.Params) (any, *neorpc.Error) {
+	n, respErr := s.getNotaryHandler()
+	if respErr != nil {
+		return nil, respErr
+	}
+	reqs := n.Requests()
+	res := make([]result.NotaryRequest, len(reqs))
+	for i := range reqs {
+		res[i] = notaryRequestToResult(reqs[i])
+	}
+	return res, nil
+}
+
+func (s *Server) getNotaryRequest(reqParams params.Params) (any, *neorpc.Error) {
+	n, respErr := s.getNotaryHandler()
+	if respErr != nil {
+		return nil, respErr
+	}
+	h, err := reqParams.Value(0).GetUint256()
+	if err != nil {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid main transaction hash: %s", err))
+	}
+	req, ok := n.Request(h)
+	if !ok {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrUnknownTransaction, "notary request is not found")
+	}
+	return notaryRequestToResult(req), nil
+}
+
+func notaryRequestToResult(req notary.RequestStatus) result.NotaryRequest {
+	res := result.NotaryRequest{
+		Hash:           req.Hash,
+		Valid:          req.Valid,
+		Sent:           req.Sent,
+		NotValidBefore: req.NotValidBefore,
+		Signers:        make([]result.NotarySigner, len(req.Witnesses)),
+		Fallbacks:      notaryFallbacksToResult(req.Fallbacks),
+		SentFallbacks:  notaryFallbacksToResult(req.SentFallbacks),
+	}
+	for i, w := range req.Witnesses {
+		res.SignaturesLeft += w.SigsLeft
+		res.Signers[i] = result.NotarySigner{
+			Account:        w.Account,
+			Type:           w.Type.String(),
+			SignaturesLeft: w.SigsLeft,
+			Keys:           w.Keys,
+			Signed:         w.Signed,
+		}
+		if res.Signers[i].Keys == nil {
+			res.Signers[i].Keys = keys.PublicKeys{}
+		}
+		if res.Signers[i].Signed == nil {
+			res.Signers[i].Signed = keys.PublicKeys{}
+		}
+	}
+	return res
+}
+
+func notaryFallbacksToResult(fbs []notary.FallbackStatus) []result.NotaryFallback {
+	res := make([]result.NotaryFallback, len(fbs))
+	for i, fb := range fbs {
+		res[i] = result.NotaryFallback{
+			Hash:            fb.Hash,
+			NotValidBefore:  fb.NotValidBefore,
+			ValidUntilBlock: fb.ValidUntilBlock,
+		}
+	}
+	return res
+}
+
 func (s *Server) getRawNotaryTransaction(reqParams params.Params) (any, *neorpc.Error) {
 	if !s.chain.P2PSigExtensionsEnabled() {
 		return nil, neorpc.NewInternalServerError("P2PSignatureExtensions are disabled")
@@ -23,10 +23,12 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
+	"github.com/nspcc-dev/dbft"
 	"github.com/nspcc-dev/neo-go/internal/random"
 	"github.com/nspcc-dev/neo-go/internal/testchain"
 	"github.com/nspcc-dev/neo-go/internal/testserdes"
 	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/consensus"
 	"github.com/nspcc-dev/neo-go/pkg/core"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/fee"
@@ -43,6 +45,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 	"github.com/nspcc-dev/neo-go/pkg/network"
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/services/notary"
 	rpc2 "github.com/nspcc-dev/neo-go/pkg/services/oracle/broadcaster"
 	"github.com/nspcc-dev/neo-go/pkg/services/rpcsrv/params"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
@@ -1136,6 +1139,52 @@ var rpcTestCases = map[string][]rpcTestCase{
 			},
 		},
 	},
+	"getfeeestimate": {
+		{
+			name:   "positive",
+			params: "[]",
+			result: func(e *executor) any { return &result.FeeEstimate{} },
+			check: func(t *testing.T, e *executor, res any) {
+				est, ok := res.(*result.FeeEstimate)
+				require.True(t, ok)
+				// No competition for block space in the test chain.
+				fpb := e.chain.FeePerByte()
+				require.Equal(t, fpb, est.FeePerByte)
+				require.Equal(t, fpb, est.Low)
+				require.Equal(t, fpb, est.Medium)
+				require.Equal(t, fpb, est.High)
+				require.Equal(t, 10, est.Blocks)
+			},
+		},
+		{
+			name:   "positive, blocks",
+			params: "[3]",
+			result: func(e *executor) any { return &result.FeeEstimate{} },
+			check: func(t *testing.T, e *executor, res any) {
+				est, ok := res.(*result.FeeEstimate)
+				require.True(t, ok)
+				require.Equal(t, 3, est.Blocks)
+			},
+		},
+		{
+			name:    "zero blocks",
+			params:  `[0]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "too many blocks",
+			params:  `[101]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "string blocks",
+			params:  `["ten"]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+	},
 	"getblocksysfee": {
 		{
 			name:   "positive",
@@ -1188,6 +1237,14 @@ var rpcTestCases = map[string][]rpcTestCase{
 			},
 		},
 	},
+	"getconsensustimeline": {
+		{
+			name:    "no consensus",
+			params:  "[]",
+			fail:    true,
+			errCode: neorpc.InternalServerErrorCode,
+		},
+	},
 	"getnativecontracts": {
 		{
 			params: "[]",
@@ -2278,6 +2335,113 @@ func TestSubmitOracle(t *testing.T) {
 	t.Run("Valid", runCase(t, false, 0, pubStr, `1`, txSigStr, msgSigStr))
 }
 
+type notaryHandlerStub []notary.RequestStatus
+
+func (n notaryHandlerStub) Requests() []notary.RequestStatus {
+	return n
+}
+
+func (n notaryHandlerStub) Request(h util.Uint256) (notary.RequestStatus, bool) {
+	for _, r := range n {
+		if r.Hash == h {
+			return r, true
+		}
+	}
+	return notary.RequestStatus{}, false
+}
+
+func TestNotaryRequestInspection(t *testing.T) {
+	rpcRequests := `{"jsonrpc": "2.0", "id": 1, "method": "getnotaryrequests", "params": []}`
+	rpcRequest := `{"jsonrpc": "2.0", "id": 1, "method": "getnotaryrequest", "params": [%s]}`
+
+	t.Run("disabled P2PSigExtensions", func(t *testing.T) {
+		_, _, httpSrv := initClearServerWithCustomConfig(t, func(c *config.Config) {
+			c.ProtocolConfiguration.P2PSigExtensions = false
+		})
+		body := doRPCCallOverHTTP(rpcRequests, httpSrv.URL, t)
+		checkErrGetResult(t, body, true, neorpc.InternalServerErrorCode)
+	})
+
+	_, rpcSrv, httpSrv := initClearServerWithServices(t, false, true, false)
+	t.Run("disabled service", func(t *testing.T) {
+		body := doRPCCallOverHTTP(rpcRequests, httpSrv.URL, t)
+		checkErrGetResult(t, body, true, neorpc.ErrNotaryDisabledCode)
+		body = doRPCCallOverHTTP(fmt.Sprintf(rpcRequest, `"`+util.Uint256{}.StringLE()+`"`), httpSrv.URL, t)
+		checkErrGetResult(t, body, true, neorpc.ErrNotaryDisabledCode)
+	})
+
+	pub := testchain.PrivateKeyByID(0).PublicKey()
+	status := notary.RequestStatus{
+		Hash:           util.Uint256{1, 2, 3},
+		Valid:          true,
+		NotValidBefore: 10,
+		Witnesses: []notary.WitnessStatus{{
+			Account:  pub.GetScriptHash(),
+			Type:     notary.Signature,
+			SigsLeft: 1,
+			Keys:     keys.PublicKeys{pub},
+		}, {
+			Account: util.Uint160{4, 5, 6},
+			Type:    notary.Contract,
+		}},
+		Fallbacks: []notary.FallbackStatus{{
+			Hash:            util.Uint256{7, 8, 9},
+			NotValidBefore:  10,
+			ValidUntilBlock: 20,
+		}},
+	}
+	expected := result.NotaryRequest{
+		Hash:           status.Hash,
+		Valid:          true,
+		NotValidBefore: 10,
+		SignaturesLeft: 1,
+		Signers: []result.NotarySigner{{
+			Account:        pub.GetScriptHash(),
+			Type:           "signature",
+			SignaturesLeft: 1,
+			Keys:           keys.PublicKeys{pub},
+			Signed:         keys.PublicKeys{},
+		}, {
+			Account: util.Uint160{4, 5, 6},
+			Type:    "contract",
+			Keys:    keys.PublicKeys{},
+			Signed:  keys.PublicKeys{},
+		}},
+		Fallbacks: []result.NotaryFallback{{
+			Hash:            util.Uint256{7, 8, 9},
+			NotValidBefore:  10,
+			ValidUntilBlock: 20,
+		}},
+		SentFallbacks: []result.NotaryFallback{},
+	}
+	rpcSrv.SetNotaryHandler(notaryHandlerStub{status})
+
+	t.Run("getnotaryrequests", func(t *testing.T) {
+		body := doRPCCallOverHTTP(rpcRequests, httpSrv.URL, t)
+		res := checkErrGetResult(t, body, false, 0)
+		var actual []result.NotaryRequest
+		require.NoError(t, json.Unmarshal(res, &actual))
+		require.Equal(t, []result.NotaryRequest{expected}, actual)
+	})
+	t.Run("getnotaryrequest", func(t *testing.T) {
+		body := doRPCCallOverHTTP(fmt.Sprintf(rpcRequest, `"`+status.Hash.StringLE()+`"`), httpSrv.URL, t)
+		res := checkErrGetResult(t, body, false, 0)
+		var actual result.NotaryRequest
+		require.NoError(t, json.Unmarshal(res, &actual))
+		require.Equal(t, expected, actual)
+
+		body = doRPCCallOverHTTP(fmt.Sprintf(rpcRequest, `"`+util.Uint256{}.StringLE()+`"`), httpSrv.URL, t)
+		checkErrGetResult(t, body, true, neorpc.ErrUnknownTransactionCode)
+		body = doRPCCallOverHTTP(fmt.Sprintf(rpcRequest, `"abc"`), httpSrv.URL, t)
+		checkErrGetResult(t, body, true, neorpc.InvalidParamsCode)
+	})
+	t.Run("disabled again", func(t *testing.T) {
+		rpcSrv.SetNotaryHandler(nil)
+		body := doRPCCallOverHTTP(rpcRequests, httpSrv.URL, t)
+		checkErrGetResult(t, body, true, neorpc.ErrNotaryDisabledCode)
+	})
+}
+
 func TestNotaryRequestRPC(t *testing.T) {
 	var notaryRequest1, notaryRequest2 *payload.P2PNotaryRequest
 	rpcSubmit := `{"jsonrpc": "2.0", "id": 1, "method": "submitnotaryrequest", "params": %s}`
@@ -4200,3 +4364,94 @@ func TestErrorResponseContentType(t *testing.T) {
 	contentType := resp.Header.Get("Content-Type")
 	require.Equal(t, expectedContentType, contentType)
 }
+
+func TestSuggestFeePerByte(t *testing.T) {
+	var (
+		next   = []int64{1000, 2000, 3000, 4000, 5000}
+		recent = []int64{1000, 1500, 6000}
+	)
+	require.Equal(t, int64(1000), suggestFeePerByte(1000, nil, nil, 50))
+	require.Equal(t, int64(1001), suggestFeePerByte(1000, next, nil, 0))
+	require.Equal(t, int64(3001), suggestFeePerByte(1000, next, nil, 50))
+	require.Equal(t, int64(4001), suggestFeePerByte(1000, next, nil, 90))
+	require.Equal(t, int64(3001), suggestFeePerByte(1000, next, recent, 50))
+	require.Equal(t, int64(1501), suggestFeePerByte(1000, nil, recent, 90))
+	require.Equal(t, int64(7000), suggestFeePerByte(7000, next, recent, 90))
+}
+
+func TestGetFeeEstimateDataCache(t *testing.T) {
+	chain, rpcSrv, _ := initClearServerWithInMemoryChain(t)
+	for _, b := range getTestBlocks(t)[:3] {
+		require.NoError(t, chain.AddBlock(b))
+	}
+	res := rpcSrv.getFeeEstimateData(2)
+	require.Equal(t, chain.BlockHeight(), rpcSrv.feeEstimateCache.height)
+	require.Equal(t, 2, res.analyzed)
+
+	// Memory pool and blocks are not analyzed again for the same height.
+	rpcSrv.feeEstimateCache.nextFees = []int64{1}
+	rpcSrv.feeEstimateCache.blockFees[0] = []int64{42}
+	res = rpcSrv.getFeeEstimateData(2)
+	require.Equal(t, []int64{1}, res.nextFees)
+	require.Equal(t, []int64{42}, res.recentFees)
+	// Even for another number of blocks, only missing ones are analyzed.
+	res = rpcSrv.getFeeEstimateData(10)
+	require.Equal(t, []int64{42}, res.recentFees)
+	require.Equal(t, 4, res.analyzed)
+	res = rpcSrv.getFeeEstimateData(1)
+	require.Equal(t, []int64{42}, res.recentFees)
+	require.Equal(t, 1, res.analyzed)
+
+	require.NoError(t, chain.AddBlock(getTestBlocks(t)[3]))
+	res = rpcSrv.getFeeEstimateData(10)
+	require.Empty(t, res.nextFees)
+	require.Empty(t, res.recentFees)
+	require.Equal(t, chain.BlockHeight(), rpcSrv.feeEstimateCache.height)
+	require.Equal(t, 5, res.analyzed)
+}
+
+type fakeTimelineService struct {
+	rounds []consensus.RoundTimeline
+}
+
+func (f *fakeTimelineService) Name() string                        { return consensus.ServiceName }
+func (f *fakeTimelineService) Start()                              {}
+func (f *fakeTimelineService) Shutdown()                           {}
+func (f *fakeTimelineService) Timeline() []consensus.RoundTimeline { return f.rounds }
+
+func TestGetConsensusTimeline(t *testing.T) {
+	_, rpcSrv, httpSrv := initClearServerWithInMemoryChain(t)
+	start := time.Unix(1700000000, 0).UTC()
+	rpcSrv.coreServer.AddService(&fakeTimelineService{rounds: []consensus.RoundTimeline{
+		{
+			Height: 5,
+			Start:  start,
+			End:    start.Add(time.Second),
+			View:   1,
+			Messages: []consensus.TimelineMessage{
+				{Time: start.Add(100 * time.Millisecond), Type: dbft.ChangeViewType, Height: 5, Validator: 2},
+				{Time: start.Add(500 * time.Millisecond), Type: dbft.PrepareRequestType, Height: 5, View: 1, Validator: 3, Sent: true},
+			},
+		},
+		{Height: 6, Start: start.Add(time.Second)},
+	}})
+
+	body := doRPCCallOverHTTP(`{"jsonrpc": "2.0", "id": 1, "method": "getconsensustimeline", "params": []}`, httpSrv.URL, t)
+	res := checkErrGetResult(t, body, false, 0)
+	var actual []result.RoundTimeline
+	require.NoError(t, json.Unmarshal(res, &actual))
+	end := start.Add(time.Second)
+	require.Equal(t, []result.RoundTimeline{
+		{
+			Height: 5,
+			Start:  start,
+			End:    &end,
+			View:   1,
+			Messages: []result.TimelineMessage{
+				{Time: start.Add(100 * time.Millisecond), Type: "ChangeView", Height: 5, Validator: 2},
+				{Time: start.Add(500 * time.Millisecond), Type: "PrepareRequest", Height: 5, View: 1, Validator: 3, Sent: true},
+			},
+		},
+		{Height: 6, Start: start.Add(time.Second), Messages: []result.TimelineMessage{}},
+	}, actual)
+}
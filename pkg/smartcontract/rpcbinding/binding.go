@@ -327,6 +327,23 @@ func (e *{{$e.Name}}) FromStackItem(item *stackitem.Array) error {
 {{- end}}
 	return nil
 }
+
+// Subscribe{{$e.SubscriptionName}} subscribes to "{{$e.ManifestName}}" events emitted by the
+// contract{{if not (len $.Hash)}} with the given hash{{end}} using the given [rpcclient.WSClient].
+// Decoded events are sent to the provided channel, events that can't be
+// decoded are skipped. It returns subscription ID that can be used to
+// unsubscribe, see [rpcclient.WSClient] documentation for receiver channel
+// handling details.
+func Subscribe{{$e.SubscriptionName}}(ws *rpcclient.WSClient{{- if not (len $.Hash) -}}, hash util.Uint160{{- end -}}, ch chan<- *{{$e.Name}}) (string, error) {
+	{{if len $.Hash -}}
+	var hash = Hash
+	{{end -}}
+	var name = "{{$e.ManifestName}}"
+	return rpcclient.ReceiveEvents(ws, &neorpc.NotificationFilter{Contract: &hash, Name: &name}, ch, func(ntf *state.ContainedNotificationEvent) (*{{$e.Name}}, error) {
+		var e = new({{$e.Name}})
+		return e, e.FromStackItem(ntf.Item)
+	})
+}
 {{end -}}`
 
 	srcTmpl = bindingDefinition +
@@ -367,7 +384,10 @@ type (
 		// ManifestName is the event's name declared in the contract manifest.
 		// It may contain any UTF8 character.
 		ManifestName string
-		Parameters   []EventParamTmpl
+		// SubscriptionName is the event's name used in the subscription
+		// function name, it's the Name without "Event" suffix.
+		SubscriptionName string
+		Parameters       []EventParamTmpl
 	}
 
 	EventParamTmpl struct {
@@ -733,8 +753,9 @@ func scTemplateToRPC(cfg binding.Config, ctr ContractTmpl, imports map[string]st
 	for _, abiEvent := range cfg.Manifest.ABI.Events {
 		eBindingName := ToEventBindingName(abiEvent.Name)
 		eTmp := CustomEventTemplate{
-			Name:         eBindingName,
-			ManifestName: abiEvent.Name,
+			Name:             eBindingName,
+			ManifestName:     abiEvent.Name,
+			SubscriptionName: strings.TrimSuffix(eBindingName, "Event"),
 		}
 		for i := range abiEvent.Parameters {
 			pBindingName := ToParameterBindingName(abiEvent.Parameters[i].Name)
@@ -770,6 +791,9 @@ func scTemplateToRPC(cfg binding.Config, ctr ContractTmpl, imports map[string]st
 		imports["github.com/nspcc-dev/neo-go/pkg/vm/stackitem"] = struct{}{}
 		imports["fmt"] = struct{}{}
 		imports["errors"] = struct{}{}
+		imports["github.com/nspcc-dev/neo-go/pkg/core/state"] = struct{}{}
+		imports["github.com/nspcc-dev/neo-go/pkg/neorpc"] = struct{}{}
+		imports["github.com/nspcc-dev/neo-go/pkg/rpcclient"] = struct{}{}
 	}
 
 	for i := range ctr.SafeMethods {
@@ -0,0 +1,736 @@
+/*
+Package tsbinding implements TypeScript RPC bindings generator.
+
+Generated bindings are self-contained (they don't depend on any particular
+TypeScript library) and target a small JSON-RPC invocation interface that is
+defined in the generated file itself:
+
+	interface Invoker {
+	  invokeFunction(contract: string, operation: string, params: ContractParam[]): Promise<InvokeResult>;
+	}
+
+	interface Actor extends Invoker {
+	  sendCall(contract: string, operation: string, params: ContractParam[]): Promise<string>;
+	}
+
+invokeFunction is expected to perform an "invokefunction" RPC call with the
+given parameters (and whatever signers the implementation deems necessary)
+returning the JSON result as is. sendCall is expected to create a transaction
+invoking the given method, sign and send it to the network, returning the
+transaction hash. ContractParam and InvokeResult are the JSON structures used
+by the "invokefunction" RPC method (parameters and result).
+
+Hashes (Hash160 and Hash256) are represented as "0x"-prefixed hex strings in
+the same byte order RPC server uses for them (little-endian), public keys are
+hex-encoded compressed keys, byte arrays are Uint8Array and integers are
+bigint. Structures and events are described by the same binding.Config that
+is used for Go bindings (extended and named types). Type overrides are Go
+specific, so they're only used for well-known interop types (hashes, public
+keys and signatures) and arrays of them, anything else is ignored.
+*/
+package tsbinding
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/binding"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/rpcbinding"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+const srcTmpl = `// Code generated by neo-go contract generate-rpcwrapper --lang ts --manifest <file.json> --out <file.ts> [--hash <hash>] [--config <config>]; DO NOT EDIT.
+
+/**
+ * RPC wrappers for {{.ContractName}} contract.
+ *
+ * @module
+ */
+
+/** StackItem is a JSON representation of a NeoVM stack item. */
+export interface StackItem {
+  type: string;
+  value?: any;
+  interface?: string;
+  id?: string;
+}
+
+/** ContractParam is a JSON representation of a contract invocation parameter. */
+export interface ContractParam {
+  type: string;
+  value?: any;
+}
+
+/** InvokeResult is a JSON result of the invokefunction RPC call. */
+export interface InvokeResult {
+  state: string;
+  exception?: string | null;
+  gasconsumed: string;
+  stack: StackItem[];
+  session?: string;
+}
+{{- if .HasIterator}}
+
+/**
+ * IteratorRef identifies an iterator returned from a method, its items can be
+ * retrieved with the traverseiterator RPC call (the session must be terminated
+ * with the terminatesession RPC call after that).
+ */
+export interface IteratorRef {
+  session: string;
+  id: string;
+}
+{{- end}}
+{{- if .Events}}
+
+/** Notification is a JSON representation of a notification emitted by a contract. */
+export interface Notification {
+  contract: string;
+  eventname: string;
+  state: StackItem;
+}
+
+/** Execution is a JSON representation of a single execution in the application log. */
+export interface Execution {
+  trigger: string;
+  vmstate: string;
+  notifications: Notification[];
+}
+
+/** ApplicationLog is a JSON result of the getapplicationlog RPC call. */
+export interface ApplicationLog {
+  executions: Execution[];
+}
+{{- end}}
+
+/**
+ * Invoker is used by ContractReader to call safe methods, invokeFunction is
+ * expected to perform invokefunction RPC call and return its result.
+ */
+export interface Invoker {
+  invokeFunction(contract: string, operation: string, params: ContractParam[]): Promise<InvokeResult>;
+}
+{{- if .Methods}}
+
+/**
+ * Actor is used by Contract to call state-changing methods, sendCall is
+ * expected to create, sign and send a transaction invoking the given method
+ * and return its hash.
+ */
+export interface Actor extends Invoker {
+  sendCall(contract: string, operation: string, params: ContractParam[]): Promise<string>;
+}
+{{- end}}
+{{- if .Hash}}
+
+/** Hash contains contract hash. */
+export const Hash = {{quote .Hash}};
+{{- end}}
+{{- range $t := .NamedTypes}}
+
+/** {{$t.Name}} is a contract-specific {{$t.ManifestName}} type used by its methods. */
+export interface {{$t.Name}} {
+{{- range $t.Fields}}
+  {{.Name}}: {{.Type}};
+{{- end}}
+}
+{{- end}}
+{{- range $e := .Events}}
+
+/** {{$e.Name}} represents {{quote $e.ManifestName}} event emitted by the contract. */
+export interface {{$e.Name}} {
+{{- range $e.Fields}}
+  {{.Name}}: {{.Type}};
+{{- end}}
+}
+{{- end}}
+
+/** ContractReader implements safe contract methods. */
+export class ContractReader {
+  protected readonly invoker: Invoker;
+  /** hash is the contract hash. */
+  readonly hash: string;
+
+  /** Creates an instance of ContractReader using {{if .Hash}}Hash{{else}}provided contract hash{{end}} and the given Invoker. */
+  constructor(invoker: Invoker, hash: string{{if .Hash}} = Hash{{end}}) {
+    this.invoker = invoker;
+    this.hash = hash;
+  }
+{{- range $m := .SafeMethods}}
+
+  /** {{$m.Name}} invokes {{backquote $m.NameABI}} method of the contract. */
+  async {{$m.Name}}({{template "ARGS" $m}}): Promise<{{$m.ReturnType}}> {
+    const res = await this.invoker.invokeFunction(this.hash, {{quote $m.NameABI}}, [{{template "PARAMS" $m}}]);
+    {{if $m.Decoder}}return {{$m.Decoder}};{{else}}checkHalt(res);{{end}}
+  }
+{{- end}}
+}
+{{- if .Methods}}
+
+/** Contract implements all contract methods. */
+export class Contract extends ContractReader {
+  protected readonly actor: Actor;
+
+  /** Creates an instance of Contract using {{if .Hash}}Hash{{else}}provided contract hash{{end}} and the given Actor. */
+  constructor(actor: Actor, hash: string{{if .Hash}} = Hash{{end}}) {
+    super(actor, hash);
+    this.actor = actor;
+  }
+{{- range $m := .Methods}}
+
+  /**
+   * {{$m.Name}} creates and sends a transaction invoking {{backquote $m.NameABI}} method of
+   * the contract, it returns the transaction hash.
+   */
+  async {{$m.Name}}({{template "ARGS" $m}}): Promise<string> {
+    return this.actor.sendCall(this.hash, {{quote $m.NameABI}}, [{{template "PARAMS" $m}}]);
+  }
+{{- end}}
+}
+{{- end}}
+{{- range $t := .NamedTypes}}
+
+/** {{$t.FuncName}}FromStackItem converts the given stack item to {{$t.Name}}. */
+export function {{$t.FuncName}}FromStackItem(item: StackItem): {{$t.Name}} {
+{{- template "FROMITEM" $t}}
+}
+{{- if $t.ToParam}}
+
+function {{$t.FuncName}}ToParam(v: {{$t.Name}}): ContractParam {
+  return arrayParam([{{range $i, $f := $t.Fields}}{{if $i}}, {{end}}{{$f.Encoder}}{{end}}]);
+}
+{{- end}}
+{{- end}}
+{{- range $e := .Events}}
+
+/** {{$e.FuncName}}FromStackItem converts the given stack item to {{$e.Name}}. */
+export function {{$e.FuncName}}FromStackItem(item: StackItem): {{$e.Name}} {
+{{- template "FROMITEM" $e}}
+}
+
+/**
+ * {{$e.FuncName}}sFromApplicationLog retrieves a set of all emitted events
+ * with {{quote $e.ManifestName}} name from the given application log.
+ */
+export function {{$e.FuncName}}sFromApplicationLog(log: ApplicationLog): {{$e.Name}}[] {
+  const res: {{$e.Name}}[] = [];
+  for (let i = 0; i < log.executions.length; i++) {
+    const ntfs = log.executions[i].notifications;
+    for (let j = 0; j < ntfs.length; j++) {
+      if (ntfs[j].eventname !== {{quote $e.ManifestName}}) {
+        continue;
+      }
+      try {
+        res.push({{$e.FuncName}}FromStackItem(ntfs[j].state));
+      } catch (err) {
+        throw new Error(` + "`" + `failed to deserialize {{$e.Name}} from stackitem (execution #${i}, event #${j}): ${err}` + "`" + `);
+      }
+    }
+  }
+  return res;
+}
+{{- end}}
+{{- range .Helpers}}
+
+{{.}}
+{{- end}}
+`
+
+const (
+	argsTmpl   = `{{define "ARGS"}}{{range $i, $a := .Arguments}}{{if $i}}, {{end}}{{$a.Name}}: {{$a.Type}}{{end}}{{end}}`
+	paramsTmpl = `{{define "PARAMS"}}{{range $i, $a := .Arguments}}{{if $i}}, {{end}}{{$a.Encoder}}{{end}}{{end}}`
+	itemTmpl   = `{{define "FROMITEM"}}
+  const arr = itemToArray(item);
+  if (arr.length !== {{len .Fields}}) {
+    throw new Error("wrong number of structure elements");
+  }
+  return {
+{{- range $i, $f := .Fields}}
+    {{$f.Name}}: {{$f.Decoder}},
+{{- end}}
+  };
+{{- end}}`
+)
+
+type (
+	contractTmpl struct {
+		ContractName string
+		Hash         string
+		SafeMethods  []methodTmpl
+		Methods      []methodTmpl
+		NamedTypes   []*structTmpl
+		Events       []*structTmpl
+		HasIterator  bool
+		Helpers      []string
+	}
+
+	methodTmpl struct {
+		Name       string
+		NameABI    string
+		Arguments  []paramTmpl
+		ReturnType string
+		// Decoder is an expression converting InvokeResult stored in res
+		// into ReturnType, it's empty for void methods.
+		Decoder string
+	}
+
+	paramTmpl struct {
+		Name    string
+		Type    string
+		Encoder string
+	}
+
+	structTmpl struct {
+		Name         string
+		ManifestName string
+		// FuncName is a prefix of functions related to this structure.
+		FuncName string
+		Fields   []fieldTmpl
+		// ToParam denotes whether the structure is used as a parameter.
+		ToParam bool
+	}
+
+	fieldTmpl struct {
+		Name    string
+		Type    string
+		Decoder string
+		Encoder string
+	}
+
+	// generator holds the state of a single Generate call.
+	generator struct {
+		cfg     *binding.Config
+		named   map[string]*structTmpl
+		helpers map[string]bool
+	}
+)
+
+// tsReserved contains TypeScript reserved words that can't be used as
+// parameter names (and "res" that is used in the generated methods).
+var tsReserved = map[string]bool{
+	"arguments": true, "await": true, "break": true, "case": true, "catch": true,
+	"class": true, "const": true, "continue": true, "debugger": true,
+	"default": true, "delete": true, "do": true, "else": true, "enum": true,
+	"eval": true, "export": true, "extends": true, "false": true,
+	"finally": true, "for": true, "function": true, "if": true,
+	"implements": true, "import": true, "in": true, "instanceof": true,
+	"interface": true, "let": true, "new": true, "null": true,
+	"package": true, "private": true, "protected": true, "public": true,
+	"res": true, "return": true, "static": true, "super": true, "switch": true,
+	"this": true, "throw": true, "true": true, "try": true, "typeof": true,
+	"undefined": true, "var": true, "void": true, "while": true, "with": true,
+	"yield": true,
+}
+
+// tsMembers contains names of class members that can't be used as method
+// names.
+var tsMembers = map[string]bool{
+	"actor": true, "constructor": true, "hash": true, "invoker": true,
+}
+
+// Generate writes TypeScript file containing smartcontract bindings to the
+// `cfg.Output`. It doesn't check manifest from Config for validity, incorrect
+// manifest can lead to unexpected results.
+func Generate(cfg binding.Config) error {
+	var (
+		g = &generator{
+			cfg:     &cfg,
+			named:   make(map[string]*structTmpl, len(cfg.NamedTypes)),
+			helpers: make(map[string]bool),
+		}
+		ctr = contractTmpl{ContractName: cfg.Manifest.Name}
+	)
+	if !cfg.Hash.Equals(util.Uint160{}) {
+		ctr.Hash = "0x" + cfg.Hash.StringLE()
+	}
+
+	// Named types are created first to be referenced from other types.
+	for name := range cfg.NamedTypes {
+		st := &structTmpl{
+			Name:         toTypeName(name),
+			ManifestName: name,
+			FuncName:     lowerFirst(toTypeName(name)),
+		}
+		g.named[name] = st
+		ctr.NamedTypes = append(ctr.NamedTypes, st)
+	}
+	sort.Slice(ctr.NamedTypes, func(i, j int) bool {
+		return ctr.NamedTypes[i].Name < ctr.NamedTypes[j].Name
+	})
+	for _, st := range ctr.NamedTypes {
+		et := cfg.NamedTypes[st.ManifestName]
+		seen := make(map[string]bool)
+		for _, f := range et.Fields {
+			name := lowerFirst(rpcbinding.ToParameterBindingName(f.Field))
+			if name == "" || seen[name] {
+				return fmt.Errorf("named type `%s` has invalid or duplicating field name `%s`", st.ManifestName, f.Field)
+			}
+			seen[name] = true
+			st.Fields = append(st.Fields, fieldTmpl{
+				Name:    name,
+				Type:    g.tsType(f.ExtendedType, false),
+				Decoder: g.decoder(f.ExtendedType, fmt.Sprintf("arr[%d]", len(st.Fields)), 0),
+			})
+		}
+	}
+
+	tmpl := binding.TemplateFromManifest(cfg, func(string, smartcontract.ParamType, *binding.Config) (string, string) {
+		return "", "" // Types are converted below.
+	})
+	for _, m := range tmpl.Methods {
+		abim := cfg.Manifest.ABI.GetMethod(m.NameABI, len(m.Arguments))
+		mt := methodTmpl{
+			Name:    safeName(lowerFirst(m.Name), tsMembers),
+			NameABI: m.NameABI,
+		}
+		var names = make(map[string]bool)
+		for i := range m.Arguments {
+			et := g.extendedType(abim.Name+"."+abim.Parameters[i].Name, abim.Parameters[i].Type)
+			name := safeName(abim.Parameters[i].Name, tsReserved)
+			for names[name] {
+				name += "_"
+			}
+			names[name] = true
+			mt.Arguments = append(mt.Arguments, paramTmpl{
+				Name:    name,
+				Type:    g.tsType(et, true),
+				Encoder: g.encoder(et, name, true, 0),
+			})
+		}
+		if !abim.Safe {
+			ctr.Methods = append(ctr.Methods, mt)
+			continue
+		}
+		et := g.extendedType(abim.Name, abim.ReturnType)
+		switch et.Base {
+		case smartcontract.VoidType:
+			g.use("checkHalt")
+			mt.ReturnType = "void"
+		case smartcontract.InteropInterfaceType:
+			g.use("itemToIterator")
+			mt.ReturnType = "IteratorRef"
+			mt.Decoder = "itemToIterator(res)"
+			ctr.HasIterator = true
+		default:
+			g.use("unwrapItem")
+			mt.ReturnType = g.tsType(et, false)
+			mt.Decoder = g.decoder(et, "unwrapItem(res)", 0)
+		}
+		ctr.SafeMethods = append(ctr.SafeMethods, mt)
+	}
+
+	for _, ev := range cfg.Manifest.ABI.Events {
+		name := rpcbinding.ToEventBindingName(ev.Name)
+		st := &structTmpl{
+			Name:         name,
+			ManifestName: ev.Name,
+			FuncName:     lowerFirst(name),
+		}
+		seen := make(map[string]bool)
+		for _, p := range ev.Parameters {
+			pName := rpcbinding.ToParameterBindingName(p.Name)
+			fName := lowerFirst(pName)
+			if fName == "" || seen[fName] {
+				return fmt.Errorf("event `%s` has invalid or duplicating parameter name `%s`", ev.Name, p.Name)
+			}
+			seen[fName] = true
+			et, ok := cfg.Types[name+"."+pName]
+			if !ok {
+				et = binding.ExtendedType{Base: p.Type}
+			}
+			st.Fields = append(st.Fields, fieldTmpl{
+				Name:    fName,
+				Type:    g.tsType(et, false),
+				Decoder: g.decoder(et, fmt.Sprintf("arr[%d]", len(st.Fields)), 0),
+			})
+		}
+		ctr.Events = append(ctr.Events, st)
+	}
+	if len(ctr.NamedTypes) != 0 || len(ctr.Events) != 0 {
+		g.use("itemToArray")
+	}
+
+	// Encoders of named types can refer to other named types, so they're
+	// generated until there are no new ones.
+	for done := false; !done; {
+		done = true
+		for _, st := range ctr.NamedTypes {
+			if !st.ToParam || st.Fields == nil || st.Fields[0].Encoder != "" {
+				continue
+			}
+			done = false
+			et := cfg.NamedTypes[st.ManifestName]
+			for i := range st.Fields {
+				st.Fields[i].Encoder = g.encoder(et.Fields[i].ExtendedType, "v."+st.Fields[i].Name, false, 0)
+			}
+		}
+	}
+	for _, st := range ctr.NamedTypes {
+		if st.ToParam {
+			g.use("arrayParam")
+		}
+	}
+
+	for _, h := range tsHelpers {
+		if g.helpers[h.name] {
+			ctr.Helpers = append(ctr.Helpers, h.code)
+		}
+	}
+
+	var srcTemplate = template.Must(template.New("generate").Funcs(template.FuncMap{
+		"backquote": func(s string) string { return "`" + s + "`" },
+		"quote": func(s string) string {
+			b, _ := json.Marshal(s)
+			return string(b)
+		},
+	}).Parse(argsTmpl + paramsTmpl + itemTmpl + srcTmpl))
+
+	err := srcTemplate.Execute(cfg.Output, ctr)
+	if err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+	return nil
+}
+
+// extendedType returns extended type data for the given method parameter or
+// return value using configured types and overrides.
+func (g *generator) extendedType(name string, typ smartcontract.ParamType) binding.ExtendedType {
+	if et, ok := g.cfg.Types[name]; ok {
+		return et
+	}
+	if over, ok := g.cfg.Overrides[name]; ok {
+		if et, ok := overrideToExtended(over); ok && (et.Base == typ ||
+			typ == smartcontract.AnyType || typ == smartcontract.ByteArrayType && et.Base != smartcontract.ArrayType) {
+			return et
+		}
+	}
+	return binding.ExtendedType{Base: typ}
+}
+
+// overrideToExtended converts well-known interop types (and arrays of them)
+// used in Go overrides into the extended type.
+func overrideToExtended(over binding.Override) (binding.ExtendedType, bool) {
+	if over.Package != "github.com/nspcc-dev/neo-go/pkg/interop" {
+		return binding.ExtendedType{}, false
+	}
+	var (
+		name = strings.TrimPrefix(over.TypeName, "interop.")
+		dims int
+	)
+	for strings.HasPrefix(name, "[]") {
+		name = strings.TrimPrefix(name, "[]")
+		dims++
+	}
+	var et binding.ExtendedType
+	switch strings.TrimPrefix(name, "interop.") {
+	case "Hash160":
+		et.Base = smartcontract.Hash160Type
+	case "Hash256":
+		et.Base = smartcontract.Hash256Type
+	case "PublicKey":
+		et.Base = smartcontract.PublicKeyType
+	case "Signature":
+		et.Base = smartcontract.SignatureType
+	default:
+		return binding.ExtendedType{}, false
+	}
+	for ; dims > 0; dims-- {
+		sub := et
+		et = binding.ExtendedType{Base: smartcontract.ArrayType, Value: &sub}
+	}
+	return et, true
+}
+
+// tsType returns TypeScript type for the given extended type. Parameters
+// (param is true) of Any type are ContractParam while results are StackItem.
+func (g *generator) tsType(et binding.ExtendedType, param bool) string {
+	var raw = "StackItem"
+	if param {
+		raw = "ContractParam"
+	}
+	switch et.Base {
+	case smartcontract.BoolType:
+		return "boolean"
+	case smartcontract.IntegerType:
+		return "bigint"
+	case smartcontract.ByteArrayType, smartcontract.SignatureType:
+		return "Uint8Array"
+	case smartcontract.StringType, smartcontract.Hash160Type, smartcontract.Hash256Type, smartcontract.PublicKeyType:
+		return "string"
+	case smartcontract.ArrayType:
+		if st, ok := g.named[et.Name]; ok {
+			return st.Name
+		}
+		if et.Value != nil {
+			return g.tsType(*et.Value, param) + "[]"
+		}
+		return raw + "[]"
+	case smartcontract.MapType:
+		var vt = raw
+		if et.Value != nil {
+			vt = g.tsType(*et.Value, param)
+		}
+		return "Map<" + g.tsType(binding.ExtendedType{Base: et.Key}, param) + ", " + vt + ">"
+	case smartcontract.VoidType:
+		return "void"
+	default: // Any, InteropInterface.
+		return raw
+	}
+}
+
+// decoder returns an expression converting StackItem v into the given type.
+// Depth is used to name variables of nested expressions.
+func (g *generator) decoder(et binding.ExtendedType, v string, depth int) string {
+	var conv string
+	switch et.Base {
+	case smartcontract.BoolType:
+		conv = "itemToBoolean"
+	case smartcontract.IntegerType:
+		conv = "itemToBigInt"
+	case smartcontract.ByteArrayType, smartcontract.SignatureType:
+		conv = "itemToBytes"
+	case smartcontract.StringType:
+		conv = "itemToString"
+	case smartcontract.Hash160Type:
+		conv = "itemToHash160"
+	case smartcontract.Hash256Type:
+		conv = "itemToHash256"
+	case smartcontract.PublicKeyType:
+		conv = "itemToPublicKey"
+	case smartcontract.ArrayType:
+		if st, ok := g.named[et.Name]; ok {
+			return st.FuncName + "FromStackItem(" + v + ")"
+		}
+		g.use("itemToArray")
+		if et.Value == nil {
+			return "itemToArray(" + v + ")"
+		}
+		e := fmt.Sprintf("e%d", depth)
+		return "itemToArray(" + v + ").map((" + e + ") => " + g.decoder(*et.Value, e, depth+1) + ")"
+	case smartcontract.MapType:
+		g.use("itemToMap")
+		var (
+			e   = fmt.Sprintf("e%d", depth)
+			kt  = binding.ExtendedType{Base: et.Key}
+			val = e + ".value"
+			vt  = "StackItem"
+		)
+		if et.Value != nil {
+			val = g.decoder(*et.Value, val, depth+1)
+			vt = g.tsType(*et.Value, false)
+		}
+		return "new Map(itemToMap(" + v + ").map((" + e + "): [" + g.tsType(kt, false) + ", " + vt + "] => [" +
+			g.decoder(kt, e+".key", depth+1) + ", " + val + "]))"
+	default: // Any, InteropInterface.
+		return v
+	}
+	g.use(conv)
+	return conv + "(" + v + ")"
+}
+
+// encoder returns an expression converting v of the given type into
+// ContractParam. If param is false, v is a structure field and its values of
+// Any type are StackItem.
+func (g *generator) encoder(et binding.ExtendedType, v string, param bool, depth int) string {
+	var conv string
+	switch et.Base {
+	case smartcontract.BoolType:
+		conv = "boolParam"
+	case smartcontract.IntegerType:
+		conv = "intParam"
+	case smartcontract.ByteArrayType:
+		conv = "bytesParam"
+	case smartcontract.SignatureType:
+		conv = "signatureParam"
+	case smartcontract.StringType:
+		conv = "stringParam"
+	case smartcontract.Hash160Type:
+		conv = "hash160Param"
+	case smartcontract.Hash256Type:
+		conv = "hash256Param"
+	case smartcontract.PublicKeyType:
+		conv = "publicKeyParam"
+	case smartcontract.ArrayType:
+		if st, ok := g.named[et.Name]; ok {
+			st.ToParam = true
+			return st.FuncName + "ToParam(" + v + ")"
+		}
+		g.use("arrayParam")
+		if et.Value == nil {
+			if param {
+				return "arrayParam(" + v + ")"
+			}
+			g.use("itemToParam")
+			return "arrayParam(" + v + ".map(itemToParam))"
+		}
+		e := fmt.Sprintf("e%d", depth)
+		return "arrayParam(" + v + ".map((" + e + ") => " + g.encoder(*et.Value, e, param, depth+1) + "))"
+	case smartcontract.MapType:
+		g.use("mapParam")
+		var (
+			k   = fmt.Sprintf("k%d", depth)
+			e   = fmt.Sprintf("e%d", depth)
+			val = g.encoder(binding.ExtendedType{Base: smartcontract.AnyType}, e, param, depth+1)
+		)
+		if et.Value != nil {
+			val = g.encoder(*et.Value, e, param, depth+1)
+		}
+		return "mapParam(Array.from(" + v + ", ([" + k + ", " + e + "]) => ({ key: " +
+			g.encoder(binding.ExtendedType{Base: et.Key}, k, param, depth+1) + ", value: " + val + " })))"
+	default: // Any, InteropInterface.
+		if param {
+			return v
+		}
+		conv = "itemToParam"
+	}
+	g.use(conv)
+	return conv + "(" + v + ")"
+}
+
+// use marks the helper with the given name and all of its dependencies as
+// used.
+func (g *generator) use(name string) {
+	if g.helpers[name] {
+		return
+	}
+	g.helpers[name] = true
+	for _, h := range tsHelpers {
+		if h.name == name {
+			for _, d := range h.deps {
+				g.use(d)
+			}
+			return
+		}
+	}
+	panic("unknown helper " + name)
+}
+
+// safeName returns a name that is not in the given reserved set.
+func safeName(s string, reserved map[string]bool) string {
+	for reserved[s] {
+		s += "_"
+	}
+	return s
+}
+
+func toTypeName(s string) string {
+	return strings.ReplaceAll(upperFirst(s), ".", "")
+}
+
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[0:1]) + s[1:]
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[0:1]) + s[1:]
+}
@@ -0,0 +1,265 @@
+package tsbinding
+
+// tsHelper is a TypeScript function that is added to the generated file if
+// it's used by the binding.
+type tsHelper struct {
+	name string
+	deps []string
+	code string
+}
+
+// tsHelpers contains all helpers in the order they're added to the generated
+// file.
+var tsHelpers = []tsHelper{
+	{
+		name: "checkHalt",
+		code: `function checkHalt(res: InvokeResult): void {
+  if (res.state !== "HALT") {
+    throw new Error(` + "`" + `invocation failed: ${res.exception ?? res.state}` + "`" + `);
+  }
+}`,
+	},
+	{
+		name: "unwrapItem",
+		deps: []string{"checkHalt"},
+		code: `function unwrapItem(res: InvokeResult): StackItem {
+  checkHalt(res);
+  if (res.stack.length !== 1) {
+    throw new Error(` + "`" + `result stack contains ${res.stack.length} items instead of 1` + "`" + `);
+  }
+  return res.stack[0];
+}`,
+	},
+	{
+		name: "itemToIterator",
+		deps: []string{"unwrapItem"},
+		code: `function itemToIterator(res: InvokeResult): IteratorRef {
+  const item = unwrapItem(res);
+  if (item.type !== "InteropInterface") {
+    throw new Error(` + "`" + `${item.type} is not an iterator` + "`" + `);
+  }
+  if (res.session === undefined || item.id === undefined) {
+    throw new Error("no session or iterator ID returned, sessions are likely disabled on the server");
+  }
+  return { session: res.session, id: item.id };
+}`,
+	},
+	{
+		name: "base64ToBytes",
+		code: `function base64ToBytes(s: string): Uint8Array {
+  return Uint8Array.from(atob(s), (c) => c.charCodeAt(0));
+}`,
+	},
+	{
+		name: "bytesToBase64",
+		code: `function bytesToBase64(b: Uint8Array): string {
+  return btoa(Array.from(b, (c) => String.fromCharCode(c)).join(""));
+}`,
+	},
+	{
+		name: "bytesToHex",
+		code: `function bytesToHex(b: Uint8Array): string {
+  return Array.from(b, (c) => c.toString(16).padStart(2, "0")).join("");
+}`,
+	},
+	{
+		name: "itemToBytes",
+		deps: []string{"base64ToBytes"},
+		code: `function itemToBytes(item: StackItem): Uint8Array {
+  if (item.type !== "ByteString" && item.type !== "Buffer") {
+    throw new Error(` + "`" + `${item.type} is not a byte array` + "`" + `);
+  }
+  return base64ToBytes(item.value as string);
+}`,
+	},
+	{
+		name: "itemToBoolean",
+		deps: []string{"itemToBytes"},
+		code: `function itemToBoolean(item: StackItem): boolean {
+  switch (item.type) {
+    case "Boolean":
+      return item.value as boolean;
+    case "Integer":
+      return BigInt(item.value) !== 0n;
+    case "ByteString":
+    case "Buffer":
+      return itemToBytes(item).some((c) => c !== 0);
+    default:
+      throw new Error(` + "`" + `${item.type} is not a boolean` + "`" + `);
+  }
+}`,
+	},
+	{
+		name: "itemToBigInt",
+		deps: []string{"itemToBytes"},
+		code: `function itemToBigInt(item: StackItem): bigint {
+  switch (item.type) {
+    case "Integer":
+      return BigInt(item.value);
+    case "Boolean":
+      return item.value ? 1n : 0n;
+    case "ByteString":
+    case "Buffer": {
+      // Little-endian two's complement.
+      const b = itemToBytes(item);
+      let res = 0n;
+      for (let i = b.length - 1; i >= 0; i--) {
+        res = (res << 8n) | BigInt(b[i]);
+      }
+      if (b.length > 0 && (b[b.length - 1] & 0x80) !== 0) {
+        res -= 1n << BigInt(8 * b.length);
+      }
+      return res;
+    }
+    default:
+      throw new Error(` + "`" + `${item.type} is not an integer` + "`" + `);
+  }
+}`,
+	},
+	{
+		name: "itemToString",
+		deps: []string{"itemToBytes"},
+		code: `function itemToString(item: StackItem): string {
+  return new TextDecoder("utf-8", { fatal: true }).decode(itemToBytes(item));
+}`,
+	},
+	{
+		name: "bytesToHash",
+		deps: []string{"bytesToHex"},
+		code: `function bytesToHash(b: Uint8Array, size: number): string {
+  if (b.length !== size) {
+    throw new Error(` + "`" + `invalid hash length ${b.length}` + "`" + `);
+  }
+  return "0x" + bytesToHex(b.slice().reverse());
+}`,
+	},
+	{
+		name: "itemToHash160",
+		deps: []string{"itemToBytes", "bytesToHash"},
+		code: `function itemToHash160(item: StackItem): string {
+  return bytesToHash(itemToBytes(item), 20);
+}`,
+	},
+	{
+		name: "itemToHash256",
+		deps: []string{"itemToBytes", "bytesToHash"},
+		code: `function itemToHash256(item: StackItem): string {
+  return bytesToHash(itemToBytes(item), 32);
+}`,
+	},
+	{
+		name: "itemToPublicKey",
+		deps: []string{"itemToBytes", "bytesToHex"},
+		code: `function itemToPublicKey(item: StackItem): string {
+  const b = itemToBytes(item);
+  if (b.length !== 33) {
+    throw new Error(` + "`" + `invalid public key length ${b.length}` + "`" + `);
+  }
+  return bytesToHex(b);
+}`,
+	},
+	{
+		name: "itemToArray",
+		code: `function itemToArray(item: StackItem): StackItem[] {
+  if (item.type !== "Array" && item.type !== "Struct") {
+    throw new Error(` + "`" + `${item.type} is not an array` + "`" + `);
+  }
+  return item.value as StackItem[];
+}`,
+	},
+	{
+		name: "itemToMap",
+		code: `function itemToMap(item: StackItem): { key: StackItem; value: StackItem }[] {
+  if (item.type !== "Map") {
+    throw new Error(` + "`" + `${item.type} is not a map` + "`" + `);
+  }
+  return item.value as { key: StackItem; value: StackItem }[];
+}`,
+	},
+	{
+		name: "boolParam",
+		code: `function boolParam(v: boolean): ContractParam {
+  return { type: "Boolean", value: v };
+}`,
+	},
+	{
+		name: "intParam",
+		code: `function intParam(v: bigint): ContractParam {
+  return { type: "Integer", value: v.toString() };
+}`,
+	},
+	{
+		name: "bytesParam",
+		deps: []string{"bytesToBase64"},
+		code: `function bytesParam(v: Uint8Array): ContractParam {
+  return { type: "ByteArray", value: bytesToBase64(v) };
+}`,
+	},
+	{
+		name: "signatureParam",
+		deps: []string{"bytesToBase64"},
+		code: `function signatureParam(v: Uint8Array): ContractParam {
+  return { type: "Signature", value: bytesToBase64(v) };
+}`,
+	},
+	{
+		name: "stringParam",
+		code: `function stringParam(v: string): ContractParam {
+  return { type: "String", value: v };
+}`,
+	},
+	{
+		name: "hash160Param",
+		code: `function hash160Param(v: string): ContractParam {
+  return { type: "Hash160", value: v };
+}`,
+	},
+	{
+		name: "hash256Param",
+		code: `function hash256Param(v: string): ContractParam {
+  return { type: "Hash256", value: v };
+}`,
+	},
+	{
+		name: "publicKeyParam",
+		code: `function publicKeyParam(v: string): ContractParam {
+  return { type: "PublicKey", value: v };
+}`,
+	},
+	{
+		name: "arrayParam",
+		code: `function arrayParam(v: ContractParam[]): ContractParam {
+  return { type: "Array", value: v };
+}`,
+	},
+	{
+		name: "mapParam",
+		code: `function mapParam(v: { key: ContractParam; value: ContractParam }[]): ContractParam {
+  return { type: "Map", value: v };
+}`,
+	},
+	{
+		name: "itemToParam",
+		deps: []string{"itemToArray", "itemToMap", "arrayParam", "mapParam"},
+		code: `function itemToParam(item: StackItem): ContractParam {
+  switch (item.type) {
+    case "Any":
+      return { type: "Any" };
+    case "Boolean":
+      return { type: "Boolean", value: item.value };
+    case "Integer":
+      return { type: "Integer", value: item.value };
+    case "ByteString":
+    case "Buffer":
+      return { type: "ByteArray", value: item.value };
+    case "Array":
+    case "Struct":
+      return arrayParam(itemToArray(item).map(itemToParam));
+    case "Map":
+      return mapParam(itemToMap(item).map((e) => ({ key: itemToParam(e.key), value: itemToParam(e.value) })));
+    default:
+      throw new Error(` + "`" + `${item.type} can't be used as a parameter` + "`" + `);
+  }
+}`,
+	},
+}